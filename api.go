@@ -0,0 +1,172 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// API is the subset of the AWS SDK S3 client used by S3. *s3.Client implements it, and other
+// implementations can be passed to NewFromAPI to stub, record or wrap the requests made.
+type API interface {
+	CreateBucket(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error)
+	ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	DeleteBucket(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
+
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	GetObjectAttributes(ctx context.Context, params *s3.GetObjectAttributesInput, optFns ...func(*s3.Options)) (*s3.GetObjectAttributesOutput, error)
+	GetObjectTagging(ctx context.Context, params *s3.GetObjectTaggingInput, optFns ...func(*s3.Options)) (*s3.GetObjectTaggingOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	UploadPartCopy(ctx context.Context, params *s3.UploadPartCopyInput, optFns ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	ListParts(ctx context.Context, params *s3.ListPartsInput, optFns ...func(*s3.Options)) (*s3.ListPartsOutput, error)
+	ListMultipartUploads(ctx context.Context, params *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error)
+}
+
+var _ API = (*s3.Client)(nil)
+
+// NewFromAPI creates an S3 wrapper that sends every request through api.
+//
+// Retry, Faults and LogRequests configure the SDK client built by New and are ignored here, as is
+// the logging of retries; wrap the client passed in instead. Logger and Telemetry cover the
+// wrapper's methods but are not passed on to the SDK. Presigning needs an *s3.Client, so
+// PresignObject fails when api is another implementation.
+func NewFromAPI(api API, optFns ...func(*Options)) *S3 {
+	o := Options{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	s := &S3{api: api, options: o, telemetry: newTelemetry(o.Telemetry)}
+	if c, ok := api.(*s3.Client); ok {
+		s.Client = c
+		s.endpoint = aws.ToString(c.Options().BaseEndpoint)
+		if r, ok := c.Options().EndpointResolverV2.(*staticResolver); ok {
+			s.endpoint = r.URL.String()
+		}
+	}
+	return s
+}
+
+// client returns the API requests are sent through, falling back to Client for wrappers built as
+// struct literals rather than by New or NewFromAPI.
+func (s *S3) client() API {
+	if s.api != nil {
+		return s.api
+	}
+	return s.Client
+}
+
+// listObjectsV2All returns every object under prefix, following continuation tokens.
+func listObjectsV2All(ctx context.Context, c API, bucket, prefix string) ([]s3types.Object, error) {
+	params := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+	paginator := s3.NewListObjectsV2Paginator(c, params)
+
+	contents := make([]s3types.Object, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, page.Contents...)
+	}
+
+	return contents, nil
+}
+
+// listPartsAll returns every part uploaded so far to a multipart upload.
+func listPartsAll(ctx context.Context, c API, bucket, key, uploadID string) ([]s3types.Part, error) {
+	params := &s3.ListPartsInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	}
+	paginator := s3.NewListPartsPaginator(c, params)
+
+	parts := make([]s3types.Part, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, page.Parts...)
+	}
+
+	return parts, nil
+}
+
+// listMultipartUploadsAll returns every incomplete multipart upload under prefix.
+func listMultipartUploadsAll(ctx context.Context, c API, bucket, prefix string) ([]s3types.MultipartUpload, error) {
+	params := &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+	paginator := s3.NewListMultipartUploadsPaginator(c, params)
+
+	uploads := make([]s3types.MultipartUpload, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, page.Uploads...)
+	}
+
+	return uploads, nil
+}
+
+// objectPartsAll returns the parts of an object created by a multipart upload, or none for other objects.
+func objectPartsAll(ctx context.Context, c API, bucket, key string) ([]s3types.ObjectPart, error) {
+	params := &s3.GetObjectAttributesInput{
+		Bucket:           aws.String(bucket),
+		Key:              aws.String(key),
+		ObjectAttributes: []s3types.ObjectAttributes{s3types.ObjectAttributesObjectParts},
+	}
+
+	parts := make([]s3types.ObjectPart, 0)
+	for {
+		out, err := c.GetObjectAttributes(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if out.ObjectParts == nil {
+			return parts, nil
+		}
+		parts = append(parts, out.ObjectParts.Parts...)
+		if !aws.ToBool(out.ObjectParts.IsTruncated) {
+			return parts, nil
+		}
+		params.PartNumberMarker = out.ObjectParts.NextPartNumberMarker
+	}
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/s3test"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// stubAPI implements API with a function per method, so each test stubs only the requests it
+// expects. Calling a method without a function fails the request.
+//
+// The list functions return every result in a single page and are used when the matching request
+// function is not set.
+type stubAPI struct {
+	createBucket            func(ctx context.Context, params *s3.CreateBucketInput) (*s3.CreateBucketOutput, error)
+	listBuckets             func(ctx context.Context, params *s3.ListBucketsInput) (*s3.ListBucketsOutput, error)
+	headBucket              func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error)
+	deleteBucket            func(ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error)
+	getObject               func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+	headObject              func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
+	getObjectAttributes     func(ctx context.Context, params *s3.GetObjectAttributesInput) (*s3.GetObjectAttributesOutput, error)
+	getObjectTagging        func(ctx context.Context, params *s3.GetObjectTaggingInput) (*s3.GetObjectTaggingOutput, error)
+	putObject               func(ctx context.Context, params *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+	copyObject              func(ctx context.Context, params *s3.CopyObjectInput) (*s3.CopyObjectOutput, error)
+	deleteObject            func(ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error)
+	deleteObjects           func(ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
+	listObjectsV2           func(ctx context.Context, params *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error)
+	createMultipartUpload   func(ctx context.Context, params *s3.CreateMultipartUploadInput) (*s3.CreateMultipartUploadOutput, error)
+	uploadPart              func(ctx context.Context, params *s3.UploadPartInput) (*s3.UploadPartOutput, error)
+	uploadPartCopy          func(ctx context.Context, params *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error)
+	completeMultipartUpload func(ctx context.Context, params *s3.CompleteMultipartUploadInput) (*s3.CompleteMultipartUploadOutput, error)
+	abortMultipartUpload    func(ctx context.Context, params *s3.AbortMultipartUploadInput) (*s3.AbortMultipartUploadOutput, error)
+	listParts               func(ctx context.Context, params *s3.ListPartsInput) (*s3.ListPartsOutput, error)
+	listMultipartUploads    func(ctx context.Context, params *s3.ListMultipartUploadsInput) (*s3.ListMultipartUploadsOutput, error)
+
+	listObjects func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error)
+	listPartsOf func(ctx context.Context, bucket, key, uploadID string) ([]s3types.Part, error)
+	listUploads func(ctx context.Context, bucket, prefix string) ([]s3types.MultipartUpload, error)
+	objectParts func(ctx context.Context, bucket, key string) ([]s3types.ObjectPart, error)
+}
+
+var _ API = (*stubAPI)(nil)
+
+// newStubbed returns a wrapper of a new stubAPI.
+func newStubbed() (*S3, *stubAPI) {
+	api := &stubAPI{}
+	return NewFromAPI(api), api
+}
+
+func unexpected(op string) error {
+	return fmt.Errorf("unexpected %s request", op)
+}
+
+func (a *stubAPI) CreateBucket(ctx context.Context, params *s3.CreateBucketInput, _ ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+	if a.createBucket == nil {
+		return nil, unexpected("CreateBucket")
+	}
+	return a.createBucket(ctx, params)
+}
+
+func (a *stubAPI) ListBuckets(ctx context.Context, params *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+	if a.listBuckets == nil {
+		return nil, unexpected("ListBuckets")
+	}
+	return a.listBuckets(ctx, params)
+}
+
+func (a *stubAPI) HeadBucket(ctx context.Context, params *s3.HeadBucketInput, _ ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	if a.headBucket == nil {
+		return nil, unexpected("HeadBucket")
+	}
+	return a.headBucket(ctx, params)
+}
+
+func (a *stubAPI) DeleteBucket(ctx context.Context, params *s3.DeleteBucketInput, _ ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+	if a.deleteBucket == nil {
+		return nil, unexpected("DeleteBucket")
+	}
+	return a.deleteBucket(ctx, params)
+}
+
+func (a *stubAPI) GetObject(ctx context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	if a.getObject == nil {
+		return nil, unexpected("GetObject")
+	}
+	return a.getObject(ctx, params)
+}
+
+func (a *stubAPI) HeadObject(ctx context.Context, params *s3.HeadObjectInput, _ ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	if a.headObject == nil {
+		return nil, unexpected("HeadObject")
+	}
+	return a.headObject(ctx, params)
+}
+
+func (a *stubAPI) GetObjectAttributes(ctx context.Context, params *s3.GetObjectAttributesInput, _ ...func(*s3.Options)) (*s3.GetObjectAttributesOutput, error) {
+	switch {
+	case a.getObjectAttributes != nil:
+		return a.getObjectAttributes(ctx, params)
+	case a.objectParts != nil:
+		parts, err := a.objectParts(ctx, aws.ToString(params.Bucket), aws.ToString(params.Key))
+		if err != nil {
+			return nil, err
+		}
+		if parts == nil {
+			return &s3.GetObjectAttributesOutput{}, nil
+		}
+		return &s3.GetObjectAttributesOutput{ObjectParts: &s3types.GetObjectAttributesParts{Parts: parts}}, nil
+	}
+	return nil, unexpected("GetObjectAttributes")
+}
+
+func (a *stubAPI) GetObjectTagging(ctx context.Context, params *s3.GetObjectTaggingInput, _ ...func(*s3.Options)) (*s3.GetObjectTaggingOutput, error) {
+	if a.getObjectTagging == nil {
+		return nil, unexpected("GetObjectTagging")
+	}
+	return a.getObjectTagging(ctx, params)
+}
+
+func (a *stubAPI) PutObject(ctx context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	if a.putObject == nil {
+		return nil, unexpected("PutObject")
+	}
+	return a.putObject(ctx, params)
+}
+
+func (a *stubAPI) CopyObject(ctx context.Context, params *s3.CopyObjectInput, _ ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+	if a.copyObject == nil {
+		return nil, unexpected("CopyObject")
+	}
+	return a.copyObject(ctx, params)
+}
+
+func (a *stubAPI) DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, _ ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	if a.deleteObject == nil {
+		return nil, unexpected("DeleteObject")
+	}
+	return a.deleteObject(ctx, params)
+}
+
+func (a *stubAPI) DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	if a.deleteObjects == nil {
+		return nil, unexpected("DeleteObjects")
+	}
+	return a.deleteObjects(ctx, params)
+}
+
+func (a *stubAPI) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	switch {
+	case a.listObjectsV2 != nil:
+		return a.listObjectsV2(ctx, params)
+	case a.listObjects != nil:
+		objects, err := a.listObjects(ctx, aws.ToString(params.Bucket), aws.ToString(params.Prefix))
+		if err != nil {
+			return nil, err
+		}
+		return &s3.ListObjectsV2Output{Contents: objects}, nil
+	}
+	return nil, unexpected("ListObjectsV2")
+}
+
+func (a *stubAPI) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	if a.createMultipartUpload == nil {
+		return nil, unexpected("CreateMultipartUpload")
+	}
+	return a.createMultipartUpload(ctx, params)
+}
+
+func (a *stubAPI) UploadPart(ctx context.Context, params *s3.UploadPartInput, _ ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	if a.uploadPart == nil {
+		return nil, unexpected("UploadPart")
+	}
+	return a.uploadPart(ctx, params)
+}
+
+func (a *stubAPI) UploadPartCopy(ctx context.Context, params *s3.UploadPartCopyInput, _ ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error) {
+	if a.uploadPartCopy == nil {
+		return nil, unexpected("UploadPartCopy")
+	}
+	return a.uploadPartCopy(ctx, params)
+}
+
+func (a *stubAPI) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	if a.completeMultipartUpload == nil {
+		return nil, unexpected("CompleteMultipartUpload")
+	}
+	return a.completeMultipartUpload(ctx, params)
+}
+
+func (a *stubAPI) AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, _ ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	if a.abortMultipartUpload == nil {
+		return nil, unexpected("AbortMultipartUpload")
+	}
+	return a.abortMultipartUpload(ctx, params)
+}
+
+func (a *stubAPI) ListParts(ctx context.Context, params *s3.ListPartsInput, _ ...func(*s3.Options)) (*s3.ListPartsOutput, error) {
+	switch {
+	case a.listParts != nil:
+		return a.listParts(ctx, params)
+	case a.listPartsOf != nil:
+		parts, err := a.listPartsOf(ctx, aws.ToString(params.Bucket), aws.ToString(params.Key), aws.ToString(params.UploadId))
+		if err != nil {
+			return nil, err
+		}
+		return &s3.ListPartsOutput{Parts: parts}, nil
+	}
+	return nil, unexpected("ListParts")
+}
+
+func (a *stubAPI) ListMultipartUploads(ctx context.Context, params *s3.ListMultipartUploadsInput, _ ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error) {
+	switch {
+	case a.listMultipartUploads != nil:
+		return a.listMultipartUploads(ctx, params)
+	case a.listUploads != nil:
+		uploads, err := a.listUploads(ctx, aws.ToString(params.Bucket), aws.ToString(params.Prefix))
+		if err != nil {
+			return nil, err
+		}
+		return &s3.ListMultipartUploadsOutput{Uploads: uploads}, nil
+	}
+	return nil, unexpected("ListMultipartUploads")
+}
+
+// countingAPI wraps another API and counts GetObject requests, as a consumer might to add
+// instrumentation.
+type countingAPI struct {
+	API
+	gets int
+}
+
+func (c *countingAPI) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	c.gets++
+	return c.API.GetObject(ctx, params, optFns...)
+}
+
+var _ = Describe("NewFromAPI", func() {
+	It("sends requests through the supplied implementation", func() {
+		stub := &stubAPI{getObject: func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			return nil, apiErr{code: "NoSuchKey"}
+		}}
+		api := &countingAPI{API: stub}
+		sut := NewFromAPI(api, func(o *Options) {
+			o.Multipart.PartSize = 8 * mib
+		})
+
+		_, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+		Expect(err).To(MatchError(ErrObjectNotFound))
+		Expect(api.gets).To(Equal(1))
+		Expect(sut.Client).To(BeNil())
+		Expect(sut.multipartOptions(types.MultipartOptions{}).PartSize).To(Equal(int64(8 * mib)))
+	})
+
+	It("keeps the SDK client and endpoint of an *s3.Client", func() {
+		built, err := New(context.Background(), "http://localhost:9000", "ak", "sk", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		sut := NewFromAPI(built.Client)
+		Expect(sut.Client).To(BeIdenticalTo(built.Client))
+		Expect(sut.sameEndpoint(built)).To(BeTrue())
+	})
+
+	It("does not presign or copy server-side without an SDK client", func() {
+		sut, _ := newStubbed()
+		other, _ := newStubbed()
+
+		_, err := sut.PresignObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).To(MatchError(ContainSubstring("requires an *s3.Client")))
+		Expect(sut.sameEndpoint(other)).To(BeFalse())
+		Expect(sut.sameEndpoint(sut)).To(BeTrue())
+	})
+
+	It("serves concurrent requests through one stubbed client", func() {
+		var mu sync.Mutex
+		stored := map[string][]byte{}
+		sut, api := newStubbed()
+		api.putObject = func(ctx context.Context, params *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+			data, err := io.ReadAll(params.Body)
+			if err != nil {
+				return nil, err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			stored[aws.ToString(params.Key)] = data
+			return &s3.PutObjectOutput{}, nil
+		}
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(stored[aws.ToString(params.Key)]))}, nil
+		}
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				key := fmt.Sprintf("key-%d", i)
+				Expect(sut.PutObject(context.Background(), "bucket-a", key, strings.NewReader(key))).To(Succeed())
+				data, err := sut.FetchObject(context.Background(), key, "bucket-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal(key))
+			}()
+		}
+		wg.Wait()
+		Expect(stored).To(HaveLen(8))
+	})
+})
+
+var _ = Describe("S3 struct literals", func() {
+	It("send requests through Client", func() {
+		server := s3test.NewServer()
+		DeferCleanup(server.Close)
+		built, err := New(context.Background(), server.URL, "ak", "sk", "")
+		Expect(err).NotTo(HaveOccurred())
+		ctx := context.Background()
+
+		sut := &S3{Client: built.Client}
+		Expect(sut.CreateBucket(ctx, "bucket-a")).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "key-a", strings.NewReader("data"))).To(Succeed())
+		Expect(sut.ListObject(ctx, "bucket-a", "")).To(Equal([]string{"key-a"}))
+		Expect(sut.FetchObject(ctx, "key-a", "bucket-a")).To(Equal([]byte("data")))
+	})
+})
+
+var _ = Describe("pagination", func() {
+	It("follows ListObjectsV2 continuation tokens", func() {
+		api := &stubAPI{listObjectsV2: func(ctx context.Context, params *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+			Expect(aws.ToString(params.Prefix)).To(Equal("logs/"))
+			if params.ContinuationToken == nil {
+				return &s3.ListObjectsV2Output{
+					Contents:              []s3types.Object{{Key: aws.String("logs/a")}},
+					IsTruncated:           aws.Bool(true),
+					NextContinuationToken: aws.String("next"),
+				}, nil
+			}
+			Expect(aws.ToString(params.ContinuationToken)).To(Equal("next"))
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: aws.String("logs/b")}}}, nil
+		}}
+
+		objects, err := listObjectsV2All(context.Background(), api, "bucket-a", "logs/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(2))
+		Expect(aws.ToString(objects[1].Key)).To(Equal("logs/b"))
+	})
+
+	It("follows object part markers", func() {
+		api := &stubAPI{getObjectAttributes: func(ctx context.Context, params *s3.GetObjectAttributesInput) (*s3.GetObjectAttributesOutput, error) {
+			if params.PartNumberMarker == nil {
+				return &s3.GetObjectAttributesOutput{ObjectParts: &s3types.GetObjectAttributesParts{
+					Parts:                []s3types.ObjectPart{{PartNumber: aws.Int32(1)}},
+					IsTruncated:          aws.Bool(true),
+					NextPartNumberMarker: aws.String("1"),
+				}}, nil
+			}
+			return &s3.GetObjectAttributesOutput{ObjectParts: &s3types.GetObjectAttributesParts{
+				Parts: []s3types.ObjectPart{{PartNumber: aws.Int32(2)}},
+			}}, nil
+		}}
+
+		parts, err := objectPartsAll(context.Background(), api, "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parts).To(HaveLen(2))
+	})
+
+	It("returns no parts for objects uploaded in one request", func() {
+		api := &stubAPI{objectParts: func(ctx context.Context, bucket, key string) ([]s3types.ObjectPart, error) {
+			return nil, nil
+		}}
+
+		parts, err := objectPartsAll(context.Background(), api, "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parts).To(BeEmpty())
+	})
+})
@@ -0,0 +1,295 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // SHA1 is one of the checksum algorithms S3 supports
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// defaultChecksumAlgorithm matches the transfer manager's default.
+const defaultChecksumAlgorithm = types.ChecksumCRC32
+
+// crc64NVME is the reversed NVME polynomial used by the CRC64NVME checksum.
+const crc64NVME = 0x9a6c9329ac4bc9b5
+
+// sdkChecksumValidation identifies the SDK middleware that validates GetObject response bodies.
+const sdkChecksumValidation = "AWSChecksum:ValidateOutputPayloadChecksum"
+
+// checksumAlgorithm resolves and validates the checksum settings of an upload.
+func checksumAlgorithm(o types.PutObjectOptions) (types.ChecksumAlgorithm, error) {
+	if o.ChecksumAlgorithm == "" {
+		if o.Checksum != "" {
+			return "", errors.New("a precomputed checksum requires ChecksumAlgorithm to be set")
+		}
+		return defaultChecksumAlgorithm, nil
+	}
+	if _, err := newChecksumHash(o.ChecksumAlgorithm); err != nil {
+		return "", err
+	}
+	return o.ChecksumAlgorithm, nil
+}
+
+// newChecksumHash returns a hash computing the checksum algorithm.
+func newChecksumHash(alg types.ChecksumAlgorithm) (hash.Hash, error) {
+	switch alg {
+	case types.ChecksumCRC32:
+		return crc32.NewIEEE(), nil
+	case types.ChecksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case types.ChecksumCRC64NVME:
+		return crc64.New(crc64.MakeTable(crc64NVME)), nil
+	case types.ChecksumSHA1:
+		return sha1.New(), nil //nolint:gosec
+	case types.ChecksumSHA256:
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", alg)
+	}
+}
+
+// supportsFullObject reports whether multipart uploads can store a full-object checksum using alg.
+func supportsFullObject(alg types.ChecksumAlgorithm) bool {
+	return alg == types.ChecksumCRC32 || alg == types.ChecksumCRC32C || alg == types.ChecksumCRC64NVME
+}
+
+// checksumValue returns the value for alg from the per-algorithm checksum fields of an SDK type.
+func checksumValue(alg types.ChecksumAlgorithm, crc32, crc32c, crc64nvme, sha1, sha256 *string) string {
+	switch alg {
+	case types.ChecksumCRC32:
+		return aws.ToString(crc32)
+	case types.ChecksumCRC32C:
+		return aws.ToString(crc32c)
+	case types.ChecksumCRC64NVME:
+		return aws.ToString(crc64nvme)
+	case types.ChecksumSHA1:
+		return aws.ToString(sha1)
+	case types.ChecksumSHA256:
+		return aws.ToString(sha256)
+	}
+	return ""
+}
+
+// checksumFields returns v in the position of alg for assigning the per-algorithm checksum fields
+// of an SDK type. An empty v leaves every field nil.
+func checksumFields(alg types.ChecksumAlgorithm, v string) (crc32, crc32c, crc64nvme, sha1, sha256 *string) {
+	p := nonEmpty(v)
+	switch alg {
+	case types.ChecksumCRC32:
+		crc32 = p
+	case types.ChecksumCRC32C:
+		crc32c = p
+	case types.ChecksumCRC64NVME:
+		crc64nvme = p
+	case types.ChecksumSHA1:
+		sha1 = p
+	case types.ChecksumSHA256:
+		sha256 = p
+	}
+	return
+}
+
+// responseChecksum returns the algorithm and value of the checksum returned with an object.
+func responseChecksum(out *s3.GetObjectOutput) (types.ChecksumAlgorithm, string) {
+	for _, alg := range []types.ChecksumAlgorithm{
+		types.ChecksumCRC64NVME, types.ChecksumCRC32C, types.ChecksumCRC32, types.ChecksumSHA256, types.ChecksumSHA1,
+	} {
+		v := checksumValue(alg, out.ChecksumCRC32, out.ChecksumCRC32C, out.ChecksumCRC64NVME, out.ChecksumSHA1, out.ChecksumSHA256)
+		if v != "" {
+			return alg, v
+		}
+	}
+	return "", ""
+}
+
+// isCompositeChecksum reports whether the checksum of an object is a checksum of its part checksums.
+func isCompositeChecksum(out *s3.GetObjectOutput, value string) bool {
+	if out.ChecksumType != "" {
+		return out.ChecksumType == s3types.ChecksumTypeComposite
+	}
+	return strings.Contains(value, "-")
+}
+
+// withoutSDKChecksumValidation removes the SDK's response checksum validation from a GetObject call
+// whose body is checked by verifyChecksum, so a mismatch is reported as a ChecksumMismatchError.
+var withoutSDKChecksumValidation = s3.WithAPIOptions(func(stack *middleware.Stack) error {
+	_, _ = stack.Deserialize.Remove(sdkChecksumValidation)
+	return nil
+})
+
+// verifyChecksum wraps the body of a GetObject response to verify it against the object's checksum.
+// The body is returned unchanged when the service did not return a checksum, or returned a
+// composite checksum without the part layout needed to verify it, including when the service
+// refuses or does not support the GetObjectAttributes request reporting the layout.
+func (s *S3) verifyChecksum(ctx context.Context, bucket, key string, out *s3.GetObjectOutput, body io.Reader) (io.Reader, error) {
+	alg, expected := responseChecksum(out)
+	if expected == "" {
+		return body, nil
+	}
+
+	var parts []int64
+	if isCompositeChecksum(out, expected) {
+		objectParts, err := objectPartsAll(ctx, s.client(), bucket, key)
+		if err != nil && !isAttributesUnavailable(err) {
+			return nil, err
+		}
+		if len(objectParts) == 0 {
+			return body, nil
+		}
+		parts = make([]int64, 0, len(objectParts))
+		for _, p := range objectParts {
+			parts = append(parts, aws.ToInt64(p.Size))
+		}
+	}
+
+	return newChecksumVerifier(body, alg, expected, parts)
+}
+
+// isAttributesUnavailable reports whether err shows that GetObjectAttributes is denied to the
+// caller or not implemented by the service.
+func isAttributesUnavailable(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "AccessDenied", "NotImplemented", "MethodNotAllowed":
+		return true
+	}
+	return false
+}
+
+// checksumVerifier checksums data as it is read and compares the result with the expected value
+// once the reader reaches EOF.
+//
+// Full-object checksums cover all of the data. Composite checksums are the checksum of the
+// concatenated part checksums followed by "-N", so the data is hashed part by part using the part
+// sizes reported by the service.
+type checksumVerifier struct {
+	r         io.Reader
+	alg       types.ChecksumAlgorithm
+	expected  string
+	hash      hash.Hash
+	parts     []int64
+	composite bool
+	remaining int64
+	digests   []byte
+	count     int
+	verified  bool
+	err       error
+}
+
+// newChecksumVerifier verifies r against expected. Composite checksums require the size of each part.
+func newChecksumVerifier(r io.Reader, alg types.ChecksumAlgorithm, expected string, parts []int64) (*checksumVerifier, error) {
+	h, err := newChecksumHash(alg)
+	if err != nil {
+		return nil, err
+	}
+	return &checksumVerifier{
+		r:         r,
+		alg:       alg,
+		expected:  expected,
+		hash:      h,
+		parts:     parts,
+		composite: parts != nil,
+	}, nil
+}
+
+func (v *checksumVerifier) Read(p []byte) (int, error) {
+	n, err := v.r.Read(p)
+	v.write(p[:n])
+
+	if err == io.EOF {
+		if verr := v.verify(); verr != nil {
+			return n, verr
+		}
+	}
+	return n, err
+}
+
+func (v *checksumVerifier) write(b []byte) {
+	if !v.composite {
+		v.hash.Write(b)
+		return
+	}
+
+	for len(b) > 0 {
+		if v.remaining == 0 && len(v.parts) > 0 {
+			v.remaining, v.parts = v.parts[0], v.parts[1:]
+		}
+		if v.remaining == 0 {
+			// More data than the reported parts, which the final comparison reports as a mismatch.
+			v.hash.Write(b)
+			return
+		}
+
+		take := min(v.remaining, int64(len(b)))
+		v.hash.Write(b[:take])
+		v.remaining -= take
+		b = b[take:]
+		if v.remaining == 0 {
+			v.endPart()
+		}
+	}
+}
+
+func (v *checksumVerifier) endPart() {
+	v.digests = v.hash.Sum(v.digests)
+	v.hash.Reset()
+	v.count++
+}
+
+// verify compares the checksum of everything read with the expected value.
+func (v *checksumVerifier) verify() error {
+	if v.verified {
+		return v.err
+	}
+	v.verified = true
+
+	actual := base64.StdEncoding.EncodeToString(v.hash.Sum(nil))
+	if v.composite {
+		if v.remaining > 0 || len(v.parts) > 0 {
+			v.endPart()
+		}
+		outer, _ := newChecksumHash(v.alg)
+		outer.Write(v.digests)
+		actual = base64.StdEncoding.EncodeToString(outer.Sum(nil)) + "-" + strconv.Itoa(v.count)
+	}
+
+	if actual != v.expected {
+		v.err = &ChecksumMismatchError{Algorithm: v.alg, Expected: v.expected, Actual: actual}
+	}
+	return v.err
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	tmtypes "github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// checksumOf returns the base64 checksum of data.
+func checksumOf(alg types.ChecksumAlgorithm, data []byte) string {
+	h, err := newChecksumHash(alg)
+	Expect(err).NotTo(HaveOccurred())
+	h.Write(data)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+var _ = Describe("Checksums", func() {
+	var (
+		sut *S3
+		api *stubAPI
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+	})
+
+	Describe("checksumAlgorithm", func() {
+		It("defaults to CRC32", func() {
+			alg, err := checksumAlgorithm(types.PutObjectOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alg).To(Equal(types.ChecksumCRC32))
+		})
+
+		It("requires an explicit algorithm for precomputed checksums", func() {
+			_, err := checksumAlgorithm(types.PutObjectOptions{Checksum: "AAAAAA=="})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects unknown algorithms", func() {
+			_, err := checksumAlgorithm(types.PutObjectOptions{ChecksumAlgorithm: "MD4"})
+			Expect(err).To(MatchError(ContainSubstring("MD4")))
+		})
+
+		It("computes the CRC64NVME check value", func() {
+			h, err := newChecksumHash(types.ChecksumCRC64NVME)
+			Expect(err).NotTo(HaveOccurred())
+			h.Write([]byte("123456789"))
+			Expect(h.Sum(nil)).To(Equal([]byte{0xae, 0x8b, 0x14, 0x86, 0x0a, 0x79, 0x98, 0x88}))
+		})
+	})
+
+	Describe("uploads", func() {
+		var fakeClient *fakeTransferManager
+
+		BeforeEach(func() {
+			fakeClient = &fakeTransferManager{}
+			sut.transfer = fakeClient
+		})
+
+		It("requests the chosen algorithm and sends a precomputed checksum", func() {
+			data := []byte("hello world")
+			sum := checksumOf(types.ChecksumSHA256, data)
+			err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(data), func(o *types.PutObjectOptions) {
+				o.ChecksumAlgorithm = types.ChecksumSHA256
+				o.Checksum = sum
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.uploadInput.ChecksumAlgorithm).To(Equal(tmtypes.ChecksumAlgorithm("SHA256")))
+			Expect(aws.ToString(fakeClient.uploadInput.ChecksumSHA256)).To(Equal(sum))
+			Expect(fakeClient.uploadInput.ChecksumCRC32).To(BeNil())
+		})
+
+		It("uses CRC32 by default", func() {
+			Expect(sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader([]byte("hi")))).To(Succeed())
+			Expect(fakeClient.uploadInput.ChecksumAlgorithm).To(Equal(tmtypes.ChecksumAlgorithmCrc32))
+		})
+
+		It("stores a precomputed checksum on multipart uploads as a full-object checksum", func() {
+			fake := &fakeMultipart{}
+			fake.install(api)
+			payload := bytes.Repeat([]byte("d"), 12*mib)
+			sum := checksumOf(types.ChecksumCRC32C, payload)
+
+			err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+				o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}
+				o.ChecksumAlgorithm = types.ChecksumCRC32C
+				o.Checksum = sum
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.uploadInput).To(BeNil())
+			Expect(fake.create.ChecksumAlgorithm).To(Equal(s3types.ChecksumAlgorithmCrc32c))
+			Expect(fake.create.ChecksumType).To(Equal(s3types.ChecksumTypeFullObject))
+			Expect(fake.completed.ChecksumType).To(Equal(s3types.ChecksumTypeFullObject))
+			Expect(aws.ToString(fake.completed.ChecksumCRC32C)).To(Equal(sum))
+			Expect(fake.assembled()).To(Equal(payload))
+		})
+
+		It("aborts a failed multipart upload that is not resumable", func() {
+			fake := &fakeMultipart{failPart: 2}
+			fake.install(api)
+			payload := bytes.Repeat([]byte("d"), 12*mib)
+
+			err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+				o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}
+				o.ChecksumAlgorithm = types.ChecksumCRC32
+				o.Checksum = checksumOf(types.ChecksumCRC32, payload)
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(fake.aborted).To(Equal([]string{"upload-1"}))
+		})
+
+		It("rejects precomputed checksums that cannot cover a multipart upload", func() {
+			payload := bytes.Repeat([]byte("d"), 6*mib)
+			err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+				o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}
+				o.ChecksumAlgorithm = types.ChecksumSHA256
+				o.Checksum = checksumOf(types.ChecksumSHA256, payload)
+			})
+			Expect(err).To(MatchError(ContainSubstring("full-object SHA256")))
+
+			err = sut.PutObjectStream(context.Background(), "bucket-a", "key-a", strings.NewReader("streamed"), func(o *types.PutObjectOptions) {
+				o.ChecksumAlgorithm = types.ChecksumCRC32
+				o.Checksum = checksumOf(types.ChecksumCRC32, []byte("streamed"))
+			})
+			Expect(err).To(MatchError(ContainSubstring("known size")))
+			Expect(fakeClient.uploadInput).To(BeNil())
+		})
+	})
+
+	Describe("FetchObject", func() {
+		var (
+			payload []byte
+			served  []byte
+			output  func() *s3.GetObjectOutput
+			params  *s3.GetObjectInput
+		)
+
+		BeforeEach(func() {
+			payload = bytes.Repeat([]byte("0123456789"), 1000)
+			served = payload
+			output = func() *s3.GetObjectOutput {
+				return &s3.GetObjectOutput{ChecksumCRC32C: aws.String(checksumOf(types.ChecksumCRC32C, payload))}
+			}
+			api.getObject = func(ctx context.Context, p *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				params = p
+				out := output()
+				out.Body = io.NopCloser(bytes.NewReader(served))
+				return out, nil
+			}
+		})
+
+		It("verifies full-object checksums", func() {
+			data, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(payload))
+			Expect(params.ChecksumMode).To(Equal(s3types.ChecksumModeEnabled))
+		})
+
+		It("reports corrupted data as a checksum mismatch", func() {
+			served = append([]byte("X"), payload[1:]...)
+
+			_, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+			Expect(err).To(MatchError(ErrChecksumMismatch))
+
+			var mismatch *ChecksumMismatchError
+			Expect(errors.As(err, &mismatch)).To(BeTrue())
+			Expect(mismatch.Algorithm).To(Equal(types.ChecksumCRC32C))
+			Expect(mismatch.Expected).To(Equal(checksumOf(types.ChecksumCRC32C, payload)))
+			Expect(mismatch.Actual).To(Equal(checksumOf(types.ChecksumCRC32C, served)))
+		})
+
+		It("skips validation when disabled", func() {
+			served = []byte("corrupt")
+
+			data, err := sut.FetchObject(context.Background(), "key-a", "bucket-a", func(o *types.FetchObjectOptions) {
+				o.DisableChecksumValidation = true
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(served))
+			Expect(params.ChecksumMode).To(BeEmpty())
+		})
+
+		It("reports mismatches from a real endpoint as a checksum mismatch", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("x-amz-checksum-crc32", checksumOf(types.ChecksumCRC32, payload))
+				_, _ = w.Write(served)
+			}))
+			defer server.Close()
+			served = []byte("corrupt")
+
+			sut, err := New(context.Background(), server.URL, "ak", "sk", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sut.FetchObject(context.Background(), "key-a", "bucket-a")
+			Expect(err).To(MatchError(ErrChecksumMismatch))
+
+			var mismatch *ChecksumMismatchError
+			Expect(errors.As(err, &mismatch)).To(BeTrue())
+			Expect(mismatch.Algorithm).To(Equal(types.ChecksumCRC32))
+			Expect(mismatch.Actual).To(Equal(checksumOf(types.ChecksumCRC32, served)))
+		})
+
+		Context("with a composite multipart checksum", func() {
+			var partSizes []int64
+
+			BeforeEach(func() {
+				partSizes = []int64{4000, 4000, 2000}
+				var digests []byte
+				offset := int64(0)
+				for _, size := range partSizes {
+					sum, _ := base64.StdEncoding.DecodeString(checksumOf(types.ChecksumSHA256, payload[offset:offset+size]))
+					digests = append(digests, sum...)
+					offset += size
+				}
+				composite := checksumOf(types.ChecksumSHA256, digests) + "-3"
+				output = func() *s3.GetObjectOutput {
+					return &s3.GetObjectOutput{
+						ChecksumSHA256: aws.String(composite),
+						ChecksumType:   s3types.ChecksumTypeComposite,
+					}
+				}
+				api.objectParts = func(ctx context.Context, bucket, key string) ([]s3types.ObjectPart, error) {
+					parts := make([]s3types.ObjectPart, 0, len(partSizes))
+					for i, size := range partSizes {
+						parts = append(parts, s3types.ObjectPart{PartNumber: aws.Int32(int32(i + 1)), Size: aws.Int64(size)})
+					}
+					return parts, nil
+				}
+			})
+
+			It("verifies each part against the composite checksum", func() {
+				data, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data).To(Equal(payload))
+			})
+
+			It("reports corruption within a part", func() {
+				served = append(append([]byte{}, payload[:5000]...), 'X')
+				served = append(served, payload[5001:]...)
+
+				_, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+				Expect(err).To(MatchError(ErrChecksumMismatch))
+			})
+
+			DescribeTable("returns the data unverified when the part layout cannot be requested",
+				func(code string) {
+					api.objectParts = func(ctx context.Context, bucket, key string) ([]s3types.ObjectPart, error) {
+						return nil, apiErr{code: code}
+					}
+					// The served data does not match the stored checksum.
+					served = []byte("anything")
+
+					data, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(data).To(Equal(served))
+
+					var buf bytes.Buffer
+					_, err = sut.DownloadObject(context.Background(), "bucket-a", "key-a", &buf)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(buf.Bytes()).To(Equal(served))
+				},
+				Entry("AccessDenied", "AccessDenied"),
+				Entry("NotImplemented", "NotImplemented"),
+				Entry("MethodNotAllowed", "MethodNotAllowed"),
+			)
+
+			It("returns other errors requesting the part layout", func() {
+				api.objectParts = func(ctx context.Context, bucket, key string) ([]s3types.ObjectPart, error) {
+					return nil, apiErr{code: "NoSuchKey"}
+				}
+
+				_, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+				Expect(err).To(MatchError(ErrObjectNotFound))
+			})
+
+			It("skips validation when the part layout is unavailable", func() {
+				partSizes = nil
+				served = []byte("anything")
+
+				_, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
+
+// errReader always fails with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -19,84 +19,44 @@ package simple_s3
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	tmtypes "github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 	transport "github.com/aws/smithy-go/endpoints"
-)
-
-// newS3ClientFromConfig is a test hook for constructing an S3 client.
-var newS3ClientFromConfig = func(cfg aws.Config, optFns ...func(*s3.Options)) *s3.Client {
-	return s3.NewFromConfig(cfg, optFns...)
-}
-
-// Test hooks for AWS SDK calls to keep behavior unit-testable.
-var loadDefaultConfig = config.LoadDefaultConfig
-
-var s3CreateBucket = func(c *s3.Client, ctx context.Context, params *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
-	return c.CreateBucket(ctx, params)
-}
-
-var s3ListBuckets = func(c *s3.Client, ctx context.Context, params *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
-	return c.ListBuckets(ctx, params)
-}
-
-var s3HeadBucket = func(c *s3.Client, ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
-	return c.HeadBucket(ctx, params)
-}
-
-var s3DeleteBucket = func(c *s3.Client, ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
-	return c.DeleteBucket(ctx, params)
-}
-
-var s3GetObject = func(c *s3.Client, ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
-	return c.GetObject(ctx, params)
-}
-
-var s3DeleteObject = func(c *s3.Client, ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
-	return c.DeleteObject(ctx, params)
-}
-
-var s3DeleteObjects = func(c *s3.Client, ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
-	return c.DeleteObjects(ctx, params)
-}
-
-var newTransferManager = func(c *s3.Client, optFns ...func(*transfermanager.Options)) transferManagerAPI {
-	return transfermanager.New(c, optFns...)
-}
-
-var listObjectsV2All = func(ctx context.Context, c *s3.Client, bucket, prefix string) ([]s3types.Object, error) {
-	params := &s3.ListObjectsV2Input{
-		Bucket: aws.String(bucket),
-		Prefix: aws.String(prefix),
-	}
-	paginator := s3.NewListObjectsV2Paginator(c, params)
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/tracing/smithyoteltracing"
 
-	contents := make([]s3types.Object, 0)
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			return nil, err
-		}
-		contents = append(contents, page.Contents...)
-	}
-
-	return contents, nil
-}
+	"github.com/drewbernetes/simple-s3/internal/compression"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
 
 // S3 wraps an AWS S3 client with simplified helper methods.
 type S3 struct {
-	// Client is the underlying AWS SDK S3 client used to execute requests.
+	// Client is the underlying AWS SDK S3 client, or nil when created by NewFromAPI with another
+	// implementation of API.
 	Client *s3.Client
+
+	api          API
+	endpoint     string
+	options      Options
+	telemetry    *telemetry
+	transferOnce sync.Once
+	transfer     transferManagerAPI
 }
 
 // transferManagerAPI captures the transfermanager client behavior used by PutObject.
@@ -120,23 +80,48 @@ func (r *staticResolver) ResolveEndpoint(_ context.Context, params s3.EndpointPa
 // New creates a configured S3 wrapper.
 //
 // If an endpoint is provided, requests are routed to that endpoint using path-style addressing.
-// If a region is empty, us-east-1 is used.
-func New(ctx context.Context, endpoint, accessKey, secretKey, region string) (*S3, error) {
+// If a region is empty, us-east-1 is used. Additional behaviour, such as the retry policy, is
+// configured through optFns.
+func New(ctx context.Context, endpoint, accessKey, secretKey, region string, optFns ...func(*Options)) (*S3, error) {
 	const defaultRegion = "us-east-1"
 	r := defaultRegion
 	if region != defaultRegion && region != "" {
 		r = region
 	}
 
-	cfg, err := loadDefaultConfig(ctx,
+	o := Options{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	apiOptions := []func(*middleware.Stack) error{addAttemptCounter}
+	if faults := addFaultInjection(o.Faults); faults != nil {
+		apiOptions = append(apiOptions, faults)
+	}
+	if o.Logger != nil {
+		apiOptions = append(apiOptions, addRetryLogging(o.Logger))
+	}
+	loadOptions := []func(*config.LoadOptions) error{
 		config.WithRegion(r),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
-	)
+		config.WithAPIOptions(apiOptions),
+	}
+	if o.Logger != nil {
+		loadOptions = append(loadOptions, config.WithLogger(sdkLogger{log: o.Logger}))
+		if o.LogRequests {
+			loadOptions = append(loadOptions, config.WithClientLogMode(aws.LogRequest|aws.LogResponse))
+		}
+	}
+	if !o.Retry.isZero() {
+		loadOptions = append(loadOptions, config.WithRetryer(newRetryer(o.Retry)))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, loadOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	options := make([]func(*s3.Options), 0, 2)
+	options := make([]func(*s3.Options), 0, 3)
 	if endpoint != "" {
 		ep, parseErr := url.Parse(endpoint)
 		if parseErr != nil {
@@ -147,21 +132,33 @@ func New(ctx context.Context, endpoint, accessKey, secretKey, region string) (*S
 			o.UsePathStyle = true
 		})
 	}
+	if o.Telemetry.TracerProvider != nil {
+		options = append(options, func(so *s3.Options) {
+			so.TracerProvider = smithyoteltracing.Adapt(o.Telemetry.TracerProvider)
+		})
+	}
 
-	return &S3{Client: newS3ClientFromConfig(cfg, options...)}, nil
+	client := s3.NewFromConfig(cfg, options...)
+	return &S3{Client: client, api: client, endpoint: endpoint, options: o, telemetry: newTelemetry(o.Telemetry)}, nil
 }
 
 // CreateBucket creates a bucket with the provided name.
-func (s *S3) CreateBucket(ctx context.Context, name string) error {
-	_, err := s3CreateBucket(s.Client, ctx, &s3.CreateBucketInput{Bucket: aws.String(name)})
-	return err
+func (s *S3) CreateBucket(ctx context.Context, name string) (err error) {
+	ctx, call := s.startOperation(ctx, "CreateBucket", name, "")
+	defer func() { call.end(ctx, err) }()
+
+	_, err = s.client().CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(name)})
+	return newError("CreateBucket", name, "", err)
 }
 
 // ListBuckets lists buckets filtered by the provided prefix.
-func (s *S3) ListBuckets(ctx context.Context, prefix string) (*s3.ListBucketsOutput, error) {
-	buckets, err := s3ListBuckets(s.Client, ctx, &s3.ListBucketsInput{Prefix: aws.String(prefix)})
+func (s *S3) ListBuckets(ctx context.Context, prefix string) (_ *s3.ListBucketsOutput, err error) {
+	ctx, call := s.startOperation(ctx, "ListBuckets", "", "")
+	defer func() { call.end(ctx, err) }()
+
+	buckets, err := s.client().ListBuckets(ctx, &s3.ListBucketsInput{Prefix: aws.String(prefix)})
 	if err != nil {
-		return nil, err
+		return nil, newError("ListBuckets", "", "", err)
 	}
 	return buckets, nil
 }
@@ -169,18 +166,21 @@ func (s *S3) ListBuckets(ctx context.Context, prefix string) (*s3.ListBucketsOut
 // DeleteBucket removes all objects from a bucket and then deletes the bucket.
 //
 // If the bucket does not exist, DeleteBucket returns nil.
-func (s *S3) DeleteBucket(ctx context.Context, name string) error {
-	_, err := s3HeadBucket(s.Client, ctx, &s3.HeadBucketInput{Bucket: aws.String(name)})
+func (s *S3) DeleteBucket(ctx context.Context, name string) (err error) {
+	ctx, call := s.startOperation(ctx, "DeleteBucket", name, "")
+	defer func() { call.end(ctx, err) }()
+
+	_, err = s.client().HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(name)})
 	if err != nil {
 		if isNotFoundError(err) {
 			return nil
 		}
-		return err
+		return newError("DeleteBucket", name, "", err)
 	}
 
-	objects, err := listObjectsV2All(ctx, s.Client, name, "")
+	objects, err := listObjectsV2All(ctx, s.client(), name, "")
 	if err != nil {
-		return err
+		return newError("DeleteBucket", name, "", err)
 	}
 
 	identifiers := make([]s3types.ObjectIdentifier, 0, len(objects))
@@ -191,9 +191,12 @@ func (s *S3) DeleteBucket(ctx context.Context, name string) error {
 		identifiers = append(identifiers, s3types.ObjectIdentifier{Key: object.Key})
 	}
 
+	if len(identifiers) > 0 {
+		call.log.InfoContext(ctx, "deleting objects before removing bucket", slog.Int("objects", len(identifiers)))
+	}
 	for i := 0; i < len(identifiers); i += 1000 {
 		end := min(i+1000, len(identifiers))
-		_, err = s3DeleteObjects(s.Client, ctx, &s3.DeleteObjectsInput{
+		out, err := s.client().DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket: aws.String(name),
 			Delete: &s3types.Delete{
 				Objects: identifiers[i:end],
@@ -201,54 +204,325 @@ func (s *S3) DeleteBucket(ctx context.Context, name string) error {
 			},
 		})
 		if err != nil {
-			return err
+			return newError("DeleteBucket", name, "", err)
+		}
+		// Quiet responses list only the keys that could not be deleted, which leave the bucket
+		// non-empty and make the final DeleteBucket fail.
+		if len(out.Errors) > 0 {
+			first := out.Errors[0]
+			call.log.WarnContext(ctx, "objects could not be deleted",
+				slog.Int("failed", len(out.Errors)),
+				slog.String("first_key", aws.ToString(first.Key)),
+				slog.String("code", aws.ToString(first.Code)),
+				slog.String("message", aws.ToString(first.Message)))
 		}
+		call.log.InfoContext(ctx, "deleted batch of objects", slog.Int("deleted", end), slog.Int("total", len(identifiers)))
 	}
 
-	_, err = s3DeleteBucket(s.Client, ctx, &s3.DeleteBucketInput{Bucket: aws.String(name)})
-	return err
+	_, err = s.client().DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String(name)})
+	return newError("DeleteBucket", name, "", err)
 }
 
 // FetchObject downloads an object and returns its full contents.
-func (s *S3) FetchObject(ctx context.Context, fileName, bucket string) ([]byte, error) {
-	obj, err := s3GetObject(s.Client, ctx, &s3.GetObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(fileName),
-	})
+//
+// The data is verified against the checksum stored with the object, if any, and an error matching
+// ErrChecksumMismatch is returned when they differ. Verifying the composite checksum of an object
+// uploaded in parts takes extra GetObjectAttributes requests, one per 1,000 parts, for the part
+// layout, which needs the s3:GetObjectAttributes permission; when that request is denied or not
+// supported the data is returned unverified. Objects stored with a gzip or zstd Content-Encoding
+// are decompressed unless DisableDecompression is set. Conditional options return an error matching
+// ErrNotModified or ErrPreconditionFailed when the stored object does not satisfy them.
+func (s *S3) FetchObject(ctx context.Context, fileName, bucket string, optFns ...func(*types.FetchObjectOptions)) (_ []byte, err error) {
+	ctx, call := s.startOperation(ctx, "FetchObject", bucket, fileName)
+	defer func() { call.end(ctx, err) }()
+
+	body, err := s.getObject(ctx, bucket, fileName, optFns)
+	if err != nil {
+		return nil, newError("FetchObject", bucket, fileName, err)
+	}
+
+	defer body.Close() //nolint:all
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, newError("FetchObject", bucket, fileName, err)
+	}
+	body.progress.done()
+	call.setSize(int64(len(data)))
+	call.transferred(ctx, int64(len(data)))
+	return data, nil
+}
+
+// DownloadObject streams an object to w and returns the number of bytes written.
+//
+// It verifies and decompresses the data as FetchObject does, including the GetObjectAttributes
+// requests made for objects uploaded in parts, without holding the object in memory.
+// A checksum mismatch is only detected once the whole object has been written, so the data written
+// must be discarded when the returned error matches ErrChecksumMismatch.
+func (s *S3) DownloadObject(ctx context.Context, bucket, key string, w io.Writer, optFns ...func(*types.FetchObjectOptions)) (_ int64, err error) {
+	ctx, call := s.startOperation(ctx, "DownloadObject", bucket, key)
+	defer func() { call.end(ctx, err) }()
+
+	body, err := s.getObject(ctx, bucket, key, optFns)
+	if err != nil {
+		return 0, newError("DownloadObject", bucket, key, err)
+	}
+
+	defer body.Close() //nolint:all
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, newError("DownloadObject", bucket, key, err)
+	}
+	body.progress.done()
+	call.setSize(n)
+	call.transferred(ctx, n)
+	return n, nil
+}
+
+// objectBody is the body of a GetObject response wrapped for checksum verification, progress
+// reporting and decompression.
+type objectBody struct {
+	io.Reader
+	closers  []io.Closer
+	progress *progressTracker
+}
+
+// Close releases the decoder, if any, and the response body.
+func (b *objectBody) Close() error {
+	var err error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		if closeErr := b.closers[i].Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// getObject issues a GetObject request for a whole object configured by the fetch options.
+func (s *S3) getObject(ctx context.Context, bucket, key string, optFns []func(*types.FetchObjectOptions)) (*objectBody, error) {
+	o := types.FetchObjectOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	params := &s3.GetObjectInput{
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(key),
+		IfMatch:           nonEmpty(o.IfMatch),
+		IfNoneMatch:       nonEmpty(o.IfNoneMatch),
+		IfModifiedSince:   nonZero(o.IfModifiedSince),
+		IfUnmodifiedSince: nonZero(o.IfUnmodifiedSince),
+	}
+	if !o.DisableChecksumValidation {
+		params.ChecksumMode = s3types.ChecksumModeEnabled
+	}
+	obj, err := s.client().GetObject(ctx, params, withoutSDKChecksumValidation)
 	if err != nil {
 		return nil, err
 	}
 
-	defer obj.Body.Close() //nolint:all
+	total := int64(-1)
+	if obj.ContentLength != nil {
+		total = *obj.ContentLength
+	}
+	body := &objectBody{
+		Reader:   obj.Body,
+		closers:  []io.Closer{obj.Body},
+		progress: newProgressTracker(o.Progress, bucket, key, total, 0),
+	}
+
+	if !o.DisableChecksumValidation {
+		body.Reader, err = s.verifyChecksum(ctx, bucket, key, obj, body.Reader)
+		if err != nil {
+			_ = body.Close()
+			return nil, err
+		}
+	}
 
-	return io.ReadAll(obj.Body)
+	body.Reader = &progressReader{r: body.Reader, progress: body.progress}
+	if codec := compression.Decoding(aws.ToString(obj.ContentEncoding)); codec != "" && !o.DisableDecompression {
+		dec, err := compression.NewReader(codec, body.Reader)
+		if err != nil {
+			_ = body.Close()
+			return nil, err
+		}
+		body.Reader = dec
+		body.closers = append(body.closers, dec)
+	}
+	return body, nil
 }
 
 // PutObject uploads content to a bucket key.
 //
-// Objects larger than 100 MiB are uploaded using multipart transfer settings.
-func (s *S3) PutObject(ctx context.Context, bucket, key string, body io.ReadSeeker) error {
-	contentType, err := readContentType(body)
+// Objects at or above the multipart threshold, 100 MiB by default, are uploaded in parts using the
+// client-level or per-call multipart settings. With the Resumable option, progress is persisted so a
+// failed multipart upload resumes from the parts already stored. Conditional options
+// return an error matching ErrPreconditionFailed when the stored object does not satisfy them.
+func (s *S3) PutObject(ctx context.Context, bucket, key string, body io.ReadSeeker, optFns ...func(*types.PutObjectOptions)) (err error) {
+	ctx, call := s.startOperation(ctx, "PutObject", bucket, key)
+	defer func() { call.end(ctx, err) }()
+
+	o := types.PutObjectOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	contentType, err := s.contentType(key, o.ContentType, func() (string, error) {
+		return readContentType(body)
+	})
 	if err != nil {
-		return err
+		return newError("PutObject", bucket, key, err)
 	}
 
+	size, err := seekerLen(body)
+	if err != nil {
+		return newError("PutObject", bucket, key, err)
+	}
+	call.setSize(size)
+
+	alg, err := checksumAlgorithm(o)
+	if err != nil {
+		return newError("PutObject", bucket, key, err)
+	}
+
+	// The transfer manager cannot resume uploads or mark a multipart upload as carrying a
+	// full-object checksum, which only CRC64NVME uploads have by default. Compressed bodies are
+	// always streamed through it because their length is not known in advance.
+	multipart := size >= s.multipartOptions(o.Multipart).Threshold
+	switch {
+	case o.Compression != "":
+		err = s.upload(ctx, bucket, key, contentType, body, size, alg, o)
+	case multipart && o.Resumable:
+		err = s.multipartUpload(ctx, bucket, key, contentType, body, size, alg, o, true)
+	case multipart && o.Checksum != "" && alg != types.ChecksumCRC64NVME:
+		err = s.multipartUpload(ctx, bucket, key, contentType, body, size, alg, o, false)
+	case !multipart && o.ContentMD5:
+		err = s.putSingle(ctx, bucket, key, contentType, body, size, alg, o)
+	default:
+		err = s.upload(ctx, bucket, key, contentType, body, size, alg, o)
+	}
+	if err == nil {
+		call.transferred(ctx, size)
+	}
+	return newError("PutObject", bucket, key, err)
+}
+
+// upload sends body through the shared transfer manager, switching to multipart above the threshold.
+//
+// A negative size indicates the length of body is unknown.
+func (s *S3) upload(ctx context.Context, bucket, key, contentType string, body io.Reader, size int64, alg types.ChecksumAlgorithm, o types.PutObjectOptions) error {
+	m := s.multipartOptions(o.Multipart)
+	if o.Compression != "" && (o.Resumable || o.Checksum != "") {
+		return errors.New("compressed uploads cannot be resumed or carry a precomputed checksum")
+	}
+	if o.Checksum != "" && alg != types.ChecksumCRC64NVME && (size < 0 || size >= m.Threshold) {
+		return fmt.Errorf("a precomputed %s checksum requires a known size below the multipart threshold", alg)
+	}
+
+	partSize := uploadPartSize(m, size)
+	parts := partCount(m, size, partSize)
+	if o.Compression != "" {
+		parts = 0
+	}
+	progress := newProgressTracker(o.Progress, bucket, key, size, parts)
+	listener := progress
+
 	params := &transfermanager.UploadObjectInput{
-		Bucket:      aws.String(bucket),
-		Key:         aws.String(key),
-		ContentType: aws.String(contentType),
-		Body:        body,
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(key),
+		ContentType:       aws.String(contentType),
+		IfMatch:           nonEmpty(o.IfMatch),
+		IfNoneMatch:       nonEmpty(o.IfNoneMatch),
+		ChecksumAlgorithm: tmtypes.ChecksumAlgorithm(alg),
 	}
+	params.ChecksumCRC32, params.ChecksumCRC32C, params.ChecksumCRC64NVME, params.ChecksumSHA1, params.ChecksumSHA256 = checksumFields(alg, o.Checksum)
+	switch {
+	case o.Compression != "":
+		// The compressed length is unknown, so progress is reported as the uncompressed body is read
+		// and the part size, scaled for the uncompressed size, is an upper bound.
+		compressed, err := compressBody(o.Compression, &progressReader{r: body, progress: progress})
+		if err != nil {
+			return err
+		}
+		defer compressed.Close() //nolint:all
 
-	var partMiBs int64 = 100
-	maxPartSize := partMiBs * 1024 * 1024
-	client := newTransferManager(s.Client, func(o *transfermanager.Options) {
-		o.PartSizeBytes = maxPartSize
-		o.MultipartUploadThreshold = maxPartSize
+		body, listener = compressed, nil
+		params.ContentEncoding = aws.String(string(o.Compression))
+		if size >= 0 {
+			params.Metadata = map[string]string{compression.OriginalSizeMetadata: strconv.FormatInt(size, 10)}
+		}
+	case size >= 0:
+		params.ContentLength = aws.Int64(size)
+	}
+	params.Body = body
+
+	var etag *etagHasher
+	if o.ContentMD5 {
+		// The transfer manager reads the body sequentially in parts of exactly partSize bytes.
+		etag = newETagHasher(partSize)
+		params.Body = io.TeeReader(body, etag)
+	}
+	out, err := s.transferManager().UploadObject(ctx, params, func(to *transfermanager.Options) {
+		to.PartSizeBytes = partSize
+		// Ignored by the transfer manager; partSize applies the threshold.
+		to.MultipartUploadThreshold = m.Threshold
+		if m.Concurrency > 0 {
+			to.Concurrency = m.Concurrency
+		}
+		if listener != nil {
+			to.ObjectProgressListeners.Register(listener)
+		}
 	})
+	if err != nil {
+		return err
+	}
+	if etag != nil {
+		if err := etag.verify(aws.ToString(out.ETag)); err != nil {
+			return err
+		}
+	}
+	if listener == nil {
+		progress.done()
+	}
+	return nil
+}
 
-	_, err = client.UploadObject(ctx, params)
-	return err
+// putSingle stores body in a single PutObject request carrying a Content-MD5 header, so the service
+// rejects data corrupted in transit, and verifies the returned ETag.
+func (s *S3) putSingle(ctx context.Context, bucket, key, contentType string, body io.ReadSeeker, size int64, alg types.ChecksumAlgorithm, o types.PutObjectOptions) error {
+	md5Base64, md5Hex, err := contentMD5(body)
+	if err != nil {
+		return err
+	}
+
+	params := &s3.PutObjectInput{
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(key),
+		ContentType:       aws.String(contentType),
+		Body:              body,
+		ContentLength:     aws.Int64(size),
+		ContentMD5:        aws.String(md5Base64),
+		IfMatch:           nonEmpty(o.IfMatch),
+		IfNoneMatch:       nonEmpty(o.IfNoneMatch),
+		ChecksumAlgorithm: s3types.ChecksumAlgorithm(alg),
+	}
+	params.ChecksumCRC32, params.ChecksumCRC32C, params.ChecksumCRC64NVME, params.ChecksumSHA1, params.ChecksumSHA256 = checksumFields(alg, o.Checksum)
+
+	out, err := s.client().PutObject(ctx, params)
+	if err != nil {
+		return err
+	}
+	if etag := aws.ToString(out.ETag); etag != "" {
+		if err := compareETag(md5Hex, etag); err != nil {
+			return err
+		}
+	}
+
+	progress := newProgressTracker(o.Progress, bucket, key, size, 1)
+	progress.partCompleted(size)
+	progress.done()
+	return nil
 }
 
 // readContentType reads up to 512 bytes to detect content type and rewinds the reader.
@@ -268,10 +542,13 @@ func readContentType(body io.ReadSeeker) (string, error) {
 }
 
 // ListObject lists object keys in a bucket filtered by prefix.
-func (s *S3) ListObject(ctx context.Context, bucket, prefix string) ([]string, error) {
-	objects, err := listObjectsV2All(ctx, s.Client, bucket, prefix)
+func (s *S3) ListObject(ctx context.Context, bucket, prefix string) (_ []string, err error) {
+	ctx, call := s.startOperation(ctx, "ListObject", bucket, "")
+	defer func() { call.end(ctx, err) }()
+
+	objects, err := listObjectsV2All(ctx, s.client(), bucket, prefix)
 	if err != nil {
-		return nil, err
+		return nil, newError("ListObject", bucket, "", err)
 	}
 
 	contents := make([]string, 0, len(objects))
@@ -285,13 +562,96 @@ func (s *S3) ListObject(ctx context.Context, bucket, prefix string) ([]string, e
 	return contents, nil
 }
 
+// IsBucketEmpty reports whether a bucket holds no objects, listing at most one key.
+func (s *S3) IsBucketEmpty(ctx context.Context, bucket string) (_ bool, err error) {
+	ctx, call := s.startOperation(ctx, "IsBucketEmpty", bucket, "")
+	defer func() { call.end(ctx, err) }()
+
+	out, err := s.client().ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		MaxKeys: aws.Int32(1),
+	})
+	if err != nil {
+		return false, newError("IsBucketEmpty", bucket, "", err)
+	}
+	return len(out.Contents) == 0, nil
+}
+
 // DeleteObject removes a single object from a bucket.
-func (s *S3) DeleteObject(ctx context.Context, bucket, key string) error {
-	_, err := s3DeleteObject(s.Client, ctx, &s3.DeleteObjectInput{
+//
+// Conditional options return an error matching ErrPreconditionFailed when the stored object does
+// not satisfy them.
+func (s *S3) DeleteObject(ctx context.Context, bucket, key string, optFns ...func(*types.DeleteObjectOptions)) (err error) {
+	ctx, call := s.startOperation(ctx, "DeleteObject", bucket, key)
+	defer func() { call.end(ctx, err) }()
+
+	o := types.DeleteObjectOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	_, err = s.client().DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket:  aws.String(bucket),
+		Key:     aws.String(key),
+		IfMatch: nonEmpty(o.IfMatch),
+	})
+	return newError("DeleteObject", bucket, key, err)
+}
+
+// StatObject returns the size, ETag and headers of an object without downloading it.
+func (s *S3) StatObject(ctx context.Context, bucket, key string) (_ *types.ObjectInfo, err error) {
+	ctx, call := s.startOperation(ctx, "StatObject", bucket, key)
+	defer func() { call.end(ctx, err) }()
+
+	head, err := s.client().HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 	})
-	return err
+	if err != nil {
+		return nil, newError("StatObject", bucket, key, err)
+	}
+
+	return &types.ObjectInfo{
+		Bucket:          bucket,
+		Key:             key,
+		Size:            aws.ToInt64(head.ContentLength),
+		ETag:            strings.Trim(aws.ToString(head.ETag), `"`),
+		LastModified:    aws.ToTime(head.LastModified),
+		ContentType:     aws.ToString(head.ContentType),
+		ContentEncoding: aws.ToString(head.ContentEncoding),
+		StorageClass:    string(head.StorageClass),
+		Metadata:        head.Metadata,
+	}, nil
+}
+
+// CopyObject copies an object within the service, keeping its metadata and tags. Objects larger than
+// the 5 GiB a single CopyObject request allows are copied in parts with UploadPartCopy, which also
+// needs permission to read the source object's tags.
+func (s *S3) CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) (err error) {
+	ctx, call := s.startOperation(ctx, "CopyObject", dstBucket, dstKey)
+	defer func() { call.end(ctx, err) }()
+
+	head, err := s.client().HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(srcBucket),
+		Key:    aws.String(srcKey),
+	})
+	if err != nil {
+		return newError("CopyObject", srcBucket, srcKey, err)
+	}
+	call.setSize(aws.ToInt64(head.ContentLength))
+	if aws.ToInt64(head.ContentLength) > maxCopySize {
+		return newError("CopyObject", dstBucket, dstKey, s.copyMultipart(ctx, srcBucket, srcKey, dstBucket, dstKey, head))
+	}
+
+	_, err = s.client().CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:            aws.String(dstBucket),
+		Key:               aws.String(dstKey),
+		CopySource:        aws.String(copySource(srcBucket, srcKey)),
+		CopySourceIfMatch: head.ETag,
+		MetadataDirective: s3types.MetadataDirectiveCopy,
+		TaggingDirective:  s3types.TaggingDirectiveCopy,
+	})
+	return newError("CopyObject", dstBucket, dstKey, err)
 }
 
 func isNotFoundError(err error) bool {
@@ -303,9 +663,18 @@ func isNotFoundError(err error) bool {
 	return false
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
+// nonEmpty returns a pointer to v, or nil if v is empty.
+func nonEmpty(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return aws.String(v)
+}
+
+// nonZero returns a pointer to t, or nil if t is the zero time.
+func nonZero(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
 	}
-	return b
+	return aws.Time(t)
 }
@@ -22,9 +22,10 @@ import (
 	"errors"
 	"io"
 	"net/url"
+	"path/filepath"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
@@ -32,91 +33,46 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/drewbernetes/simple-s3/pkg/types"
 	"github.com/drewbernetes/simple-s3/pkg/util"
 )
 
 var _ util.S3Interface = (*S3)(nil)
 
-var (
-	origNewS3ClientFromConfig = newS3ClientFromConfig
-	origLoadDefaultConfig     = loadDefaultConfig
-	origS3CreateBucket        = s3CreateBucket
-	origS3ListBuckets         = s3ListBuckets
-	origS3HeadBucket          = s3HeadBucket
-	origS3DeleteBucket        = s3DeleteBucket
-	origS3GetObject           = s3GetObject
-	origS3DeleteObject        = s3DeleteObject
-	origS3DeleteObjects       = s3DeleteObjects
-	origNewTransferManager    = newTransferManager
-	origListObjectsV2All      = listObjectsV2All
-)
-
-func restoreHooks() {
-	newS3ClientFromConfig = origNewS3ClientFromConfig
-	loadDefaultConfig = origLoadDefaultConfig
-	s3CreateBucket = origS3CreateBucket
-	s3ListBuckets = origS3ListBuckets
-	s3HeadBucket = origS3HeadBucket
-	s3DeleteBucket = origS3DeleteBucket
-	s3GetObject = origS3GetObject
-	s3DeleteObject = origS3DeleteObject
-	s3DeleteObjects = origS3DeleteObjects
-	newTransferManager = origNewTransferManager
-	listObjectsV2All = origListObjectsV2All
-}
-
 var _ = Describe("S3 Client", func() {
-	BeforeEach(func() {
-		restoreHooks()
-	})
-
-	AfterEach(func() {
-		restoreHooks()
-	})
 
 	Describe("New", func() {
-		BeforeEach(func() {
-			loadDefaultConfig = func(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
-				opts := config.LoadOptions{}
-				for _, fn := range optFns {
-					err := fn(&opts)
-					Expect(err).NotTo(HaveOccurred())
-				}
-				return aws.Config{Region: opts.Region}, nil
-			}
-		})
-
 		It("uses default region and no endpoint options when endpoint is empty", func() {
-			cfgRegion := ""
-			optCount := 0
-			newS3ClientFromConfig = func(cfg aws.Config, optFns ...func(*s3.Options)) *s3.Client {
-				cfgRegion = cfg.Region
-				optCount = len(optFns)
-				return &s3.Client{}
-			}
-
-			_, err := New(context.Background(), "", "ak", "sk", "")
+			sut, err := New(context.Background(), "", "ak", "sk", "")
 			Expect(err).NotTo(HaveOccurred())
-			Expect(cfgRegion).To(Equal("us-east-1"))
-			Expect(optCount).To(Equal(0))
+
+			o := sut.Client.Options()
+			Expect(o.Region).To(Equal("us-east-1"))
+			Expect(o.UsePathStyle).To(BeFalse())
+			Expect(o.EndpointResolverV2).NotTo(BeAssignableToTypeOf(&staticResolver{}))
+			Expect(sut.api).To(BeIdenticalTo(sut.Client))
 		})
 
 		It("uses supplied region and endpoint options", func() {
-			seenPathStyle := false
-			newS3ClientFromConfig = func(cfg aws.Config, optFns ...func(*s3.Options)) *s3.Client {
-				Expect(cfg.Region).To(Equal("eu-west-1"))
-				Expect(optFns).To(HaveLen(1))
+			sut, err := New(context.Background(), "http://example.local:9000", "ak", "sk", "eu-west-1")
+			Expect(err).NotTo(HaveOccurred())
 
-				o := &s3.Options{}
-				optFns[0](o)
-				seenPathStyle = o.UsePathStyle
-				Expect(o.EndpointResolverV2).NotTo(BeNil())
-				return &s3.Client{}
-			}
+			o := sut.Client.Options()
+			Expect(o.Region).To(Equal("eu-west-1"))
+			Expect(o.UsePathStyle).To(BeTrue())
+			Expect(o.EndpointResolverV2).To(BeAssignableToTypeOf(&staticResolver{}))
+		})
+
+		It("configures a retryer only when retry options are set", func() {
+			sut, err := New(context.Background(), "", "ak", "sk", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sut.Client.Options().Retryer.MaxAttempts()).To(Equal(3))
 
-			_, err := New(context.Background(), "http://example.local:9000", "ak", "sk", "eu-west-1")
+			sut, err = New(context.Background(), "", "ak", "sk", "", func(o *Options) {
+				o.Retry.MaxAttempts = 5
+			})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(seenPathStyle).To(BeTrue())
+			Expect(sut.Client.Options().Retryer.MaxAttempts()).To(Equal(5))
 		})
 
 		It("returns an error for invalid endpoint", func() {
@@ -125,9 +81,8 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns load config error", func() {
-			loadDefaultConfig = func(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
-				return aws.Config{}, errors.New("load config failed")
-			}
+			GinkgoT().Setenv("AWS_CONFIG_FILE", filepath.Join(GinkgoT().TempDir(), "config"))
+			GinkgoT().Setenv("AWS_PROFILE", "missing")
 
 			_, err := New(context.Background(), "", "ak", "sk", "")
 			Expect(err).To(HaveOccurred())
@@ -159,8 +114,8 @@ var _ = Describe("S3 Client", func() {
 
 	Describe("CreateBucket", func() {
 		It("creates a bucket", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3CreateBucket = func(c *s3.Client, ctx context.Context, params *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
+			sut, api := newStubbed()
+			api.createBucket = func(ctx context.Context, params *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
 				Expect(aws.ToString(params.Bucket)).To(Equal("bucket-a"))
 				return &s3.CreateBucketOutput{}, nil
 			}
@@ -170,8 +125,8 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns an underlying error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3CreateBucket = func(c *s3.Client, ctx context.Context, params *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
+			sut, api := newStubbed()
+			api.createBucket = func(ctx context.Context, params *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
 				return nil, errors.New("boom")
 			}
 
@@ -182,8 +137,8 @@ var _ = Describe("S3 Client", func() {
 
 	Describe("ListBuckets", func() {
 		It("lists buckets", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3ListBuckets = func(c *s3.Client, ctx context.Context, params *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+			sut, api := newStubbed()
+			api.listBuckets = func(ctx context.Context, params *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
 				Expect(aws.ToString(params.Prefix)).To(Equal("prefix-"))
 				return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{{Name: aws.String("prefix-a")}}}, nil
 			}
@@ -195,8 +150,8 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns an underlying error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3ListBuckets = func(c *s3.Client, ctx context.Context, params *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+			sut, api := newStubbed()
+			api.listBuckets = func(ctx context.Context, params *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
 				return nil, errors.New("boom")
 			}
 
@@ -207,13 +162,13 @@ var _ = Describe("S3 Client", func() {
 
 	Describe("DeleteBucket", func() {
 		It("returns nil when bucket does not exist", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3HeadBucket = func(c *s3.Client, ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+			sut, api := newStubbed()
+			api.headBucket = func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
 				return nil, apiErr{code: "NoSuchBucket"}
 			}
 
 			calledDelete := false
-			s3DeleteBucket = func(c *s3.Client, ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+			api.deleteBucket = func(ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
 				calledDelete = true
 				return &s3.DeleteBucketOutput{}, nil
 			}
@@ -224,8 +179,8 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns head bucket error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3HeadBucket = func(c *s3.Client, ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+			sut, api := newStubbed()
+			api.headBucket = func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
 				return nil, errors.New("head failed")
 			}
 
@@ -234,11 +189,11 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns list objects error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3HeadBucket = func(c *s3.Client, ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+			sut, api := newStubbed()
+			api.headBucket = func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
 				return &s3.HeadBucketOutput{}, nil
 			}
-			listObjectsV2All = func(ctx context.Context, c *s3.Client, bucket, prefix string) ([]s3types.Object, error) {
+			api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
 				return nil, errors.New("list failed")
 			}
 
@@ -247,8 +202,8 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("deletes objects in 1000-item chunks and then deletes bucket", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3HeadBucket = func(c *s3.Client, ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+			sut, api := newStubbed()
+			api.headBucket = func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
 				return &s3.HeadBucketOutput{}, nil
 			}
 
@@ -257,18 +212,18 @@ var _ = Describe("S3 Client", func() {
 				key := "key"
 				objects[i] = s3types.Object{Key: &key}
 			}
-			listObjectsV2All = func(ctx context.Context, c *s3.Client, bucket, prefix string) ([]s3types.Object, error) {
+			api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
 				return objects, nil
 			}
 
 			chunkSizes := make([]int, 0)
-			s3DeleteObjects = func(c *s3.Client, ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+			api.deleteObjects = func(ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
 				chunkSizes = append(chunkSizes, len(params.Delete.Objects))
 				return &s3.DeleteObjectsOutput{}, nil
 			}
 
 			deletedBucket := false
-			s3DeleteBucket = func(c *s3.Client, ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+			api.deleteBucket = func(ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
 				deletedBucket = true
 				return &s3.DeleteBucketOutput{}, nil
 			}
@@ -280,15 +235,15 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns delete objects error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3HeadBucket = func(c *s3.Client, ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+			sut, api := newStubbed()
+			api.headBucket = func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
 				return &s3.HeadBucketOutput{}, nil
 			}
-			listObjectsV2All = func(ctx context.Context, c *s3.Client, bucket, prefix string) ([]s3types.Object, error) {
+			api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
 				key := "key"
 				return []s3types.Object{{Key: &key}}, nil
 			}
-			s3DeleteObjects = func(c *s3.Client, ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+			api.deleteObjects = func(ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
 				return nil, errors.New("delete objects failed")
 			}
 
@@ -297,26 +252,26 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("skips objects with nil or empty keys", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3HeadBucket = func(c *s3.Client, ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+			sut, api := newStubbed()
+			api.headBucket = func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
 				return &s3.HeadBucketOutput{}, nil
 			}
 
 			empty := ""
 			valid := "valid-key"
-			listObjectsV2All = func(ctx context.Context, c *s3.Client, bucket, prefix string) ([]s3types.Object, error) {
+			api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
 				return []s3types.Object{
 					{},
 					{Key: &empty},
 					{Key: &valid},
 				}, nil
 			}
-			s3DeleteObjects = func(c *s3.Client, ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+			api.deleteObjects = func(ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
 				Expect(params.Delete.Objects).To(HaveLen(1))
 				Expect(aws.ToString(params.Delete.Objects[0].Key)).To(Equal(valid))
 				return &s3.DeleteObjectsOutput{}, nil
 			}
-			s3DeleteBucket = func(c *s3.Client, ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+			api.deleteBucket = func(ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
 				return &s3.DeleteBucketOutput{}, nil
 			}
 
@@ -325,14 +280,14 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns delete bucket error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3HeadBucket = func(c *s3.Client, ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+			sut, api := newStubbed()
+			api.headBucket = func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
 				return &s3.HeadBucketOutput{}, nil
 			}
-			listObjectsV2All = func(ctx context.Context, c *s3.Client, bucket, prefix string) ([]s3types.Object, error) {
+			api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
 				return nil, nil
 			}
-			s3DeleteBucket = func(c *s3.Client, ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+			api.deleteBucket = func(ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
 				return nil, errors.New("delete bucket failed")
 			}
 
@@ -343,8 +298,8 @@ var _ = Describe("S3 Client", func() {
 
 	Describe("FetchObject", func() {
 		It("fetches an object", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3GetObject = func(c *s3.Client, ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			sut, api := newStubbed()
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
 				return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader([]byte("payload")))}, nil
 			}
 
@@ -354,29 +309,43 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns get object error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3GetObject = func(c *s3.Client, ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			sut, api := newStubbed()
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
 				return nil, errors.New("get object failed")
 			}
 
 			_, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("passes conditional options", func() {
+			sut, api := newStubbed()
+			since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				Expect(aws.ToString(params.IfNoneMatch)).To(Equal(`"etag-a"`))
+				Expect(aws.ToTime(params.IfModifiedSince)).To(Equal(since))
+				Expect(params.IfMatch).To(BeNil())
+				Expect(params.IfUnmodifiedSince).To(BeNil())
+				return nil, apiErr{code: "NotModified"}
+			}
+
+			_, err := sut.FetchObject(context.Background(), "key-a", "bucket-a", func(o *types.FetchObjectOptions) {
+				o.IfNoneMatch = `"etag-a"`
+				o.IfModifiedSince = since
+			})
+			Expect(err).To(MatchError(ErrNotModified))
+		})
 	})
 
 	Describe("PutObject", func() {
 		It("uploads with transfer manager and rewinds body after content sniff", func() {
-			sut := &S3{Client: &s3.Client{}}
+			sut, _ := newStubbed()
+			o := &transfermanager.Options{}
+			sut.transferOptions(o)
+			Expect(o.PartSizeBytes).To(Equal(int64(100 * 1024 * 1024)))
+			Expect(o.MultipartUploadThreshold).To(Equal(int64(100 * 1024 * 1024)))
 			fakeClient := &fakeTransferManager{}
-			newTransferManager = func(c *s3.Client, optFns ...func(*transfermanager.Options)) transferManagerAPI {
-				o := &transfermanager.Options{}
-				for _, fn := range optFns {
-					fn(o)
-				}
-				Expect(o.PartSizeBytes).To(Equal(int64(100 * 1024 * 1024)))
-				Expect(o.MultipartUploadThreshold).To(Equal(int64(100 * 1024 * 1024)))
-				return fakeClient
-			}
+			sut.transfer = fakeClient
 
 			body := bytes.NewReader([]byte("hello world"))
 			err := sut.PutObject(context.Background(), "bucket-a", "key-a", body)
@@ -390,16 +359,27 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns read content type error", func() {
-			sut := &S3{Client: &s3.Client{}}
+			sut, _ := newStubbed()
 			err := sut.PutObject(context.Background(), "bucket-a", "key-a", &failingReadSeeker{})
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("passes conditional options", func() {
+			sut, _ := newStubbed()
+			fakeClient := &fakeTransferManager{err: apiErr{code: "PreconditionFailed"}}
+			sut.transfer = fakeClient
+
+			err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader([]byte("hello world")), func(o *types.PutObjectOptions) {
+				o.IfNoneMatch = types.ETagAny
+			})
+			Expect(err).To(MatchError(ErrPreconditionFailed))
+			Expect(aws.ToString(fakeClient.uploadInput.IfNoneMatch)).To(Equal("*"))
+			Expect(fakeClient.uploadInput.IfMatch).To(BeNil())
+		})
+
 		It("returns transfer upload error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			newTransferManager = func(c *s3.Client, optFns ...func(*transfermanager.Options)) transferManagerAPI {
-				return &fakeTransferManager{err: errors.New("upload failed")}
-			}
+			sut, _ := newStubbed()
+			sut.transfer = &fakeTransferManager{err: errors.New("upload failed")}
 
 			err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader([]byte("hello world")))
 			Expect(err).To(HaveOccurred())
@@ -421,8 +401,8 @@ var _ = Describe("S3 Client", func() {
 
 	Describe("ListObject", func() {
 		It("lists object keys", func() {
-			sut := &S3{Client: &s3.Client{}}
-			listObjectsV2All = func(ctx context.Context, c *s3.Client, bucket, prefix string) ([]s3types.Object, error) {
+			sut, api := newStubbed()
+			api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
 				k1 := "a"
 				k2 := "b"
 				return []s3types.Object{{Key: &k1}, {}, {Key: &k2}}, nil
@@ -434,8 +414,8 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns list error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			listObjectsV2All = func(ctx context.Context, c *s3.Client, bucket, prefix string) ([]s3types.Object, error) {
+			sut, api := newStubbed()
+			api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
 				return nil, errors.New("list failed")
 			}
 
@@ -444,10 +424,42 @@ var _ = Describe("S3 Client", func() {
 		})
 	})
 
+	Describe("IsBucketEmpty", func() {
+		It("lists at most one key", func() {
+			sut, api := newStubbed()
+			var params *s3.ListObjectsV2Input
+			contents := []s3types.Object{{Key: aws.String("a")}}
+			api.listObjectsV2 = func(ctx context.Context, p *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+				params = p
+				return &s3.ListObjectsV2Output{Contents: contents}, nil
+			}
+
+			empty, err := sut.IsBucketEmpty(context.Background(), "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(empty).To(BeFalse())
+			Expect(aws.ToInt32(params.MaxKeys)).To(Equal(int32(1)))
+
+			contents = nil
+			empty, err = sut.IsBucketEmpty(context.Background(), "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(empty).To(BeTrue())
+		})
+
+		It("returns list errors", func() {
+			sut, api := newStubbed()
+			api.listObjectsV2 = func(ctx context.Context, p *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+				return nil, apiErr{code: "NoSuchBucket"}
+			}
+
+			_, err := sut.IsBucketEmpty(context.Background(), "bucket-a")
+			Expect(err).To(MatchError(ErrBucketNotFound))
+		})
+	})
+
 	Describe("DeleteObject", func() {
 		It("deletes a single object", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3DeleteObject = func(c *s3.Client, ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+			sut, api := newStubbed()
+			api.deleteObject = func(ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
 				Expect(aws.ToString(params.Bucket)).To(Equal("bucket-a"))
 				Expect(aws.ToString(params.Key)).To(Equal("key-a"))
 				return &s3.DeleteObjectOutput{}, nil
@@ -458,14 +470,128 @@ var _ = Describe("S3 Client", func() {
 		})
 
 		It("returns delete error", func() {
-			sut := &S3{Client: &s3.Client{}}
-			s3DeleteObject = func(c *s3.Client, ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+			sut, api := newStubbed()
+			api.deleteObject = func(ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
 				return nil, errors.New("delete failed")
 			}
 
 			err := sut.DeleteObject(context.Background(), "bucket-a", "key-a")
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("passes conditional options", func() {
+			sut, api := newStubbed()
+			api.deleteObject = func(ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+				Expect(aws.ToString(params.IfMatch)).To(Equal(`"etag-a"`))
+				return nil, apiErr{code: "PreconditionFailed"}
+			}
+
+			err := sut.DeleteObject(context.Background(), "bucket-a", "key-a", func(o *types.DeleteObjectOptions) {
+				o.IfMatch = `"etag-a"`
+			})
+			Expect(err).To(MatchError(ErrPreconditionFailed))
+		})
+	})
+
+	Describe("DownloadObject", func() {
+		It("streams the object to the writer", func() {
+			sut, api := newStubbed()
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				Expect(aws.ToString(params.Bucket)).To(Equal("bucket-a"))
+				Expect(aws.ToString(params.Key)).To(Equal("key-a"))
+				return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader([]byte("payload")))}, nil
+			}
+
+			var buf bytes.Buffer
+			n, err := sut.DownloadObject(context.Background(), "bucket-a", "key-a", &buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(7)))
+			Expect(buf.String()).To(Equal("payload"))
+		})
+
+		It("returns get object error", func() {
+			sut, api := newStubbed()
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				return nil, apiErr{code: "NoSuchKey"}
+			}
+
+			_, err := sut.DownloadObject(context.Background(), "bucket-a", "key-a", io.Discard)
+			Expect(err).To(MatchError(ErrObjectNotFound))
+		})
+	})
+
+	Describe("StatObject", func() {
+		It("returns the object headers", func() {
+			sut, api := newStubbed()
+			modified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+				Expect(aws.ToString(params.Bucket)).To(Equal("bucket-a"))
+				Expect(aws.ToString(params.Key)).To(Equal("key-a"))
+				return &s3.HeadObjectOutput{
+					ContentLength: aws.Int64(7),
+					ETag:          aws.String(`"etag-a"`),
+					LastModified:  aws.Time(modified),
+					ContentType:   aws.String("text/plain"),
+					Metadata:      map[string]string{"owner": "me"},
+				}, nil
+			}
+
+			info, err := sut.StatObject(context.Background(), "bucket-a", "key-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*info).To(Equal(types.ObjectInfo{
+				Bucket:       "bucket-a",
+				Key:          "key-a",
+				Size:         7,
+				ETag:         "etag-a",
+				LastModified: modified,
+				ContentType:  "text/plain",
+				Metadata:     map[string]string{"owner": "me"},
+			}))
+		})
+
+		It("maps missing objects", func() {
+			sut, api := newStubbed()
+			api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+				return nil, apiErr{code: "NotFound"}
+			}
+
+			_, err := sut.StatObject(context.Background(), "bucket-a", "key-a")
+			Expect(err).To(MatchError(ErrObjectNotFound))
+		})
+	})
+
+	Describe("CopyObject", func() {
+		It("copies an object keeping metadata and tags", func() {
+			sut, api := newStubbed()
+			api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+				Expect(aws.ToString(params.Key)).To(Equal("dir/key a"))
+				return &s3.HeadObjectOutput{ContentLength: aws.Int64(5), ETag: aws.String(`"etag-a"`)}, nil
+			}
+			api.copyObject = func(ctx context.Context, params *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+				Expect(aws.ToString(params.Bucket)).To(Equal("bucket-b"))
+				Expect(aws.ToString(params.Key)).To(Equal("key-b"))
+				Expect(aws.ToString(params.CopySource)).To(Equal("bucket-a/dir/key%20a"))
+				Expect(aws.ToString(params.CopySourceIfMatch)).To(Equal(`"etag-a"`))
+				Expect(params.MetadataDirective).To(Equal(s3types.MetadataDirectiveCopy))
+				Expect(params.TaggingDirective).To(Equal(s3types.TaggingDirectiveCopy))
+				return &s3.CopyObjectOutput{}, nil
+			}
+
+			Expect(sut.CopyObject(context.Background(), "bucket-a", "dir/key a", "bucket-b", "key-b")).To(Succeed())
+		})
+
+		It("returns copy error", func() {
+			sut, api := newStubbed()
+			api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+				return &s3.HeadObjectOutput{ContentLength: aws.Int64(5)}, nil
+			}
+			api.copyObject = func(ctx context.Context, params *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+				return nil, apiErr{code: "NoSuchBucket"}
+			}
+
+			err := sut.CopyObject(context.Background(), "bucket-a", "key-a", "bucket-b", "key-b")
+			Expect(err).To(MatchError(ErrBucketNotFound))
+		})
 	})
 
 	Describe("isNotFoundError", func() {
@@ -482,12 +608,17 @@ var _ = Describe("S3 Client", func() {
 })
 
 type fakeTransferManager struct {
-	uploadInput *transfermanager.UploadObjectInput
-	err         error
+	uploadInput   *transfermanager.UploadObjectInput
+	uploadOptions transfermanager.Options
+	err           error
 }
 
 func (f *fakeTransferManager) UploadObject(ctx context.Context, params *transfermanager.UploadObjectInput, optFns ...func(*transfermanager.Options)) (*transfermanager.UploadObjectOutput, error) {
 	f.uploadInput = params
+	f.uploadOptions = transfermanager.Options{}
+	for _, fn := range optFns {
+		fn(&f.uploadOptions)
+	}
 	if f.err != nil {
 		return nil, f.err
 	}
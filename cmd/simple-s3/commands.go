@@ -0,0 +1,496 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// timeFormat is used for timestamps in text output.
+const timeFormat = "2006-01-02 15:04:05"
+
+// bucketResult is the JSON output of mb and rb.
+type bucketResult struct {
+	Bucket string `json:"bucket"`
+}
+
+// bucketEntry is a bucket listed by ls.
+type bucketEntry struct {
+	Name         string    `json:"name"`
+	CreationDate time.Time `json:"creationDate"`
+}
+
+// objectEntry is an object listed by ls or removed by rm.
+type objectEntry struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+}
+
+// transferResult is the JSON output of cp, get and put.
+type transferResult struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Bytes       int64  `json:"bytes"`
+}
+
+// presignResult is the JSON output of presign.
+type presignResult struct {
+	URL     string `json:"url"`
+	Method  string `json:"method"`
+	Expires string `json:"expires"`
+}
+
+// nargs returns a usage error unless args has between lo and hi entries.
+func nargs(args []string, lo, hi int) error {
+	if len(args) < lo || len(args) > hi {
+		return usageError(fmt.Sprintf("expected %s, got %d", plural(lo, hi), len(args)))
+	}
+	return nil
+}
+
+func plural(lo, hi int) string {
+	switch {
+	case lo == hi && lo == 1:
+		return "1 argument"
+	case lo == hi:
+		return fmt.Sprintf("%d arguments", lo)
+	default:
+		return fmt.Sprintf("%d to %d arguments", lo, hi)
+	}
+}
+
+func mbCommand(*flag.FlagSet) func(context.Context, *env, []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := nargs(args, 1, 1); err != nil {
+			return err
+		}
+		l, err := parseS3URL(args[0], false)
+		if err != nil {
+			return err
+		}
+		c, err := e.client(ctx)
+		if err != nil {
+			return err
+		}
+		if err := c.CreateBucket(ctx, l.bucket); err != nil {
+			return err
+		}
+		return e.output(bucketResult{Bucket: l.bucket}, func(w io.Writer) {
+			_, _ = fmt.Fprintf(w, "make_bucket: %s\n", l.bucket)
+		})
+	}
+}
+
+func rbCommand(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+	force := fs.Bool("force", false, "delete the objects in the bucket first")
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := nargs(args, 1, 1); err != nil {
+			return err
+		}
+		l, err := parseS3URL(args[0], false)
+		if err != nil {
+			return err
+		}
+		c, err := e.client(ctx)
+		if err != nil {
+			return err
+		}
+		// DeleteBucket removes any objects first, so an empty bucket is required unless forced.
+		if !*force {
+			empty, err := c.IsBucketEmpty(ctx, l.bucket)
+			if err != nil {
+				return err
+			}
+			if !empty {
+				return fmt.Errorf("bucket %s is not empty; use -force to delete its objects", l.bucket)
+			}
+		}
+		if err := c.DeleteBucket(ctx, l.bucket); err != nil {
+			return err
+		}
+		return e.output(bucketResult{Bucket: l.bucket}, func(w io.Writer) {
+			_, _ = fmt.Fprintf(w, "remove_bucket: %s\n", l.bucket)
+		})
+	}
+}
+
+func lsCommand(*flag.FlagSet) func(context.Context, *env, []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := nargs(args, 0, 1); err != nil {
+			return err
+		}
+		c, err := e.client(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			out, err := c.ListBuckets(ctx, "")
+			if err != nil {
+				return err
+			}
+			buckets := make([]bucketEntry, 0, len(out.Buckets))
+			for _, b := range out.Buckets {
+				buckets = append(buckets, bucketEntry{Name: aws.ToString(b.Name), CreationDate: aws.ToTime(b.CreationDate)})
+			}
+			return e.output(buckets, func(w io.Writer) {
+				for _, b := range buckets {
+					_, _ = fmt.Fprintf(w, "%s  %s\n", b.CreationDate.Format(timeFormat), b.Name)
+				}
+			})
+		}
+
+		l, err := parseS3URL(args[0], false)
+		if err != nil {
+			return err
+		}
+		keys, err := c.ListObject(ctx, l.bucket, l.key)
+		if err != nil {
+			return err
+		}
+		objects := make([]objectEntry, 0, len(keys))
+		for _, key := range keys {
+			objects = append(objects, objectEntry{Bucket: l.bucket, Key: key})
+		}
+		return e.output(objects, func(w io.Writer) {
+			for _, o := range objects {
+				_, _ = fmt.Fprintln(w, o.Key)
+			}
+		})
+	}
+}
+
+func cpCommand(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+	contentType := fs.String("content-type", "", "Content-Type of uploaded objects; empty detects it")
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := nargs(args, 2, 2); err != nil {
+			return err
+		}
+		src, err := parseLocation(args[0])
+		if err != nil {
+			return err
+		}
+		dst, err := parseLocation(args[1])
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case src.isRemote() && dst.isRemote():
+			if src.key == "" || strings.HasSuffix(src.key, "/") {
+				return usageError(fmt.Sprintf("source %s does not name an object", src))
+			}
+			if dst.key == "" || strings.HasSuffix(dst.key, "/") {
+				dst.key += path.Base(src.key)
+			}
+			c, err := e.client(ctx)
+			if err != nil {
+				return err
+			}
+			if err := c.CopyObject(ctx, src.bucket, src.key, dst.bucket, dst.key); err != nil {
+				return err
+			}
+			return e.transferred("copy", src, dst, -1)
+		case src.isRemote():
+			return download(ctx, e, src, dst.path)
+		case dst.isRemote():
+			if dst.key == "" || strings.HasSuffix(dst.key, "/") {
+				if src.path == "-" {
+					return usageError("a key is required when uploading from stdin")
+				}
+				dst.key += filepath.Base(src.path)
+			}
+			return upload(ctx, e, src.path, dst, *contentType)
+		default:
+			return usageError("at least one of source and destination must be an s3:// URL")
+		}
+	}
+}
+
+func getCommand(*flag.FlagSet) func(context.Context, *env, []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := nargs(args, 1, 2); err != nil {
+			return err
+		}
+		src, err := parseS3URL(args[0], true)
+		if err != nil {
+			return err
+		}
+		dst := "-"
+		if len(args) == 2 {
+			dst = args[1]
+		}
+		return download(ctx, e, src, dst)
+	}
+}
+
+func putCommand(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+	contentType := fs.String("content-type", "", "Content-Type of the object; empty detects it")
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := nargs(args, 2, 2); err != nil {
+			return err
+		}
+		dst, err := parseS3URL(args[1], false)
+		if err != nil {
+			return err
+		}
+		if dst.key == "" || strings.HasSuffix(dst.key, "/") {
+			if args[0] == "-" {
+				return usageError("a key is required when uploading from stdin")
+			}
+			dst.key += filepath.Base(args[0])
+		}
+		return upload(ctx, e, args[0], dst, *contentType)
+	}
+}
+
+func rmCommand(*flag.FlagSet) func(context.Context, *env, []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		if len(args) == 0 {
+			return usageError("expected at least 1 argument, got 0")
+		}
+		targets := make([]location, 0, len(args))
+		for _, arg := range args {
+			l, err := parseS3URL(arg, true)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, l)
+		}
+		c, err := e.client(ctx)
+		if err != nil {
+			return err
+		}
+
+		deleted := make([]objectEntry, 0, len(targets))
+		var errs []error
+		for _, l := range targets {
+			if err := c.DeleteObject(ctx, l.bucket, l.key); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			deleted = append(deleted, objectEntry{Bucket: l.bucket, Key: l.key})
+		}
+		if err := e.output(deleted, func(w io.Writer) {
+			for _, o := range deleted {
+				_, _ = fmt.Fprintf(w, "delete: s3://%s/%s\n", o.Bucket, o.Key)
+			}
+		}); err != nil {
+			return err
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func statCommand(*flag.FlagSet) func(context.Context, *env, []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := nargs(args, 1, 1); err != nil {
+			return err
+		}
+		l, err := parseS3URL(args[0], true)
+		if err != nil {
+			return err
+		}
+		c, err := e.client(ctx)
+		if err != nil {
+			return err
+		}
+		info, err := c.StatObject(ctx, l.bucket, l.key)
+		if err != nil {
+			return err
+		}
+		return e.output(info, func(w io.Writer) {
+			tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+			_, _ = fmt.Fprintf(tw, "Key:\t%s\n", l)
+			_, _ = fmt.Fprintf(tw, "Size:\t%d\n", info.Size)
+			_, _ = fmt.Fprintf(tw, "ETag:\t%s\n", info.ETag)
+			_, _ = fmt.Fprintf(tw, "Last modified:\t%s\n", info.LastModified.Format(timeFormat))
+			if info.ContentType != "" {
+				_, _ = fmt.Fprintf(tw, "Content type:\t%s\n", info.ContentType)
+			}
+			if info.ContentEncoding != "" {
+				_, _ = fmt.Fprintf(tw, "Content encoding:\t%s\n", info.ContentEncoding)
+			}
+			if info.StorageClass != "" {
+				_, _ = fmt.Fprintf(tw, "Storage class:\t%s\n", info.StorageClass)
+			}
+			for _, k := range sortedKeys(info.Metadata) {
+				_, _ = fmt.Fprintf(tw, "Metadata %s:\t%s\n", k, info.Metadata[k])
+			}
+			_ = tw.Flush()
+		})
+	}
+}
+
+func presignCommand(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+	method := fs.String("method", http.MethodGet, "HTTP method the URL permits, GET or PUT")
+	expires := fs.Duration("expires", 15*time.Minute, "how long the URL stays valid")
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := nargs(args, 1, 1); err != nil {
+			return err
+		}
+		l, err := parseS3URL(args[0], true)
+		if err != nil {
+			return err
+		}
+		m := strings.ToUpper(*method)
+		if m != http.MethodGet && m != http.MethodPut {
+			return usageError(fmt.Sprintf("unsupported method %q", *method))
+		}
+		if *expires <= 0 {
+			return usageError("-expires must be positive")
+		}
+		c, err := e.client(ctx)
+		if err != nil {
+			return err
+		}
+		u, err := c.PresignObject(ctx, l.bucket, l.key, func(o *types.PresignOptions) {
+			o.Method = m
+			o.Expires = *expires
+		})
+		if err != nil {
+			return err
+		}
+		return e.output(presignResult{URL: u, Method: m, Expires: expires.String()}, func(w io.Writer) {
+			_, _ = fmt.Fprintln(w, u)
+		})
+	}
+}
+
+// download writes the object at src to the local path dst, or to stdout when dst is "-". An
+// existing directory receives a file named after the key. Files are written to a temporary file
+// first, so a failed download leaves any existing file untouched.
+func download(ctx context.Context, e *env, src location, dst string) error {
+	if src.key == "" || strings.HasSuffix(src.key, "/") {
+		return usageError(fmt.Sprintf("source %s does not name an object", src))
+	}
+	c, err := e.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	if dst == "-" {
+		// The content occupies stdout, so nothing else is written there.
+		_, err := c.DownloadObject(ctx, src.bucket, src.key, e.stdout)
+		return err
+	}
+
+	if fi, err := os.Stat(dst); err == nil && fi.IsDir() {
+		dst = filepath.Join(dst, path.Base(src.key))
+	}
+	f, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".*")
+	if err != nil {
+		return err
+	}
+	n, err := c.DownloadObject(ctx, src.bucket, src.key, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), dst)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return e.transferred("download", src, location{path: dst}, n)
+}
+
+// upload stores the local file src, or stdin when src is "-", as the object dst.
+func upload(ctx context.Context, e *env, src string, dst location, contentType string) error {
+	c, err := e.client(ctx)
+	if err != nil {
+		return err
+	}
+	opt := func(o *types.PutObjectOptions) { o.ContentType = contentType }
+
+	if src == "-" {
+		r := &countingReader{r: e.stdin}
+		if err := c.PutObjectStream(ctx, dst.bucket, dst.key, r, opt); err != nil {
+			return err
+		}
+		return e.transferred("upload", location{path: src}, dst, r.n)
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("%s is a directory", src)
+	}
+	if err := c.PutObject(ctx, dst.bucket, dst.key, f, opt); err != nil {
+		return err
+	}
+	return e.transferred("upload", location{path: src}, dst, fi.Size())
+}
+
+// transferred reports a completed transfer. A negative size is omitted from the text output.
+func (e *env) transferred(verb string, src, dst location, n int64) error {
+	return e.output(transferResult{Source: src.String(), Destination: dst.String(), Bytes: max(n, 0)}, func(w io.Writer) {
+		if n < 0 {
+			_, _ = fmt.Fprintf(w, "%s: %s to %s\n", verb, src, dst)
+			return
+		}
+		_, _ = fmt.Fprintf(w, "%s: %s to %s (%d bytes)\n", verb, src, dst, n)
+	})
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
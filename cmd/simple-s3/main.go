@@ -0,0 +1,269 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command simple-s3 manages buckets and objects on S3 and S3-compatible services.
+//
+// Usage:
+//
+//	simple-s3 [global flags] <command> [flags] [arguments]
+//
+// The endpoint and credentials are read from the S3_ENDPOINT, S3_ACCESS_KEY, S3_SECRET_KEY and
+// S3_REGION environment variables, and can be overridden with flags. Objects are addressed as
+// s3://bucket/key. With -json, results are written to stdout as JSON for scripting.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	simple_s3 "github.com/drewbernetes/simple-s3"
+	"github.com/drewbernetes/simple-s3/pkg/util"
+)
+
+// Exit codes returned by run.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// newClient creates the client used by commands. It is a variable so tests can substitute a mock.
+var newClient = func(ctx context.Context, endpoint, accessKey, secretKey, region string) (util.S3Interface, error) {
+	return simple_s3.New(ctx, endpoint, accessKey, secretKey, region)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
+
+// config holds the settings shared by every command.
+type config struct {
+	endpoint  string
+	accessKey string
+	secretKey string
+	region    string
+	json      bool
+}
+
+// register adds the global flags to fs, defaulting to the current values so flags given before
+// and after the command name both apply.
+func (c *config) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.endpoint, "endpoint", c.endpoint, "S3 endpoint URL ($S3_ENDPOINT); empty uses AWS")
+	fs.StringVar(&c.accessKey, "access-key", c.accessKey, "access key ID ($S3_ACCESS_KEY)")
+	fs.StringVar(&c.secretKey, "secret-key", c.secretKey, "secret access key ($S3_SECRET_KEY)")
+	fs.StringVar(&c.region, "region", c.region, "region ($S3_REGION); empty uses us-east-1")
+	fs.BoolVar(&c.json, "json", c.json, "write results as JSON")
+}
+
+// env is the environment passed to a command.
+type env struct {
+	config
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// client creates a client from the configured endpoint and credentials.
+func (e *env) client(ctx context.Context) (util.S3Interface, error) {
+	if e.accessKey == "" || e.secretKey == "" {
+		return nil, errors.New("missing credentials: set -access-key and -secret-key, or S3_ACCESS_KEY and S3_SECRET_KEY")
+	}
+	return newClient(ctx, e.endpoint, e.accessKey, e.secretKey, e.region)
+}
+
+// output writes v as JSON in JSON mode, and otherwise calls text.
+func (e *env) output(v any, text func(w io.Writer)) error {
+	if !e.json {
+		text(e.stdout)
+		return nil
+	}
+	enc := json.NewEncoder(e.stdout)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// command is a subcommand of simple-s3.
+type command struct {
+	usage   string
+	summary string
+	setup   func(fs *flag.FlagSet) func(ctx context.Context, e *env, args []string) error
+}
+
+// commands lists the subcommands by name.
+var commands = map[string]command{
+	"mb":      {"mb s3://bucket", "create a bucket", mbCommand},
+	"rb":      {"rb [-force] s3://bucket", "remove a bucket", rbCommand},
+	"ls":      {"ls [s3://bucket[/prefix]]", "list buckets, or the objects in a bucket", lsCommand},
+	"cp":      {"cp <source> <destination>", "copy between local files and objects", cpCommand},
+	"get":     {"get s3://bucket/key [file|-]", "download an object to a file or stdout", getCommand},
+	"put":     {"put [-content-type type] <file|-> s3://bucket/key", "upload a file or stdin", putCommand},
+	"rm":      {"rm s3://bucket/key...", "remove objects", rmCommand},
+	"stat":    {"stat s3://bucket/key", "show object details", statCommand},
+	"presign": {"presign [-method GET|PUT] [-expires duration] s3://bucket/key", "create a presigned URL", presignCommand},
+}
+
+// run executes the command line args and returns the process exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) int {
+	e := &env{
+		config: config{
+			endpoint:  getenv("S3_ENDPOINT"),
+			accessKey: getenv("S3_ACCESS_KEY"),
+			secretKey: getenv("S3_SECRET_KEY"),
+			region:    getenv("S3_REGION"),
+		},
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	global := flag.NewFlagSet("simple-s3", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	e.register(global)
+	global.Usage = func() { usage(global) }
+	if err := global.Parse(args); err != nil {
+		return parseExit(err)
+	}
+	if global.NArg() == 0 {
+		usage(global)
+		return exitUsage
+	}
+
+	name := global.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "simple-s3: unknown command %q\n", name)
+		usage(global)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("simple-s3 "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	e.register(fs)
+	exec := cmd.setup(fs)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: simple-s3 %s\n\n%s.\n\nFlags:\n", cmd.usage, capitalise(cmd.summary))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(global.Args()[1:]); err != nil {
+		return parseExit(err)
+	}
+
+	if err := exec(ctx, e, fs.Args()); err != nil {
+		_, _ = fmt.Fprintf(stderr, "simple-s3 %s: %v\n", name, err)
+		var u usageError
+		if errors.As(err, &u) {
+			fs.Usage()
+			return exitUsage
+		}
+		return exitError
+	}
+	return exitOK
+}
+
+// usage prints the global usage and the list of commands.
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	_, _ = fmt.Fprintln(w, "Usage: simple-s3 [global flags] <command> [flags] [arguments]")
+	_, _ = fmt.Fprintln(w, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].summary)
+	}
+	_, _ = fmt.Fprintln(w, "\nGlobal flags:")
+	fs.PrintDefaults()
+}
+
+// parseExit returns the exit code for a flag parsing error, treating -h as success.
+func parseExit(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	return exitUsage
+}
+
+func capitalise(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// usageError reports arguments a command cannot accept.
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+// location is a parsed command argument: an object or prefix when bucket is set, otherwise a
+// local path.
+type location struct {
+	bucket string
+	key    string
+	path   string
+}
+
+// isRemote reports whether the location refers to S3.
+func (l location) isRemote() bool { return l.bucket != "" }
+
+func (l location) String() string {
+	if l.isRemote() {
+		return "s3://" + l.bucket + "/" + l.key
+	}
+	return l.path
+}
+
+// parseLocation parses an s3://bucket/key URL, or returns a local path for any other argument.
+func parseLocation(arg string) (location, error) {
+	rest, ok := strings.CutPrefix(arg, "s3://")
+	if !ok {
+		return location{path: arg}, nil
+	}
+	bucket, key, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return location{}, usageError(fmt.Sprintf("invalid S3 URL %q: missing bucket", arg))
+	}
+	return location{bucket: bucket, key: key}, nil
+}
+
+// parseS3URL parses an argument that must be an s3:// URL. With needKey set it must name an object.
+func parseS3URL(arg string, needKey bool) (location, error) {
+	l, err := parseLocation(arg)
+	if err != nil {
+		return l, err
+	}
+	if !l.isRemote() {
+		return l, usageError(fmt.Sprintf("expected an s3://bucket URL, got %q", arg))
+	}
+	if needKey && (l.key == "" || strings.HasSuffix(l.key, "/")) {
+		return l, usageError(fmt.Sprintf("expected an s3://bucket/key URL naming an object, got %q", arg))
+	}
+	return l, nil
+}
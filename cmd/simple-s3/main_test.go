@@ -0,0 +1,331 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/drewbernetes/simple-s3/pkg/mock"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+	"github.com/drewbernetes/simple-s3/pkg/util"
+)
+
+var _ = Describe("simple-s3", func() {
+	var (
+		client  *mock.MockS3Interface
+		env     map[string]string
+		stdin   string
+		created []string
+	)
+
+	BeforeEach(func() {
+		client = mock.NewMockS3Interface(gomock.NewController(GinkgoT()))
+		env = map[string]string{"S3_ACCESS_KEY": "ak", "S3_SECRET_KEY": "sk"}
+		stdin = ""
+		created = nil
+
+		orig := newClient
+		DeferCleanup(func() { newClient = orig })
+		newClient = func(ctx context.Context, endpoint, accessKey, secretKey, region string) (util.S3Interface, error) {
+			created = append(created, strings.Join([]string{endpoint, accessKey, secretKey, region}, ","))
+			return client, nil
+		}
+	})
+
+	execute := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr, func(k string) string { return env[k] })
+		return code, stdout.String(), stderr.String()
+	}
+
+	Describe("configuration", func() {
+		It("reads the environment", func() {
+			env["S3_ENDPOINT"] = "http://localhost:9000"
+			env["S3_REGION"] = "eu-west-1"
+			client.EXPECT().CreateBucket(gomock.Any(), "bucket-a").Return(nil)
+
+			code, _, _ := execute("mb", "s3://bucket-a")
+			Expect(code).To(Equal(exitOK))
+			Expect(created).To(Equal([]string{"http://localhost:9000,ak,sk,eu-west-1"}))
+		})
+
+		It("lets flags before and after the command override the environment", func() {
+			env["S3_ENDPOINT"] = "http://localhost:9000"
+			client.EXPECT().CreateBucket(gomock.Any(), "bucket-a").Return(nil)
+
+			code, _, _ := execute("-endpoint", "http://other:9000", "mb", "-access-key", "ak2", "s3://bucket-a")
+			Expect(code).To(Equal(exitOK))
+			Expect(created).To(Equal([]string{"http://other:9000,ak2,sk,"}))
+		})
+
+		It("requires credentials", func() {
+			delete(env, "S3_SECRET_KEY")
+
+			code, _, stderr := execute("mb", "s3://bucket-a")
+			Expect(code).To(Equal(exitError))
+			Expect(stderr).To(ContainSubstring("missing credentials"))
+			Expect(created).To(BeEmpty())
+		})
+
+		It("rejects unknown commands and bad arguments", func() {
+			code, _, stderr := execute("mv", "a", "b")
+			Expect(code).To(Equal(exitUsage))
+			Expect(stderr).To(ContainSubstring(`unknown command "mv"`))
+
+			code, _, stderr = execute("stat", "bucket-a/key")
+			Expect(code).To(Equal(exitUsage))
+			Expect(stderr).To(ContainSubstring("expected an s3://bucket URL"))
+
+			code, _, _ = execute("mb")
+			Expect(code).To(Equal(exitUsage))
+			Expect(created).To(BeEmpty())
+		})
+
+		It("prints usage without a command", func() {
+			code, _, stderr := execute()
+			Expect(code).To(Equal(exitUsage))
+			Expect(stderr).To(ContainSubstring("presign"))
+		})
+	})
+
+	Describe("buckets", func() {
+		It("removes an empty bucket", func() {
+			client.EXPECT().IsBucketEmpty(gomock.Any(), "bucket-a").Return(true, nil)
+			client.EXPECT().DeleteBucket(gomock.Any(), "bucket-a").Return(nil)
+
+			code, stdout, _ := execute("rb", "s3://bucket-a")
+			Expect(code).To(Equal(exitOK))
+			Expect(stdout).To(Equal("remove_bucket: bucket-a\n"))
+		})
+
+		It("refuses to remove a non-empty bucket without -force", func() {
+			client.EXPECT().IsBucketEmpty(gomock.Any(), "bucket-a").Return(false, nil)
+
+			code, _, stderr := execute("rb", "s3://bucket-a")
+			Expect(code).To(Equal(exitError))
+			Expect(stderr).To(ContainSubstring("use -force"))
+		})
+
+		It("removes a non-empty bucket with -force", func() {
+			client.EXPECT().DeleteBucket(gomock.Any(), "bucket-a").Return(nil)
+
+			code, _, _ := execute("rb", "-force", "s3://bucket-a")
+			Expect(code).To(Equal(exitOK))
+		})
+
+		It("lists buckets as JSON", func() {
+			created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			client.EXPECT().ListBuckets(gomock.Any(), "").Return(&s3.ListBucketsOutput{
+				Buckets: []s3types.Bucket{{Name: aws.String("bucket-a"), CreationDate: aws.Time(created)}},
+			}, nil)
+
+			code, stdout, _ := execute("-json", "ls")
+			Expect(code).To(Equal(exitOK))
+			var out []bucketEntry
+			Expect(json.Unmarshal([]byte(stdout), &out)).To(Succeed())
+			Expect(out).To(Equal([]bucketEntry{{Name: "bucket-a", CreationDate: created}}))
+		})
+
+		It("lists keys under a prefix", func() {
+			client.EXPECT().ListObject(gomock.Any(), "bucket-a", "dir/").Return([]string{"dir/a", "dir/b"}, nil)
+
+			code, stdout, _ := execute("ls", "s3://bucket-a/dir/")
+			Expect(code).To(Equal(exitOK))
+			Expect(stdout).To(Equal("dir/a\ndir/b\n"))
+		})
+	})
+
+	Describe("transfers", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+		})
+
+		It("uploads a file into a prefix", func() {
+			src := filepath.Join(dir, "a.txt")
+			Expect(os.WriteFile(src, []byte("payload"), 0o644)).To(Succeed())
+			client.EXPECT().PutObject(gomock.Any(), "bucket-a", "dir/a.txt", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, bucket, key string, body io.ReadSeeker, optFns ...func(*types.PutObjectOptions)) error {
+					o := types.PutObjectOptions{}
+					for _, fn := range optFns {
+						fn(&o)
+					}
+					Expect(o.ContentType).To(Equal("text/csv"))
+					data, err := io.ReadAll(body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(data)).To(Equal("payload"))
+					return nil
+				})
+
+			code, stdout, _ := execute("-json", "cp", "-content-type", "text/csv", src, "s3://bucket-a/dir/")
+			Expect(code).To(Equal(exitOK))
+			var out transferResult
+			Expect(json.Unmarshal([]byte(stdout), &out)).To(Succeed())
+			Expect(out).To(Equal(transferResult{Source: src, Destination: "s3://bucket-a/dir/a.txt", Bytes: 7}))
+		})
+
+		It("uploads stdin as a stream", func() {
+			stdin = "streamed"
+			client.EXPECT().PutObjectStream(gomock.Any(), "bucket-a", "key-a", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, bucket, key string, body io.Reader, optFns ...func(*types.PutObjectOptions)) error {
+					_, err := io.Copy(io.Discard, body)
+					return err
+				})
+
+			code, stdout, _ := execute("put", "-", "s3://bucket-a/key-a")
+			Expect(code).To(Equal(exitOK))
+			Expect(stdout).To(Equal("upload: - to s3://bucket-a/key-a (8 bytes)\n"))
+		})
+
+		It("requires a key when uploading stdin", func() {
+			code, _, _ := execute("put", "-", "s3://bucket-a/")
+			Expect(code).To(Equal(exitUsage))
+		})
+
+		It("downloads an object into a directory", func() {
+			client.EXPECT().DownloadObject(gomock.Any(), "bucket-a", "dir/a.txt", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, bucket, key string, w io.Writer, optFns ...func(*types.FetchObjectOptions)) (int64, error) {
+					n, err := io.WriteString(w, "payload")
+					return int64(n), err
+				})
+
+			code, stdout, _ := execute("cp", "s3://bucket-a/dir/a.txt", dir)
+			Expect(code).To(Equal(exitOK))
+			Expect(stdout).To(ContainSubstring("download: s3://bucket-a/dir/a.txt to "))
+			Expect(filepath.Join(dir, "a.txt")).To(BeARegularFile())
+			data, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("payload"))
+		})
+
+		It("leaves an existing file untouched when a download fails", func() {
+			dst := filepath.Join(dir, "a.txt")
+			Expect(os.WriteFile(dst, []byte("original"), 0o644)).To(Succeed())
+			client.EXPECT().DownloadObject(gomock.Any(), "bucket-a", "a.txt", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, bucket, key string, w io.Writer, optFns ...func(*types.FetchObjectOptions)) (int64, error) {
+					_, _ = io.WriteString(w, "partial")
+					return 7, errors.New("connection reset")
+				})
+
+			code, _, stderr := execute("get", "s3://bucket-a/a.txt", dst)
+			Expect(code).To(Equal(exitError))
+			Expect(stderr).To(ContainSubstring("connection reset"))
+			data, err := os.ReadFile(dst)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("original"))
+			entries, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+
+		It("writes to stdout by default", func() {
+			client.EXPECT().DownloadObject(gomock.Any(), "bucket-a", "key-a", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, bucket, key string, w io.Writer, optFns ...func(*types.FetchObjectOptions)) (int64, error) {
+					n, err := io.WriteString(w, "payload")
+					return int64(n), err
+				})
+
+			code, stdout, _ := execute("-json", "get", "s3://bucket-a/key-a")
+			Expect(code).To(Equal(exitOK))
+			Expect(stdout).To(Equal("payload"))
+		})
+
+		It("copies between buckets", func() {
+			client.EXPECT().CopyObject(gomock.Any(), "bucket-a", "dir/a.txt", "bucket-b", "a.txt").Return(nil)
+
+			code, stdout, _ := execute("cp", "s3://bucket-a/dir/a.txt", "s3://bucket-b")
+			Expect(code).To(Equal(exitOK))
+			Expect(stdout).To(Equal("copy: s3://bucket-a/dir/a.txt to s3://bucket-b/a.txt\n"))
+		})
+
+		It("rejects copies between local paths", func() {
+			code, _, _ := execute("cp", "a", "b")
+			Expect(code).To(Equal(exitUsage))
+		})
+	})
+
+	Describe("objects", func() {
+		It("removes every object and reports failures", func() {
+			client.EXPECT().DeleteObject(gomock.Any(), "bucket-a", "key-a").Return(nil)
+			client.EXPECT().DeleteObject(gomock.Any(), "bucket-a", "key-b").Return(errors.New("delete failed"))
+
+			code, stdout, stderr := execute("-json", "rm", "s3://bucket-a/key-a", "s3://bucket-a/key-b")
+			Expect(code).To(Equal(exitError))
+			Expect(stderr).To(ContainSubstring("delete failed"))
+			var out []objectEntry
+			Expect(json.Unmarshal([]byte(stdout), &out)).To(Succeed())
+			Expect(out).To(Equal([]objectEntry{{Bucket: "bucket-a", Key: "key-a"}}))
+		})
+
+		It("shows object details", func() {
+			client.EXPECT().StatObject(gomock.Any(), "bucket-a", "key-a").Return(&types.ObjectInfo{
+				Bucket:       "bucket-a",
+				Key:          "key-a",
+				Size:         7,
+				ETag:         "etag-a",
+				LastModified: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+				ContentType:  "text/plain",
+				Metadata:     map[string]string{"owner": "me"},
+			}, nil)
+
+			code, stdout, _ := execute("stat", "s3://bucket-a/key-a")
+			Expect(code).To(Equal(exitOK))
+			Expect(stdout).To(ContainSubstring("Size:           7\n"))
+			Expect(stdout).To(ContainSubstring("Last modified:  2026-01-02 03:04:05\n"))
+			Expect(stdout).To(ContainSubstring("Metadata owner: me\n"))
+		})
+
+		It("presigns a PUT URL", func() {
+			client.EXPECT().PresignObject(gomock.Any(), "bucket-a", "key-a", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, bucket, key string, optFns ...func(*types.PresignOptions)) (string, error) {
+					o := types.PresignOptions{}
+					for _, fn := range optFns {
+						fn(&o)
+					}
+					Expect(o).To(Equal(types.PresignOptions{Method: "PUT", Expires: time.Hour}))
+					return "https://example.local/bucket-a/key-a?sig", nil
+				})
+
+			code, stdout, _ := execute("-json", "presign", "-method", "put", "-expires", "1h", "s3://bucket-a/key-a")
+			Expect(code).To(Equal(exitOK))
+			var out presignResult
+			Expect(json.Unmarshal([]byte(stdout), &out)).To(Succeed())
+			Expect(out).To(Equal(presignResult{URL: "https://example.local/bucket-a/key-a?sig", Method: "PUT", Expires: "1h0m0s"}))
+		})
+
+		It("rejects unsupported presign methods", func() {
+			code, _, _ := execute("presign", "-method", "DELETE", "s3://bucket-a/key-a")
+			Expect(code).To(Equal(exitUsage))
+		})
+	})
+})
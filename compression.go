@@ -0,0 +1,43 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"io"
+
+	"github.com/drewbernetes/simple-s3/internal/compression"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// compressBody returns a reader producing body compressed with codec. Compression runs in a separate
+// goroutine, which stops once the returned reader is closed.
+func compressBody(codec types.Compression, body io.Reader) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	enc, err := compression.NewWriter(codec, pw)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		_, err := io.Copy(enc, body)
+		if closeErr := enc.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/internal/compression"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// compressed returns data encoded with codec.
+func compressed(codec types.Compression, data []byte) []byte {
+	var buf bytes.Buffer
+	enc, err := compression.NewWriter(codec, &buf)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = enc.Write(data)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(enc.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("Compression", func() {
+	var (
+		sut     *S3
+		api     *stubAPI
+		payload []byte
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		payload = []byte(strings.Repeat(`{"level":"info","msg":"request served"}`+"\n", 200))
+	})
+
+	Describe("uploads", func() {
+		var fake *readingTransferManager
+
+		BeforeEach(func() {
+			fake = &readingTransferManager{}
+			sut.transfer = fake
+		})
+
+		It("gzips the body and records the encoding and original size", func() {
+			err := sut.PutObject(context.Background(), "bucket-a", "logs/app.json", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+				o.Compression = types.CompressionGzip
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(aws.ToString(fake.input.ContentEncoding)).To(Equal("gzip"))
+			Expect(aws.ToString(fake.input.ContentType)).To(Equal("application/json"))
+			Expect(fake.input.ContentLength).To(BeNil())
+			Expect(fake.input.Metadata).To(HaveKeyWithValue("original-size", "8000"))
+			Expect(len(fake.read)).To(BeNumerically("<", len(payload)))
+
+			zr, err := gzip.NewReader(bytes.NewReader(fake.read))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(io.ReadAll(zr)).To(Equal(payload))
+		})
+
+		It("compresses with zstd", func() {
+			err := sut.PutObject(context.Background(), "bucket-a", "logs/app.json", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+				o.Compression = types.CompressionZstd
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aws.ToString(fake.input.ContentEncoding)).To(Equal("zstd"))
+
+			zr, err := zstd.NewReader(bytes.NewReader(fake.read))
+			Expect(err).NotTo(HaveOccurred())
+			defer zr.Close()
+			Expect(io.ReadAll(zr)).To(Equal(payload))
+		})
+
+		It("omits the original size when a stream's length is unknown", func() {
+			err := sut.PutObjectStream(context.Background(), "bucket-a", "logs/app.json", bytes.NewBuffer(payload), func(o *types.PutObjectOptions) {
+				o.Compression = types.CompressionGzip
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aws.ToString(fake.input.ContentEncoding)).To(Equal("gzip"))
+			Expect(fake.input.Metadata).To(BeEmpty())
+		})
+
+		It("reports progress against the uncompressed body", func() {
+			var events []types.ProgressEvent
+			err := sut.PutObject(context.Background(), "bucket-a", "logs/app.json", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+				o.Compression = types.CompressionGzip
+				o.Progress = func(e types.ProgressEvent) {
+					events = append(events, e)
+				}
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			last := events[len(events)-1]
+			Expect(last.Done).To(BeTrue())
+			Expect(last.BytesTransferred).To(Equal(int64(len(payload))))
+			Expect(last.TotalBytes).To(Equal(int64(len(payload))))
+		})
+
+		DescribeTable("rejects options that need the compressed length up front",
+			func(opt func(*types.PutObjectOptions)) {
+				err := sut.PutObject(context.Background(), "bucket-a", "logs/app.json", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+					o.Compression = types.CompressionGzip
+				}, opt)
+				Expect(err).To(MatchError(ContainSubstring("compressed uploads cannot be resumed")))
+				Expect(fake.input).To(BeNil())
+			},
+			Entry("resumable", func(o *types.PutObjectOptions) { o.Resumable = true }),
+			Entry("precomputed checksum", func(o *types.PutObjectOptions) {
+				o.ChecksumAlgorithm = types.ChecksumCRC32
+				o.Checksum = "AAAAAA=="
+			}),
+		)
+
+		It("rejects unknown codecs", func() {
+			err := sut.PutObject(context.Background(), "bucket-a", "logs/app.json", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+				o.Compression = "br"
+			})
+			Expect(err).To(MatchError(ContainSubstring(`unsupported compression "br"`)))
+		})
+	})
+
+	Describe("FetchObject", func() {
+		serve := func(encoding string, data []byte) {
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				return &s3.GetObjectOutput{
+					ContentEncoding: nonEmpty(encoding),
+					ContentLength:   aws.Int64(int64(len(data))),
+					Body:            io.NopCloser(bytes.NewReader(data)),
+				}, nil
+			}
+		}
+
+		DescribeTable("decompresses recognised encodings",
+			func(encoding string, codec types.Compression) {
+				serve(encoding, compressed(codec, payload))
+
+				data, err := sut.FetchObject(context.Background(), "logs/app.json", "bucket-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data).To(Equal(payload))
+			},
+			Entry("gzip", "gzip", types.CompressionGzip),
+			Entry("x-gzip", "x-gzip", types.CompressionGzip),
+			Entry("zstd", "zstd", types.CompressionZstd),
+		)
+
+		It("returns the stored bytes when decompression is disabled", func() {
+			stored := compressed(types.CompressionGzip, payload)
+			serve("gzip", stored)
+
+			data, err := sut.FetchObject(context.Background(), "logs/app.json", "bucket-a", func(o *types.FetchObjectOptions) {
+				o.DisableDecompression = true
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(stored))
+		})
+
+		It("returns objects with other encodings unchanged", func() {
+			serve("br", []byte("opaque"))
+
+			data, err := sut.FetchObject(context.Background(), "logs/app.json", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal([]byte("opaque")))
+		})
+
+		It("reports corrupt content", func() {
+			serve("gzip", []byte("not gzip data"))
+
+			_, err := sut.FetchObject(context.Background(), "logs/app.json", "bucket-a")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("OpenObject", func() {
+		var (
+			stored   []byte
+			metadata map[string]string
+			gets     int
+		)
+
+		BeforeEach(func() {
+			stored = compressed(types.CompressionZstd, payload)
+			metadata = map[string]string{"original-size": "8000"}
+			gets = 0
+			api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+				return &s3.HeadObjectOutput{
+					ContentLength:   aws.Int64(int64(len(stored))),
+					ContentEncoding: aws.String("zstd"),
+					ETag:            aws.String(`"etag-a"`),
+					Metadata:        metadata,
+				}, nil
+			}
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				gets++
+				Expect(params.Range).To(BeNil())
+				return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(stored))}, nil
+			}
+		})
+
+		It("reads decompressed content with the original size", func() {
+			r, err := sut.OpenObject(context.Background(), "bucket-a", "logs/app.json")
+			Expect(err).NotTo(HaveOccurred())
+			defer r.Close() //nolint:all
+
+			Expect(r.Size()).To(Equal(int64(len(payload))))
+			Expect(io.ReadAll(r)).To(Equal(payload))
+		})
+
+		It("decodes again from the start when seeking backwards", func() {
+			r, err := sut.OpenObject(context.Background(), "bucket-a", "logs/app.json")
+			Expect(err).NotTo(HaveOccurred())
+			defer r.Close() //nolint:all
+
+			_, err = io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.Seek(-10, io.SeekEnd)).To(Equal(int64(len(payload) - 10)))
+			Expect(io.ReadAll(r)).To(Equal(payload[len(payload)-10:]))
+			Expect(gets).To(Equal(2))
+		})
+
+		It("learns the size at the end when it was not recorded", func() {
+			metadata = nil
+
+			r, err := sut.OpenObject(context.Background(), "bucket-a", "logs/app.json")
+			Expect(err).NotTo(HaveOccurred())
+			defer r.Close() //nolint:all
+
+			Expect(r.Size()).To(Equal(int64(-1)))
+			_, err = r.Seek(0, io.SeekEnd)
+			Expect(err).To(MatchError(ContainSubstring("size of zstd encoded object is unknown")))
+
+			Expect(io.ReadAll(r)).To(Equal(payload))
+			Expect(r.Size()).To(Equal(int64(len(payload))))
+		})
+
+		It("does not support ReadAt", func() {
+			r, err := sut.OpenObject(context.Background(), "bucket-a", "logs/app.json")
+			Expect(err).NotTo(HaveOccurred())
+			defer r.Close() //nolint:all
+
+			_, err = r.ReadAt(make([]byte, 4), 0)
+			Expect(err).To(MatchError(ContainSubstring("random access is not supported")))
+		})
+
+		It("reads the stored bytes when decompression is disabled", func() {
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				data, err := applyRange(stored, aws.ToString(params.Range))
+				Expect(err).NotTo(HaveOccurred())
+				return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+			}
+
+			r, err := sut.OpenObject(context.Background(), "bucket-a", "logs/app.json", func(o *types.OpenObjectOptions) {
+				o.DisableDecompression = true
+			})
+			Expect(err).NotTo(HaveOccurred())
+			defer r.Close() //nolint:all
+
+			Expect(r.Size()).To(Equal(int64(len(stored))))
+			Expect(io.ReadAll(r)).To(Equal(stored))
+		})
+	})
+})
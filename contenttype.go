@@ -0,0 +1,32 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import "github.com/drewbernetes/simple-s3/internal/contenttype"
+
+// contentType resolves the Content-Type of an upload. An explicit type is used as is. Otherwise the
+// extension of the key is looked up in the client table, the default table and the system MIME
+// database in turn, and sniff detects the type from the content when none of them match.
+func (s *S3) contentType(key, explicit string, sniff func() (string, error)) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	if ct := contenttype.ByExtension(key, s.options.ContentTypes); ct != "" {
+		return ct, nil
+	}
+	return sniff()
+}
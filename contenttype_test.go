@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("Content type detection", func() {
+	var (
+		sut *S3
+	)
+
+	BeforeEach(func() {
+		sut, _ = newStubbed()
+	})
+
+	sniffed := func() (string, error) {
+		return "sniffed/type", nil
+	}
+
+	DescribeTable("resolves the type from the key's extension",
+		func(key, expected string) {
+			ct, err := sut.contentType(key, "", sniffed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ct).To(Equal(expected))
+		},
+		Entry("css", "site/style.css", "text/css; charset=utf-8"),
+		Entry("javascript", "site/app.js", "text/javascript; charset=utf-8"),
+		Entry("svg", "img/logo.svg", "image/svg+xml"),
+		Entry("json", "data/config.json", "application/json"),
+		Entry("upper-case extension", "img/LOGO.SVG", "image/svg+xml"),
+		Entry("system MIME database", "img/photo.png", "image/png"),
+		Entry("no extension", "README", "sniffed/type"),
+		Entry("unknown extension", "data/file.unknown-ext", "sniffed/type"),
+	)
+
+	It("prefers the client table over the defaults", func() {
+		sut.options.ContentTypes = map[string]string{".json": "application/vnd.api+json", ".ndjson": "application/x-ndjson"}
+
+		ct, err := sut.contentType("data/config.json", "", sniffed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ct).To(Equal("application/vnd.api+json"))
+
+		ct, err = sut.contentType("data/events.ndjson", "", sniffed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ct).To(Equal("application/x-ndjson"))
+	})
+
+	It("uses an explicit type without detection", func() {
+		ct, err := sut.contentType("site/style.css", "text/x-custom", func() (string, error) {
+			return "", errors.New("sniff must not run")
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ct).To(Equal("text/x-custom"))
+	})
+
+	It("returns sniffing errors", func() {
+		_, err := sut.contentType("README", "", func() (string, error) {
+			return "", errors.New("read failed")
+		})
+		Expect(err).To(MatchError("read failed"))
+	})
+
+	Describe("uploads", func() {
+		var fakeClient *fakeTransferManager
+
+		BeforeEach(func() {
+			fakeClient = &fakeTransferManager{}
+			sut.transfer = fakeClient
+		})
+
+		It("labels web assets by extension even when the content sniffs as text", func() {
+			err := sut.PutObject(context.Background(), "bucket-a", "site/style.css", bytes.NewReader([]byte("body { color: red; }")))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aws.ToString(fakeClient.uploadInput.ContentType)).To(Equal("text/css; charset=utf-8"))
+		})
+
+		It("uses the explicit content type on PutObject and PutObjectStream", func() {
+			explicit := func(o *types.PutObjectOptions) {
+				o.ContentType = "application/octet-stream"
+			}
+
+			Expect(sut.PutObject(context.Background(), "bucket-a", "site/app.js", bytes.NewReader([]byte("x")), explicit)).To(Succeed())
+			Expect(aws.ToString(fakeClient.uploadInput.ContentType)).To(Equal("application/octet-stream"))
+
+			Expect(sut.PutObjectStream(context.Background(), "bucket-a", "site/app.js", bytes.NewReader([]byte("x")), explicit)).To(Succeed())
+			Expect(aws.ToString(fakeClient.uploadInput.ContentType)).To(Equal("application/octet-stream"))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// copyMultipart copies the object described by head in parts with UploadPartCopy, carrying over its
+// user metadata, content headers and tags. Every part is copied from the version with the ETag in
+// head, and the upload is aborted if any part fails.
+func (s *S3) copyMultipart(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string, head *s3.HeadObjectOutput) error {
+	tagging, err := s.objectTagging(ctx, srcBucket, srcKey)
+	if err != nil {
+		return err
+	}
+
+	created, err := s.client().CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:                  aws.String(dstBucket),
+		Key:                     aws.String(dstKey),
+		CacheControl:            head.CacheControl,
+		ContentDisposition:      head.ContentDisposition,
+		ContentEncoding:         head.ContentEncoding,
+		ContentLanguage:         head.ContentLanguage,
+		ContentType:             head.ContentType,
+		Metadata:                head.Metadata,
+		Tagging:                 tagging,
+		WebsiteRedirectLocation: head.WebsiteRedirectLocation,
+	})
+	if err != nil {
+		return err
+	}
+	uploadID := aws.ToString(created.UploadId)
+
+	m := s.multipartOptions(types.MultipartOptions{})
+	size := aws.ToInt64(head.ContentLength)
+	partSize := uploadPartSize(m, size)
+	parts := make([]s3types.CompletedPart, (size+partSize-1)/partSize)
+	err = forEach(ctx, len(parts), int(firstPositive(int64(m.Concurrency), defaultConcurrency)), func(ctx context.Context, i int) error {
+		start := int64(i) * partSize
+		out, err := s.client().UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+			Bucket:            aws.String(dstBucket),
+			Key:               aws.String(dstKey),
+			UploadId:          aws.String(uploadID),
+			PartNumber:        aws.Int32(int32(i + 1)),
+			CopySource:        aws.String(copySource(srcBucket, srcKey)),
+			CopySourceIfMatch: head.ETag,
+			CopySourceRange:   aws.String(fmt.Sprintf("bytes=%d-%d", start, min(start+partSize, size)-1)),
+		})
+		if err != nil {
+			return err
+		}
+		parts[i] = s3types.CompletedPart{PartNumber: aws.Int32(int32(i + 1))}
+		if out.CopyPartResult != nil {
+			parts[i].ETag = out.CopyPartResult.ETag
+		}
+		return nil
+	})
+	if err == nil {
+		_, err = s.client().CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(dstBucket),
+			Key:             aws.String(dstKey),
+			UploadId:        aws.String(uploadID),
+			MultipartUpload: &s3types.CompletedMultipartUpload{Parts: parts},
+		})
+	}
+	if err != nil {
+		s.abortUpload(ctx, dstBucket, dstKey, uploadID)
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CopyObject of large objects", func() {
+	var (
+		sut       *S3
+		api       *stubAPI
+		size      int64
+		mu        sync.Mutex
+		ranges    map[int32]string
+		created   *s3.CreateMultipartUploadInput
+		completed *s3.CompleteMultipartUploadInput
+		aborted   bool
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		size = maxCopySize + 1
+		ranges = map[int32]string{}
+		created, completed, aborted = nil, nil, false
+
+		api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+			return &s3.HeadObjectOutput{
+				ContentLength:   aws.Int64(size),
+				ETag:            aws.String(`"etag-a"`),
+				ContentType:     aws.String("video/mp4"),
+				ContentEncoding: aws.String("gzip"),
+				CacheControl:    aws.String("max-age=60"),
+				Metadata:        map[string]string{"owner": "team-a"},
+			}, nil
+		}
+		api.getObjectTagging = func(ctx context.Context, params *s3.GetObjectTaggingInput) (*s3.GetObjectTaggingOutput, error) {
+			return &s3.GetObjectTaggingOutput{TagSet: []s3types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}}, nil
+		}
+		api.createMultipartUpload = func(ctx context.Context, params *s3.CreateMultipartUploadInput) (*s3.CreateMultipartUploadOutput, error) {
+			created = params
+			return &s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil
+		}
+		api.uploadPartCopy = func(ctx context.Context, params *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
+			Expect(aws.ToString(params.CopySource)).To(Equal("bucket-a/big"))
+			Expect(aws.ToString(params.CopySourceIfMatch)).To(Equal(`"etag-a"`))
+			mu.Lock()
+			defer mu.Unlock()
+			n := aws.ToInt32(params.PartNumber)
+			ranges[n] = aws.ToString(params.CopySourceRange)
+			return &s3.UploadPartCopyOutput{CopyPartResult: &s3types.CopyPartResult{ETag: aws.String(fmt.Sprintf("etag-%d", n))}}, nil
+		}
+		api.completeMultipartUpload = func(ctx context.Context, params *s3.CompleteMultipartUploadInput) (*s3.CompleteMultipartUploadOutput, error) {
+			completed = params
+			return &s3.CompleteMultipartUploadOutput{}, nil
+		}
+		api.abortMultipartUpload = func(ctx context.Context, params *s3.AbortMultipartUploadInput) (*s3.AbortMultipartUploadOutput, error) {
+			aborted = true
+			return &s3.AbortMultipartUploadOutput{}, nil
+		}
+	})
+
+	It("copies in parts keeping metadata, content headers and tags", func() {
+		Expect(sut.CopyObject(context.Background(), "bucket-a", "big", "bucket-b", "copy")).To(Succeed())
+
+		Expect(aws.ToString(created.Bucket)).To(Equal("bucket-b"))
+		Expect(aws.ToString(created.Key)).To(Equal("copy"))
+		Expect(aws.ToString(created.ContentType)).To(Equal("video/mp4"))
+		Expect(aws.ToString(created.ContentEncoding)).To(Equal("gzip"))
+		Expect(aws.ToString(created.CacheControl)).To(Equal("max-age=60"))
+		Expect(created.Metadata).To(Equal(map[string]string{"owner": "team-a"}))
+		Expect(aws.ToString(created.Tagging)).To(Equal("env=prod"))
+
+		// The default 100 MiB parts cover 5 GiB in 51 parts plus a final byte.
+		Expect(ranges).To(HaveLen(52))
+		Expect(ranges[1]).To(Equal(fmt.Sprintf("bytes=0-%d", defaultPartSize-1)))
+		Expect(ranges[52]).To(Equal(fmt.Sprintf("bytes=%d-%d", 51*defaultPartSize, size-1)))
+		Expect(completed.MultipartUpload.Parts).To(HaveLen(52))
+		Expect(aws.ToString(completed.MultipartUpload.Parts[51].ETag)).To(Equal("etag-52"))
+		Expect(aborted).To(BeFalse())
+	})
+
+	It("aborts the upload when a part fails", func() {
+		api.uploadPartCopy = func(ctx context.Context, params *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
+			return nil, apiErr{code: "PreconditionFailed"}
+		}
+
+		err := sut.CopyObject(context.Background(), "bucket-a", "big", "bucket-b", "copy")
+		Expect(err).To(MatchError(ErrPreconditionFailed))
+		Expect(aborted).To(BeTrue())
+		Expect(completed).To(BeNil())
+	})
+})
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/drewbernetes/simple-s3/internal/dirwalk"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// UploadDirectory uploads the files below localDir to keys under prefix, using the path of each file
+// relative to localDir with forward slashes.
+//
+// Files are uploaded concurrently through PutObject. A failed file does not stop the others: the
+// summary lists what was uploaded, skipped and failed, and the returned error joins the failures.
+func (s *S3) UploadDirectory(ctx context.Context, localDir, bucket, prefix string, optFns ...func(*types.UploadDirectoryOptions)) (_ *types.TransferSummary, err error) {
+	ctx, call := s.startOperation(ctx, "UploadDirectory", bucket, prefix)
+	defer func() { call.end(ctx, err) }()
+
+	o := types.UploadDirectoryOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if err = dirwalk.ValidateGlobs(o.Include, o.Exclude); err != nil {
+		return nil, newError("UploadDirectory", bucket, prefix, err)
+	}
+
+	w, err := dirwalk.Walk(localDir, prefix, o)
+	if err != nil {
+		return nil, newError("UploadDirectory", bucket, prefix, err)
+	}
+
+	summary := &types.TransferSummary{Skipped: w.Skipped, Failed: w.Failed}
+	var mu sync.Mutex
+	concurrency := int(firstPositive(int64(o.Concurrency), defaultConcurrency))
+	err = forEach(ctx, len(w.Files), concurrency, func(ctx context.Context, i int) error {
+		f := w.Files[i]
+		f.Err = s.uploadFile(ctx, bucket, f, o.ObjectOptions)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if f.Err != nil {
+			summary.Failed = append(summary.Failed, f)
+			return nil
+		}
+		summary.Transferred = append(summary.Transferred, f)
+		summary.Bytes += f.Size
+		return nil
+	})
+	dirwalk.SortTransfers(summary)
+
+	errs := make([]error, 0, len(summary.Failed)+1)
+	for _, f := range summary.Failed {
+		errs = append(errs, f.Err)
+	}
+	if err != nil {
+		errs = append(errs, newError("UploadDirectory", bucket, prefix, err))
+	}
+	call.setSize(summary.Bytes)
+	return summary, errors.Join(errs...)
+}
+
+// uploadFile uploads a single local file.
+func (s *S3) uploadFile(ctx context.Context, bucket string, f types.FileTransfer, optFns []func(*types.PutObjectOptions)) error {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return newError("PutObject", bucket, f.Key, err)
+	}
+	defer file.Close() //nolint:all
+
+	return s.PutObject(ctx, bucket, f.Key, file, optFns...)
+}
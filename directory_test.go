@@ -0,0 +1,255 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// bucketTransferManager stores uploads by key and fails those listed in failKeys. The input of each
+// upload is kept when inputs is set.
+type bucketTransferManager struct {
+	mu       sync.Mutex
+	objects  map[string]string
+	inputs   map[string]*transfermanager.UploadObjectInput
+	failKeys map[string]error
+}
+
+func (b *bucketTransferManager) UploadObject(ctx context.Context, params *transfermanager.UploadObjectInput, optFns ...func(*transfermanager.Options)) (*transfermanager.UploadObjectOutput, error) {
+	key := aws.ToString(params.Key)
+	if err := b.failKeys[key]; err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objects[key] = string(data)
+	if b.inputs != nil {
+		b.inputs[key] = params
+	}
+	return &transfermanager.UploadObjectOutput{}, nil
+}
+
+// writeTree creates files below dir from a map of slash-separated paths to contents.
+func writeTree(dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		Expect(os.MkdirAll(filepath.Dir(p), 0o755)).To(Succeed())
+		Expect(os.WriteFile(p, []byte(content), 0o644)).To(Succeed())
+	}
+}
+
+func keys(files []types.FileTransfer) []string {
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		out = append(out, f.Key)
+	}
+	return out
+}
+
+var _ = Describe("UploadDirectory", func() {
+	var (
+		sut  *S3
+		fake *bucketTransferManager
+		dir  string
+	)
+
+	BeforeEach(func() {
+		sut, _ = newStubbed()
+		fake = &bucketTransferManager{objects: map[string]string{}}
+		sut.transfer = fake
+		dir = GinkgoT().TempDir()
+		writeTree(dir, map[string]string{
+			"index.html":           "<html></html>",
+			"assets/app.js":        "console.log(1)",
+			"assets/app.js.map":    "{}",
+			"assets/img/logo.svg":  "<svg/>",
+			"node_modules/x/a.js":  "x",
+			"node_modules/x/b.txt": "y",
+		})
+	})
+
+	It("uploads every file under the prefix", func() {
+		summary, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "site/v1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(keys(summary.Transferred)).To(Equal([]string{
+			"site/v1/assets/app.js",
+			"site/v1/assets/app.js.map",
+			"site/v1/assets/img/logo.svg",
+			"site/v1/index.html",
+			"site/v1/node_modules/x/a.js",
+			"site/v1/node_modules/x/b.txt",
+		}))
+		Expect(summary.Bytes).To(Equal(int64(13 + 14 + 2 + 6 + 1 + 1)))
+		Expect(summary.Failed).To(BeEmpty())
+		Expect(fake.objects).To(HaveKeyWithValue("site/v1/assets/img/logo.svg", "<svg/>"))
+	})
+
+	DescribeTable("joins the prefix and relative path",
+		func(prefix, expected string) {
+			_, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", prefix, func(o *types.UploadDirectoryOptions) {
+				o.Include = []string{"index.html"}
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fake.objects).To(HaveKey(expected))
+		},
+		Entry("no prefix", "", "index.html"),
+		Entry("trailing slash", "site/", "site/index.html"),
+	)
+
+	It("applies include and exclude filters", func() {
+		summary, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "", func(o *types.UploadDirectoryOptions) {
+			o.Include = []string{"*.js", "assets/**/*.svg"}
+			o.Exclude = []string{"node_modules", "**/*.map"}
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(keys(summary.Transferred)).To(Equal([]string{"assets/app.js", "assets/img/logo.svg"}))
+		Expect(keys(summary.Skipped)).To(Equal([]string{"assets/app.js.map", "index.html"}))
+	})
+
+	It("rejects malformed patterns before uploading", func() {
+		_, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "", func(o *types.UploadDirectoryOptions) {
+			o.Exclude = []string{"[a-"}
+		})
+		Expect(err).To(MatchError(ContainSubstring(`invalid pattern "[a-"`)))
+		Expect(fake.objects).To(BeEmpty())
+	})
+
+	Describe("symbolic links", func() {
+		BeforeEach(func() {
+			shared := GinkgoT().TempDir()
+			writeTree(shared, map[string]string{"fonts/a.woff2": "font"})
+			Expect(os.Symlink(filepath.Join(shared, "fonts"), filepath.Join(dir, "fonts"))).To(Succeed())
+			Expect(os.Symlink(filepath.Join(dir, "index.html"), filepath.Join(dir, "home.html"))).To(Succeed())
+			Expect(os.Symlink(dir, filepath.Join(dir, "assets", "loop"))).To(Succeed())
+		})
+
+		It("skips links by default", func() {
+			summary, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "", func(o *types.UploadDirectoryOptions) {
+				o.Exclude = []string{"node_modules"}
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(keys(summary.Skipped)).To(Equal([]string{"assets/loop", "fonts", "home.html"}))
+			Expect(fake.objects).NotTo(HaveKey("home.html"))
+		})
+
+		It("follows links without looping", func() {
+			summary, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "", func(o *types.UploadDirectoryOptions) {
+				o.FollowSymlinks = true
+				o.Exclude = []string{"node_modules"}
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(keys(summary.Transferred)).To(Equal([]string{
+				"assets/app.js",
+				"assets/app.js.map",
+				"assets/img/logo.svg",
+				"fonts/a.woff2",
+				"home.html",
+				"index.html",
+			}))
+			Expect(fake.objects).To(HaveKeyWithValue("fonts/a.woff2", "font"))
+		})
+
+		It("walks a directory once for each link to it and skips loops", func() {
+			Expect(os.Symlink(filepath.Join(dir, "fonts"), filepath.Join(dir, "assets", "fonts"))).To(Succeed())
+
+			summary, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "", func(o *types.UploadDirectoryOptions) {
+				o.FollowSymlinks = true
+				o.Exclude = []string{"node_modules"}
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(keys(summary.Transferred)).To(ContainElements("fonts/a.woff2", "assets/fonts/a.woff2"))
+			Expect(keys(summary.Skipped)).To(Equal([]string{"assets/loop"}))
+			Expect(summary.Skipped[0].Err).To(MatchError(ContainSubstring("loops back")))
+		})
+
+		It("reports broken links as failures", func() {
+			Expect(os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "broken"))).To(Succeed())
+
+			summary, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "", func(o *types.UploadDirectoryOptions) {
+				o.FollowSymlinks = true
+			})
+			Expect(err).To(MatchError(os.ErrNotExist))
+			Expect(keys(summary.Failed)).To(Equal([]string{"broken"}))
+			Expect(summary.Transferred).NotTo(BeEmpty())
+		})
+	})
+
+	It("reports unreadable directories and keeps walking", func() {
+		if os.Geteuid() == 0 {
+			Skip("directory permissions do not apply to root")
+		}
+		private := filepath.Join(dir, "private")
+		Expect(os.Mkdir(private, 0o000)).To(Succeed())
+		DeferCleanup(os.Chmod, private, os.FileMode(0o700))
+
+		summary, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "")
+		Expect(err).To(MatchError(os.ErrPermission))
+		Expect(keys(summary.Failed)).To(Equal([]string{"private"}))
+		Expect(summary.Transferred).NotTo(BeEmpty())
+	})
+
+	It("continues past failed files and returns their errors", func() {
+		fake.failKeys = map[string]error{"assets/app.js": apiErr{code: "AccessDenied"}}
+
+		summary, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "", func(o *types.UploadDirectoryOptions) {
+			o.Concurrency = 2
+		})
+		Expect(err).To(MatchError(ErrAccessDenied))
+		Expect(keys(summary.Failed)).To(Equal([]string{"assets/app.js"}))
+		Expect(summary.Transferred).To(HaveLen(5))
+	})
+
+	It("applies object options to every upload", func() {
+		var calls int
+		var mu sync.Mutex
+		_, err := sut.UploadDirectory(context.Background(), dir, "bucket-a", "", func(o *types.UploadDirectoryOptions) {
+			o.ObjectOptions = []func(*types.PutObjectOptions){func(po *types.PutObjectOptions) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+			}}
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(6))
+	})
+
+	It("fails when the directory cannot be read", func() {
+		_, err := sut.UploadDirectory(context.Background(), filepath.Join(dir, "missing"), "bucket-a", "")
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+})
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/drewbernetes/simple-s3/internal/dirwalk"
+	"github.com/drewbernetes/simple-s3/internal/localfs"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// DownloadPrefix downloads the objects under prefix into localDir, recreating the key hierarchy
+// below the prefix. The prefix is treated as a directory, so "logs" downloads "logs/a" but not
+// "logs2/a".
+//
+// Objects are downloaded concurrently and written byte for byte, so compressed objects are not
+// decoded. They are verified against their stored checksums as FetchObject does, with the same
+// GetObjectAttributes requests for objects uploaded in parts. Each file is written to a temporary
+// file that is renamed into place once complete, and its modification time is set to the object's.
+// Files that already have the size and ETag of their object are skipped. Keys containing ".."
+// elements or otherwise resolving outside localDir are not downloaded, nor are keys whose path
+// passes through a symbolic link leading outside it. A failed object does not stop the others: the
+// summary lists what was downloaded, skipped and failed, and the returned error joins the failures.
+func (s *S3) DownloadPrefix(ctx context.Context, bucket, prefix, localDir string, optFns ...func(*types.DownloadPrefixOptions)) (_ *types.TransferSummary, err error) {
+	ctx, call := s.startOperation(ctx, "DownloadPrefix", bucket, prefix)
+	defer func() { call.end(ctx, err) }()
+
+	o := types.DownloadPrefixOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	// Listing below the prefix as a directory keeps "logs" from matching "logs2".
+	listPrefix := ""
+	if prefix != "" {
+		listPrefix = strings.TrimSuffix(prefix, "/") + "/"
+	}
+	objects, err := listObjectsV2All(ctx, s.client(), bucket, listPrefix)
+	if err != nil {
+		return nil, newError("DownloadPrefix", bucket, prefix, err)
+	}
+
+	root, err := localfs.OpenRoot(localDir)
+	if err != nil {
+		return nil, newError("DownloadPrefix", bucket, prefix, err)
+	}
+	defer root.Close() //nolint:all
+
+	summary := &types.TransferSummary{}
+	var mu sync.Mutex
+	concurrency := int(firstPositive(int64(o.Concurrency), defaultConcurrency))
+	err = forEach(ctx, len(objects), concurrency, func(ctx context.Context, i int) error {
+		obj := objects[i]
+		key := aws.ToString(obj.Key)
+		rel := strings.TrimPrefix(key, listPrefix)
+		if rel == "" || strings.HasSuffix(key, "/") {
+			// Zero-byte keys ending in a slash are folder markers created by consoles and other tools.
+			return nil
+		}
+
+		f := types.FileTransfer{Key: key, Size: aws.ToInt64(obj.Size)}
+		skip := false
+		f.Path, f.Err = dirwalk.LocalPath(localDir, rel)
+		name := filepath.FromSlash(rel)
+		if f.Err == nil {
+			skip, f.Err = upToDate(root, name, f.Size, aws.ToString(obj.ETag), s.etagPartSize(o.PartSize, f.Size))
+		}
+		if f.Err == nil && !skip {
+			f.Err = s.downloadFile(ctx, root, bucket, obj, name)
+		}
+		f.Err = newError("DownloadPrefix", bucket, key, f.Err)
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case f.Err != nil:
+			summary.Failed = append(summary.Failed, f)
+		case skip:
+			summary.Skipped = append(summary.Skipped, f)
+		default:
+			summary.Transferred = append(summary.Transferred, f)
+			summary.Bytes += f.Size
+			call.transferred(ctx, f.Size)
+		}
+		return nil
+	})
+	dirwalk.SortTransfers(summary)
+
+	errs := make([]error, 0, len(summary.Failed)+1)
+	for _, f := range summary.Failed {
+		errs = append(errs, f.Err)
+	}
+	if err != nil {
+		errs = append(errs, newError("DownloadPrefix", bucket, prefix, err))
+	}
+	call.setSize(summary.Bytes)
+	return summary, errors.Join(errs...)
+}
+
+// etagPartSize returns the part size used for an upload of size bytes in parts of partSize, or of
+// the client's part size when zero, for comparison with multipart ETags.
+func (s *S3) etagPartSize(partSize, size int64) int64 {
+	// Only objects uploaded in parts have multipart ETags, so the threshold does not apply.
+	m := types.MultipartOptions{PartSize: s.multipartOptions(types.MultipartOptions{PartSize: partSize}).PartSize}
+	return uploadPartSize(m, size)
+}
+
+// upToDate reports whether the regular file name below root has the given size and ETag.
+func upToDate(root *os.Root, name string, size int64, etag string, partSize int64) (bool, error) {
+	f, info, err := localfs.OpenRegular(root, name)
+	if err != nil || f == nil {
+		return false, err
+	}
+	defer f.Close() //nolint:all
+
+	if info.Size() != size {
+		return false, nil
+	}
+	return readerMatchesETag(f, etag, partSize)
+}
+
+// downloadFile writes an object to name below root through a temporary file in the same directory,
+// so an interrupted download never leaves a partial file in place.
+func (s *S3) downloadFile(ctx context.Context, root *os.Root, bucket string, obj s3types.Object, name string) error {
+	out, err := s.client().GetObject(ctx, &s3.GetObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          obj.Key,
+		IfMatch:      obj.ETag,
+		ChecksumMode: s3types.ChecksumModeEnabled,
+	}, withoutSDKChecksumValidation)
+	if err != nil {
+		return err
+	}
+
+	defer out.Body.Close() //nolint:all
+
+	body, err := s.verifyChecksum(ctx, bucket, aws.ToString(obj.Key), out, out.Body)
+	if err != nil {
+		return err
+	}
+
+	return localfs.WriteFile(root, name, body, aws.ToTime(obj.LastModified))
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("DownloadPrefix", func() {
+	var (
+		sut      *S3
+		api      *stubAPI
+		dir      string
+		objects  map[string][]byte
+		etags    map[string]string
+		modified time.Time
+		mu       sync.Mutex
+		gets     []string
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		dir = GinkgoT().TempDir()
+		modified = time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+		objects = map[string][]byte{
+			"site/index.html":          []byte("<html></html>"),
+			"site/assets/app.js":       []byte("console.log(1)"),
+			"site/assets/img/logo.svg": []byte("<svg/>"),
+			"site/empty/":              {},
+		}
+		etags = map[string]string{}
+		gets = nil
+
+		api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
+			Expect(bucket).To(Equal("bucket-a"))
+			var out []s3types.Object
+			for key, data := range objects {
+				if !strings.HasPrefix(key, prefix) {
+					continue
+				}
+				etag := etags[key]
+				if etag == "" {
+					etag = md5ETag(data)
+				}
+				out = append(out, s3types.Object{
+					Key:          aws.String(key),
+					Size:         aws.Int64(int64(len(data))),
+					ETag:         aws.String(etag),
+					LastModified: aws.Time(modified),
+				})
+			}
+			sort.Slice(out, func(i, j int) bool { return aws.ToString(out[i].Key) < aws.ToString(out[j].Key) })
+			return out, nil
+		}
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			key := aws.ToString(params.Key)
+			Expect(params.IfMatch).NotTo(BeNil())
+			Expect(params.ChecksumMode).To(Equal(s3types.ChecksumModeEnabled))
+
+			mu.Lock()
+			defer mu.Unlock()
+			gets = append(gets, key)
+			return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(objects[key]))}, nil
+		}
+	})
+
+	It("mirrors the prefix into the directory", func() {
+		summary, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site/", dir)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(keys(summary.Transferred)).To(Equal([]string{"site/assets/app.js", "site/assets/img/logo.svg", "site/index.html"}))
+		Expect(summary.Bytes).To(Equal(int64(14 + 6 + 13)))
+
+		p := filepath.Join(dir, "assets", "img", "logo.svg")
+		Expect(os.ReadFile(p)).To(Equal([]byte("<svg/>")))
+		info, err := os.Stat(p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.ModTime().Equal(modified)).To(BeTrue())
+		Expect(filepath.Join(dir, "empty")).NotTo(BeAnExistingFile())
+
+		entries, err := os.ReadDir(filepath.Join(dir, "assets"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2), "no temporary files are left behind")
+	})
+
+	It("treats a prefix without a trailing slash as part of the key", func() {
+		_, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site/assets", dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Join(dir, "app.js")).To(BeAnExistingFile())
+	})
+
+	It("does not download keys under sibling prefixes", func() {
+		objects["site2/index.html"] = []byte("sibling")
+		objects["sitemap.xml"] = []byte("<urlset/>")
+
+		summary, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site", dir)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(keys(summary.Transferred)).To(Equal([]string{"site/assets/app.js", "site/assets/img/logo.svg", "site/index.html"}))
+		Expect(os.ReadFile(filepath.Join(dir, "index.html"))).To(Equal([]byte("<html></html>")))
+		Expect(filepath.Join(dir, "2")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(dir, "map.xml")).NotTo(BeAnExistingFile())
+	})
+
+	DescribeTable("rejects keys escaping the directory",
+		func(key string) {
+			objects[key] = []byte("evil")
+
+			summary, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site/", dir)
+			Expect(err).To(MatchError(ContainSubstring("key path")))
+			Expect(keys(summary.Failed)).To(Equal([]string{key}))
+			Expect(summary.Transferred).To(HaveLen(3))
+			Expect(gets).NotTo(ContainElement(key))
+		},
+		Entry("parent directory", "site/../evil"),
+		Entry("parent directory inside the tree", "site/assets/../../evil"),
+		Entry("backslash separators", "site/..\\evil"),
+	)
+
+	It("does not write through links leading outside the directory", func() {
+		outside := GinkgoT().TempDir()
+		Expect(os.Symlink(outside, filepath.Join(dir, "assets"))).To(Succeed())
+
+		summary, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site/", dir)
+		Expect(err).To(HaveOccurred())
+		Expect(keys(summary.Failed)).To(Equal([]string{"site/assets/app.js", "site/assets/img/logo.svg"}))
+		Expect(keys(summary.Transferred)).To(Equal([]string{"site/index.html"}))
+		Expect(os.ReadDir(outside)).To(BeEmpty())
+	})
+
+	It("skips files whose size and ETag already match", func() {
+		writeTree(dir, map[string]string{
+			"index.html":    "<html></html>",
+			"assets/app.js": "console.log(2)",
+		})
+
+		summary, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site/", dir)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(keys(summary.Skipped)).To(Equal([]string{"site/index.html"}))
+		Expect(keys(summary.Transferred)).To(Equal([]string{"site/assets/app.js", "site/assets/img/logo.svg"}))
+		Expect(gets).NotTo(ContainElement("site/index.html"))
+		Expect(os.ReadFile(filepath.Join(dir, "assets", "app.js"))).To(Equal([]byte("console.log(1)")))
+	})
+
+	It("matches multipart ETags using the part size", func() {
+		data := bytes.Repeat([]byte("a"), 12*mib)
+		objects = map[string][]byte{"big.bin": data}
+		etags["big.bin"] = partsETag(data, 5*mib)
+		Expect(os.WriteFile(filepath.Join(dir, "big.bin"), data, 0o644)).To(Succeed())
+
+		summary, err := sut.DownloadPrefix(context.Background(), "bucket-a", "", dir, func(o *types.DownloadPrefixOptions) {
+			o.PartSize = 5 * mib
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys(summary.Skipped)).To(Equal([]string{"big.bin"}))
+		Expect(gets).To(BeEmpty())
+	})
+
+	It("continues past failed objects", func() {
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			key := aws.ToString(params.Key)
+			if key == "site/index.html" {
+				return nil, apiErr{code: "PreconditionFailed"}
+			}
+			return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(objects[key]))}, nil
+		}
+
+		summary, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site/", dir, func(o *types.DownloadPrefixOptions) {
+			o.Concurrency = 1
+		})
+		Expect(err).To(MatchError(ErrPreconditionFailed))
+		Expect(keys(summary.Failed)).To(Equal([]string{"site/index.html"}))
+		Expect(summary.Transferred).To(HaveLen(2))
+		Expect(filepath.Join(dir, "index.html")).NotTo(BeAnExistingFile())
+	})
+
+	It("removes partial downloads", func() {
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			return &s3.GetObjectOutput{Body: io.NopCloser(io.MultiReader(strings.NewReader("part"), errReader{io.ErrUnexpectedEOF}))}, nil
+		}
+
+		_, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site/assets/img/", dir)
+		Expect(err).To(MatchError(io.ErrUnexpectedEOF))
+		Expect(os.ReadDir(dir)).To(BeEmpty())
+	})
+
+	It("returns listing errors", func() {
+		api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
+			return nil, apiErr{code: "NoSuchBucket"}
+		}
+
+		_, err := sut.DownloadPrefix(context.Background(), "bucket-a", "site/", dir)
+		Expect(err).To(MatchError(ErrBucketNotFound))
+	})
+})
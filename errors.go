@@ -0,0 +1,212 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/smithy-go"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// Sentinel errors matched by errors.Is against any error returned by S3 methods.
+var (
+	// ErrBucketNotFound indicates the bucket does not exist.
+	ErrBucketNotFound = errors.New("bucket not found")
+	// ErrObjectNotFound indicates the object key does not exist.
+	ErrObjectNotFound = errors.New("object not found")
+	// ErrAccessDenied indicates the credentials are not permitted to perform the request.
+	ErrAccessDenied = errors.New("access denied")
+	// ErrBucketAlreadyExists indicates the bucket name is already taken, including by the caller.
+	ErrBucketAlreadyExists = errors.New("bucket already exists")
+	// ErrBucketNotEmpty indicates the bucket still contains objects.
+	ErrBucketNotEmpty = errors.New("bucket not empty")
+	// ErrPreconditionFailed indicates a conditional request did not match the stored object.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrNotModified indicates a conditional fetch found the object unchanged.
+	ErrNotModified = errors.New("not modified")
+	// ErrInvalidRange indicates the requested byte range cannot be satisfied.
+	ErrInvalidRange = errors.New("invalid range")
+	// ErrThrottled indicates the service asked the client to slow down.
+	ErrThrottled = errors.New("request throttled")
+	// ErrUploadNotFound indicates the multipart upload does not exist, or has been completed or aborted.
+	ErrUploadNotFound = errors.New("multipart upload not found")
+	// ErrChecksumMismatch indicates data does not match the checksum or ETag expected for it.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// errorCodes maps S3 API error codes onto sentinel errors.
+var errorCodes = map[string]error{
+	"NoSuchBucket":               ErrBucketNotFound,
+	"NoSuchKey":                  ErrObjectNotFound,
+	"AccessDenied":               ErrAccessDenied,
+	"Forbidden":                  ErrAccessDenied,
+	"BucketAlreadyExists":        ErrBucketAlreadyExists,
+	"BucketAlreadyOwnedByYou":    ErrBucketAlreadyExists,
+	"BucketNotEmpty":             ErrBucketNotEmpty,
+	"PreconditionFailed":         ErrPreconditionFailed,
+	"ConditionalRequestConflict": ErrPreconditionFailed,
+	"NotModified":                ErrNotModified,
+	"InvalidRange":               ErrInvalidRange,
+	"SlowDown":                   ErrThrottled,
+	"Throttling":                 ErrThrottled,
+	"ThrottlingException":        ErrThrottled,
+	"TooManyRequests":            ErrThrottled,
+	"RequestLimitExceeded":       ErrThrottled,
+	"NoSuchUpload":               ErrUploadNotFound,
+	"BadDigest":                  ErrChecksumMismatch,
+	"InvalidDigest":              ErrChecksumMismatch,
+}
+
+// ChecksumMismatchError reports data whose checksum differs from the one stored with the object.
+// It matches ErrChecksumMismatch.
+type ChecksumMismatchError struct {
+	// Algorithm is the checksum algorithm used.
+	Algorithm types.ChecksumAlgorithm
+	// Expected is the base64 checksum stored with the object. Composite multipart checksums end in
+	// "-N" where N is the number of parts.
+	Expected string
+	// Actual is the base64 checksum of the data received.
+	Actual string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch: expected %s, got %s", e.Algorithm, e.Expected, e.Actual)
+}
+
+// Is reports whether target is ErrChecksumMismatch.
+func (e *ChecksumMismatchError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+// Error describes a failed S3 operation.
+//
+// Errors returned by S3 methods can be inspected with errors.As to obtain an *Error, and
+// compared with errors.Is against the sentinel errors declared in this package.
+type Error struct {
+	// Op is the name of the S3 method that failed, such as "PutObject".
+	Op string
+	// Bucket is the bucket the operation targeted, if any.
+	Bucket string
+	// Key is the object key the operation targeted, if any.
+	Key string
+	// Code is the API error code returned by the service, if any.
+	Code string
+	// StatusCode is the HTTP status code of the failed response, or 0 if no response was received.
+	StatusCode int
+	// RequestID is the request ID reported by the service, if any.
+	RequestID string
+	// Attempts is the number of attempts made before the operation failed.
+	Attempts int
+	// Err is the underlying error.
+	Err error
+
+	kind error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Key != "":
+		return fmt.Sprintf("%s %s/%s: %v", e.Op, e.Bucket, e.Key, e.Err)
+	case e.Bucket != "":
+		return fmt.Sprintf("%s %s: %v", e.Op, e.Bucket, e.Err)
+	default:
+		return fmt.Sprintf("%s: %v", e.Op, e.Err)
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches one of the package sentinel errors. Errors built outside
+// this package, such as by test fakes, are matched on their Code and StatusCode.
+func (e *Error) Is(target error) bool {
+	kind := e.kind
+	if kind == nil {
+		kind = classifyError(e)
+	}
+	return kind != nil && kind == target
+}
+
+// newError wraps err with the operation details. It returns nil if err is nil.
+func newError(op, bucket, key string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	e := &Error{
+		Op:       op,
+		Bucket:   bucket,
+		Key:      key,
+		Attempts: Attempts(err),
+		Err:      err,
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		e.Code = apiErr.ErrorCode()
+	}
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		e.StatusCode = statusErr.HTTPStatusCode()
+	}
+	var requestErr interface{ ServiceRequestID() string }
+	if errors.As(err, &requestErr) {
+		e.RequestID = requestErr.ServiceRequestID()
+	}
+
+	e.kind = classifyError(e)
+	return e
+}
+
+// classifyError selects the sentinel error matching the error code, falling back to the status code.
+func classifyError(e *Error) error {
+	if kind, ok := errorCodes[e.Code]; ok {
+		return kind
+	}
+
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		if e.Key != "" {
+			return ErrObjectNotFound
+		}
+		return ErrBucketNotFound
+	case http.StatusForbidden:
+		return ErrAccessDenied
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case http.StatusNotModified:
+		return ErrNotModified
+	case http.StatusRequestedRangeNotSatisfiable:
+		return ErrInvalidRange
+	case http.StatusTooManyRequests:
+		return ErrThrottled
+	}
+
+	if e.Code == "NotFound" {
+		if e.Key != "" {
+			return ErrObjectNotFound
+		}
+		return ErrBucketNotFound
+	}
+
+	return nil
+}
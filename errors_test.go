@@ -0,0 +1,186 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("Errors", func() {
+
+	Describe("newError", func() {
+		It("returns nil for a nil error", func() {
+			Expect(newError("PutObject", "bucket-a", "key-a", nil)).To(BeNil())
+		})
+
+		DescribeTable("maps API error codes onto sentinel errors",
+			func(code, key string, expected error) {
+				err := newError("Op", "bucket-a", key, apiErr{code: code})
+				Expect(errors.Is(err, expected)).To(BeTrue())
+			},
+			Entry("NoSuchBucket", "NoSuchBucket", "", ErrBucketNotFound),
+			Entry("NoSuchKey", "NoSuchKey", "key-a", ErrObjectNotFound),
+			Entry("NotFound on a bucket", "NotFound", "", ErrBucketNotFound),
+			Entry("NotFound on an object", "NotFound", "key-a", ErrObjectNotFound),
+			Entry("AccessDenied", "AccessDenied", "", ErrAccessDenied),
+			Entry("BucketAlreadyExists", "BucketAlreadyExists", "", ErrBucketAlreadyExists),
+			Entry("BucketAlreadyOwnedByYou", "BucketAlreadyOwnedByYou", "", ErrBucketAlreadyExists),
+			Entry("BucketNotEmpty", "BucketNotEmpty", "", ErrBucketNotEmpty),
+			Entry("PreconditionFailed", "PreconditionFailed", "key-a", ErrPreconditionFailed),
+			Entry("InvalidRange", "InvalidRange", "key-a", ErrInvalidRange),
+			Entry("SlowDown", "SlowDown", "key-a", ErrThrottled),
+			Entry("NoSuchUpload", "NoSuchUpload", "key-a", ErrUploadNotFound),
+			Entry("BadDigest", "BadDigest", "key-a", ErrChecksumMismatch),
+		)
+
+		DescribeTable("falls back to the HTTP status code",
+			func(status int, key string, expected error) {
+				err := newError("Op", "bucket-a", key, statusErr{status: status, requestID: "req-1"})
+				Expect(errors.Is(err, expected)).To(BeTrue())
+
+				var e *Error
+				Expect(errors.As(err, &e)).To(BeTrue())
+				Expect(e.StatusCode).To(Equal(status))
+				Expect(e.RequestID).To(Equal("req-1"))
+			},
+			Entry("404 on a bucket", http.StatusNotFound, "", ErrBucketNotFound),
+			Entry("404 on an object", http.StatusNotFound, "key-a", ErrObjectNotFound),
+			Entry("403", http.StatusForbidden, "key-a", ErrAccessDenied),
+			Entry("412", http.StatusPreconditionFailed, "key-a", ErrPreconditionFailed),
+			Entry("416", http.StatusRequestedRangeNotSatisfiable, "key-a", ErrInvalidRange),
+			Entry("429", http.StatusTooManyRequests, "key-a", ErrThrottled),
+		)
+
+		It("matches no sentinel for unknown errors", func() {
+			err := newError("Op", "bucket-a", "", errors.New("boom"))
+			Expect(errors.Is(err, ErrBucketNotFound)).To(BeFalse())
+			Expect(errors.Is(err, ErrAccessDenied)).To(BeFalse())
+		})
+
+		It("carries the operation details and unwraps", func() {
+			inner := &RetryError{Attempts: 3, Err: apiErr{code: "SlowDown"}}
+			err := newError("PutObject", "bucket-a", "key-a", inner)
+
+			var e *Error
+			Expect(errors.As(err, &e)).To(BeTrue())
+			Expect(e.Op).To(Equal("PutObject"))
+			Expect(e.Bucket).To(Equal("bucket-a"))
+			Expect(e.Key).To(Equal("key-a"))
+			Expect(e.Code).To(Equal("SlowDown"))
+			Expect(e.Attempts).To(Equal(3))
+			Expect(errors.Is(err, inner)).To(BeTrue())
+		})
+
+		It("classifies errors built outside the package", func() {
+			err := &Error{Op: "FetchObject", Bucket: "bucket-a", Key: "key-a", Code: "NoSuchKey", StatusCode: http.StatusNotFound, Err: errors.New("missing")}
+			Expect(errors.Is(err, ErrObjectNotFound)).To(BeTrue())
+			Expect(errors.Is(&Error{Op: "PutObject", StatusCode: http.StatusTooManyRequests}, ErrThrottled)).To(BeTrue())
+			Expect(errors.Is(&Error{Op: "PutObject"}, ErrThrottled)).To(BeFalse())
+		})
+
+		It("formats the message with the target", func() {
+			Expect(newError("PutObject", "b", "k", errors.New("boom")).Error()).To(Equal("PutObject b/k: boom"))
+			Expect(newError("CreateBucket", "b", "", errors.New("boom")).Error()).To(Equal("CreateBucket b: boom"))
+			Expect(newError("ListBuckets", "", "", errors.New("boom")).Error()).To(Equal("ListBuckets: boom"))
+		})
+	})
+
+	Describe("S3 methods", func() {
+		It("wraps errors from every wrapper method", func() {
+			sut, api := newStubbed()
+			api.deleteObject = func(ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+				return nil, apiErr{code: "AccessDenied"}
+			}
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				return nil, apiErr{code: "NoSuchKey"}
+			}
+			api.createBucket = func(ctx context.Context, params *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
+				return nil, apiErr{code: "BucketAlreadyOwnedByYou"}
+			}
+
+			Expect(sut.DeleteObject(context.Background(), "bucket-a", "key-a")).To(MatchError(ErrAccessDenied))
+			_, err := sut.FetchObject(context.Background(), "key-a", "bucket-a")
+			Expect(err).To(MatchError(ErrObjectNotFound))
+			Expect(sut.CreateBucket(context.Background(), "bucket-a")).To(MatchError(ErrBucketAlreadyExists))
+		})
+
+		It("reports the status code and request ID from a real response", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/xml")
+				w.Header().Set("X-Amz-Request-Id", "req-123")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			}))
+			defer server.Close()
+
+			sut, err := New(context.Background(), server.URL, "ak", "sk", "", func(o *Options) {
+				o.Retry.MaxAttempts = 1
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sut.FetchObject(context.Background(), "key-a", "bucket-a")
+			Expect(err).To(MatchError(ErrObjectNotFound))
+
+			var e *Error
+			Expect(errors.As(err, &e)).To(BeTrue())
+			Expect(e.Op).To(Equal("FetchObject"))
+			Expect(e.Code).To(Equal("NoSuchKey"))
+			Expect(e.StatusCode).To(Equal(http.StatusNotFound))
+			Expect(e.RequestID).To(Equal("req-123"))
+			Expect(e.Attempts).To(Equal(1))
+		})
+
+		DescribeTable("maps conditional responses from a real endpoint",
+			func(status int, expected error) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(status)
+				}))
+				defer server.Close()
+
+				sut, err := New(context.Background(), server.URL, "ak", "sk", "", func(o *Options) {
+					o.Retry.MaxAttempts = 1
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = sut.FetchObject(context.Background(), "key-a", "bucket-a", func(o *types.FetchObjectOptions) {
+					o.IfNoneMatch = `"etag-a"`
+				})
+				Expect(err).To(MatchError(expected))
+			},
+			Entry("304 Not Modified", http.StatusNotModified, ErrNotModified),
+			Entry("412 Precondition Failed", http.StatusPreconditionFailed, ErrPreconditionFailed),
+		)
+	})
+})
+
+type statusErr struct {
+	status    int
+	requestID string
+}
+
+func (e statusErr) Error() string            { return http.StatusText(e.status) }
+func (e statusErr) HTTPStatusCode() int      { return e.status }
+func (e statusErr) ServiceRequestID() string { return e.requestID }
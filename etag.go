@@ -0,0 +1,176 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"crypto/md5" //nolint:gosec // S3 ETags are MD5 digests
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ETagMismatchError reports an uploaded object whose ETag differs from the MD5 digest computed
+// locally. It matches ErrChecksumMismatch.
+type ETagMismatchError struct {
+	// Expected is the ETag computed from the data sent.
+	Expected string
+	// Actual is the ETag returned by the service.
+	Actual string
+}
+
+func (e *ETagMismatchError) Error() string {
+	return fmt.Sprintf("ETag mismatch: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// Is reports whether target is ErrChecksumMismatch.
+func (e *ETagMismatchError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+// etagHasher computes the ETag S3 assigns to data written to it, whether the data is stored in a
+// single request or in parts of partSize bytes.
+type etagHasher struct {
+	partSize int64
+	whole    hash.Hash
+	part     hash.Hash
+	inPart   int64
+	digests  []byte
+	parts    int
+}
+
+func newETagHasher(partSize int64) *etagHasher {
+	return &etagHasher{
+		partSize: partSize,
+		whole:    md5.New(), //nolint:gosec
+		part:     md5.New(), //nolint:gosec
+	}
+}
+
+func (h *etagHasher) Write(p []byte) (int, error) {
+	h.whole.Write(p)
+	for b := p; len(b) > 0; {
+		take := min(h.partSize-h.inPart, int64(len(b)))
+		h.part.Write(b[:take])
+		h.inPart += take
+		b = b[take:]
+		if h.inPart == h.partSize {
+			h.endPart()
+		}
+	}
+	return len(p), nil
+}
+
+func (h *etagHasher) endPart() {
+	h.digests = h.part.Sum(h.digests)
+	h.part.Reset()
+	h.inPart = 0
+	h.parts++
+}
+
+// verify compares the ETag returned by the service with the one computed from the data written. An
+// empty ETag cannot be verified and is accepted.
+func (h *etagHasher) verify(etag string) error {
+	if etag == "" {
+		return nil
+	}
+
+	expected := hex.EncodeToString(h.whole.Sum(nil))
+	if isMultipartETag(etag) {
+		if h.inPart > 0 {
+			h.endPart()
+		}
+		sum := md5.Sum(h.digests) //nolint:gosec
+		expected = hex.EncodeToString(sum[:]) + "-" + strconv.Itoa(h.parts)
+	}
+	return compareETag(expected, etag)
+}
+
+// fileMatchesETag reports whether the file at path has the ETag S3 assigns to it when uploaded in
+// parts of partSize bytes. An empty ETag never matches.
+func fileMatchesETag(path, etag string, partSize int64) (bool, error) {
+	if etag == "" {
+		return false, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close() //nolint:all
+	return readerMatchesETag(f, etag, partSize)
+}
+
+// readerMatchesETag reports whether the content of r has the ETag S3 assigns to it when uploaded in
+// parts of partSize bytes. An empty ETag never matches.
+func readerMatchesETag(r io.Reader, etag string, partSize int64) (bool, error) {
+	if etag == "" {
+		return false, nil
+	}
+
+	h := newETagHasher(partSize)
+	if _, err := io.Copy(h, r); err != nil {
+		return false, err
+	}
+	return h.verify(etag) == nil, nil
+}
+
+// multipartETag returns the ETag of a multipart object from the ETags of its parts in order.
+func multipartETag(partETags []string) (string, error) {
+	digests := make([]byte, 0, len(partETags)*md5.Size)
+	for _, etag := range partETags {
+		digest, err := hex.DecodeString(strings.Trim(etag, `"`))
+		if err != nil {
+			return "", fmt.Errorf("part ETag %s is not an MD5 digest", etag)
+		}
+		digests = append(digests, digest...)
+	}
+	sum := md5.Sum(digests) //nolint:gosec
+	return hex.EncodeToString(sum[:]) + "-" + strconv.Itoa(len(partETags)), nil
+}
+
+// compareETag returns an ETagMismatchError if etag, which may be quoted, differs from expected.
+func compareETag(expected, etag string) error {
+	actual := strings.Trim(etag, `"`)
+	if !strings.EqualFold(expected, actual) {
+		return &ETagMismatchError{Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// isMultipartETag reports whether etag belongs to an object uploaded in parts.
+func isMultipartETag(etag string) bool {
+	return strings.Contains(etag, "-")
+}
+
+// contentMD5 returns the base64 MD5 digest used for the Content-MD5 header and its hex form used for
+// ETags, leaving body rewound.
+func contentMD5(body io.ReadSeeker) (string, string, error) {
+	h := md5.New() //nolint:gosec
+	if _, err := io.Copy(h, body); err != nil {
+		return "", "", err
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return "", "", err
+	}
+	sum := h.Sum(nil)
+	return base64.StdEncoding.EncodeToString(sum), hex.EncodeToString(sum), nil
+}
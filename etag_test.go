@@ -0,0 +1,176 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// readingTransferManager consumes the upload body and returns a fixed ETag.
+type readingTransferManager struct {
+	etag  string
+	read  []byte
+	input *transfermanager.UploadObjectInput
+}
+
+func (r *readingTransferManager) UploadObject(ctx context.Context, params *transfermanager.UploadObjectInput, optFns ...func(*transfermanager.Options)) (*transfermanager.UploadObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.read = data
+	r.input = params
+	return &transfermanager.UploadObjectOutput{ETag: aws.String(r.etag)}, nil
+}
+
+// partsETag returns the multipart ETag of data split into parts of partSize bytes.
+func partsETag(data []byte, partSize int) string {
+	var etags []string
+	for offset := 0; offset < len(data); offset += partSize {
+		etags = append(etags, md5ETag(data[offset:min(offset+partSize, len(data))]))
+	}
+	etag, err := multipartETag(etags)
+	Expect(err).NotTo(HaveOccurred())
+	return etag
+}
+
+var _ = Describe("ETag verification", func() {
+	var (
+		sut *S3
+		api *stubAPI
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+	})
+
+	Describe("etagHasher", func() {
+		data := []byte("hello multipart world")
+
+		It("computes single-request ETags", func() {
+			h := newETagHasher(5)
+			_, _ = h.Write(data)
+			Expect(h.verify(md5ETag(data))).To(Succeed())
+		})
+
+		It("computes md5-of-md5s ETags across writes of any size", func() {
+			h := newETagHasher(5)
+			_, _ = h.Write(data[:3])
+			_, _ = h.Write(data[3:17])
+			_, _ = h.Write(data[17:])
+
+			Expect(partsETag(data, 5)).To(HaveSuffix("-5"))
+			Expect(h.verify(partsETag(data, 5))).To(Succeed())
+		})
+
+		It("reports mismatches", func() {
+			h := newETagHasher(5)
+			_, _ = h.Write(data)
+
+			err := h.verify(`"0123456789abcdef0123456789abcdef"`)
+			Expect(err).To(MatchError(ErrChecksumMismatch))
+			var mismatch *ETagMismatchError
+			Expect(errors.As(err, &mismatch)).To(BeTrue())
+			Expect(mismatch.Actual).To(Equal("0123456789abcdef0123456789abcdef"))
+		})
+
+		It("accepts an empty ETag", func() {
+			Expect(newETagHasher(5).verify("")).To(Succeed())
+		})
+	})
+
+	Describe("PutObject", func() {
+		contentMD5 := func(o *types.PutObjectOptions) {
+			o.ContentMD5 = true
+		}
+
+		It("sends Content-MD5 with single-part uploads and verifies the ETag", func() {
+			data := []byte("hello world")
+			var params *s3.PutObjectInput
+			api.putObject = func(ctx context.Context, p *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+				params = p
+				return &s3.PutObjectOutput{ETag: aws.String(md5ETag(data))}, nil
+			}
+			// Single-part uploads with Content-MD5 must not use the transfer manager.
+			unused := &fakeTransferManager{}
+			sut.transfer = unused
+
+			Expect(sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(data), contentMD5)).To(Succeed())
+			sum := md5.Sum(data)
+			Expect(aws.ToString(params.ContentMD5)).To(Equal(base64.StdEncoding.EncodeToString(sum[:])))
+			Expect(aws.ToInt64(params.ContentLength)).To(Equal(int64(len(data))))
+			Expect(aws.ToString(params.ContentType)).To(Equal("text/plain; charset=utf-8"))
+			Expect(unused.uploadInput).To(BeNil())
+		})
+
+		It("flags a single-part ETag that does not match", func() {
+			api.putObject = func(ctx context.Context, p *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+				return &s3.PutObjectOutput{ETag: aws.String(md5ETag([]byte("other")))}, nil
+			}
+
+			err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader([]byte("hello")), contentMD5)
+			Expect(err).To(MatchError(ErrChecksumMismatch))
+		})
+
+		It("verifies the ETag of multipart uploads made by the transfer manager", func() {
+			payload := bytes.Repeat([]byte("e"), 12*mib)
+			fake := &readingTransferManager{etag: partsETag(payload, 5*mib)}
+			sut.transfer = fake
+			multipart := func(o *types.PutObjectOptions) {
+				o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}
+			}
+
+			Expect(sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(payload), contentMD5, multipart)).To(Succeed())
+			Expect(fake.read).To(Equal(payload))
+
+			fake.etag = partsETag(payload, 6*mib)
+			err := sut.PutObjectStream(context.Background(), "bucket-a", "key-a", bytes.NewReader(payload), contentMD5, multipart)
+			Expect(err).To(MatchError(ErrChecksumMismatch))
+		})
+
+		It("sends Content-MD5 for resumable parts and verifies the completed ETag", func() {
+			fake := &fakeMultipart{}
+			fake.install(api)
+			payload := bytes.Repeat([]byte("f"), 12*mib)
+			resumable := func(o *types.PutObjectOptions) {
+				o.Resumable = true
+				o.StateStore = &memStateStore{}
+				o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}
+			}
+
+			Expect(sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(payload), contentMD5, resumable)).To(Succeed())
+			Expect(fake.contentMD5s).To(Equal(3))
+
+			fake.completeETag = `"0123456789abcdef0123456789abcdef-3"`
+			err := sut.PutObject(context.Background(), "bucket-a", "key-b", bytes.NewReader(payload), contentMD5, resumable)
+			Expect(err).To(MatchError(ErrChecksumMismatch))
+		})
+	})
+})
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// Fault describes a failure or delay injected into the requests made by a client, for testing retry
+// and timeout handling against a real or test server.
+//
+// Faults apply to each attempt, so an injected error is seen by the SDK retry policy exactly like a
+// response from the service. Every matching fault applies in order: latency accumulates and the first
+// fault that fails the attempt ends it.
+type Fault struct {
+	// Operations limits the fault to the named S3 API operations, such as "PutObject" or "UploadPart".
+	// Empty matches every operation.
+	Operations []string
+	// Keys limits the fault to requests for the given object keys. Empty matches every request.
+	Keys []string
+	// Times is the number of matching attempts affected, after which the fault no longer applies.
+	// Zero affects every matching attempt.
+	Times int
+
+	// Latency delays each affected attempt before it is sent. The delay ends early if the context
+	// is done.
+	Latency time.Duration
+	// StatusCode replaces the response with an S3 error response with this HTTP status, without
+	// sending the request.
+	StatusCode int
+	// ErrorCode is the S3 error code of the injected response, such as "SlowDown". Empty uses
+	// "InternalError" for 500, "SlowDown" for 503 and the status text otherwise.
+	ErrorCode string
+	// ConnectionReset fails the attempt with a connection reset error without sending the request.
+	ConnectionReset bool
+	// TruncateBody cuts the response body short, failing reads with io.ErrUnexpectedEOF once
+	// TruncateAfter bytes have been returned.
+	TruncateBody  bool
+	TruncateAfter int64
+}
+
+// Common faults. Limit them to operations or keys by setting the fields of a copy.
+var (
+	// FaultInternalError fails requests with a 500 InternalError response.
+	FaultInternalError = Fault{StatusCode: http.StatusInternalServerError}
+	// FaultSlowDown fails requests with a 503 SlowDown throttling response.
+	FaultSlowDown = Fault{StatusCode: http.StatusServiceUnavailable}
+	// FaultConnectionReset fails requests as if the connection was reset.
+	FaultConnectionReset = Fault{ConnectionReset: true}
+)
+
+// errorCode returns the S3 error code of the injected response.
+func (f *Fault) errorCode() string {
+	switch {
+	case f.ErrorCode != "":
+		return f.ErrorCode
+	case f.StatusCode == http.StatusInternalServerError:
+		return "InternalError"
+	case f.StatusCode == http.StatusServiceUnavailable:
+		return "SlowDown"
+	}
+	return strings.ReplaceAll(http.StatusText(f.StatusCode), " ", "")
+}
+
+// activeFault is a Fault with the number of attempts it has affected.
+type activeFault struct {
+	Fault
+	used atomic.Int64
+}
+
+// take reports whether the fault applies to an attempt of op on key, counting the attempt if so.
+func (f *activeFault) take(op, key string) bool {
+	if len(f.Operations) > 0 && !slices.Contains(f.Operations, op) {
+		return false
+	}
+	if len(f.Keys) > 0 && !slices.Contains(f.Keys, key) {
+		return false
+	}
+	return f.Times <= 0 || f.used.Add(1) <= int64(f.Times)
+}
+
+// faultKey is the stack value holding the object key of the operation.
+type faultKey struct{}
+
+// faultKeyRecorder stores the Key field of the operation input, when it has one, for faultInjector.
+type faultKeyRecorder struct{}
+
+func (faultKeyRecorder) ID() string { return "SimpleS3FaultKey" }
+
+func (faultKeyRecorder) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	middleware.InitializeOutput, middleware.Metadata, error,
+) {
+	// Inputs are generated structs, so the key is found by field name rather than per operation.
+	if v := reflect.ValueOf(in.Parameters); v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct {
+		if field := v.Elem().FieldByName("Key"); field.IsValid() && field.CanInterface() {
+			if key, ok := field.Interface().(*string); ok && key != nil {
+				ctx = middleware.WithStackValue(ctx, faultKey{}, *key)
+			}
+		}
+	}
+	return next.HandleInitialize(ctx, in)
+}
+
+// faultInjector applies faults to each attempt just before it is sent.
+type faultInjector struct {
+	faults []*activeFault
+}
+
+func (*faultInjector) ID() string { return "SimpleS3FaultInjector" }
+
+func (f *faultInjector) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	middleware.DeserializeOutput, middleware.Metadata, error,
+) {
+	op := awsmiddleware.GetOperationName(ctx)
+	key, _ := middleware.GetStackValue(ctx, faultKey{}).(string)
+
+	truncate := int64(-1)
+	for _, fault := range f.faults {
+		if !fault.take(op, key) {
+			continue
+		}
+		if fault.Latency > 0 {
+			timer := time.NewTimer(fault.Latency)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return middleware.DeserializeOutput{}, middleware.Metadata{}, ctx.Err()
+			case <-timer.C:
+			}
+		}
+		switch {
+		case fault.ConnectionReset:
+			err := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+			return middleware.DeserializeOutput{}, middleware.Metadata{}, &smithyhttp.RequestSendError{Err: err}
+		case fault.StatusCode != 0:
+			return middleware.DeserializeOutput{RawResponse: faultResponse(in, fault.StatusCode, fault.errorCode())}, middleware.Metadata{}, nil
+		case fault.TruncateBody && truncate < 0:
+			truncate = fault.TruncateAfter
+		}
+	}
+
+	out, metadata, err := next.HandleDeserialize(ctx, in)
+	if resp, ok := out.RawResponse.(*smithyhttp.Response); ok && truncate >= 0 && resp.Body != nil {
+		resp.Body = &truncatedBody{ReadCloser: resp.Body, remaining: truncate}
+	}
+	return out, metadata, err
+}
+
+// faultResponse builds an S3 error response to the request in in.
+func faultResponse(in middleware.DeserializeInput, status int, code string) *smithyhttp.Response {
+	header := http.Header{}
+	header.Set("x-amz-request-id", "fault-injected")
+	var body []byte
+	if req, ok := in.Request.(*smithyhttp.Request); !ok || req.Method != http.MethodHead {
+		// Responses to HEAD requests have no body, so the status code alone identifies the error.
+		header.Set("Content-Type", "application/xml")
+		body = fmt.Appendf(nil, "<Error><Code>%s</Code><Message>injected fault</Message><RequestId>fault-injected</RequestId></Error>", code)
+	}
+	return &smithyhttp.Response{Response: &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Header:        header,
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+	}}
+}
+
+// truncatedBody returns the first remaining bytes of a response body and then io.ErrUnexpectedEOF.
+type truncatedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// addFaultInjection returns a stack option installing faults, or nil when there are none.
+func addFaultInjection(faults []Fault) func(*middleware.Stack) error {
+	if len(faults) == 0 {
+		return nil
+	}
+	injector := &faultInjector{faults: make([]*activeFault, 0, len(faults))}
+	for _, fault := range faults {
+		injector.faults = append(injector.faults, &activeFault{Fault: fault})
+	}
+	return func(stack *middleware.Stack) error {
+		if err := stack.Initialize.Add(faultKeyRecorder{}, middleware.Before); err != nil {
+			return err
+		}
+		return stack.Deserialize.Add(injector, middleware.After)
+	}
+}
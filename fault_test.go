@@ -0,0 +1,159 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/s3test"
+)
+
+var _ = Describe("Fault injection", func() {
+	var (
+		ctx    context.Context
+		server *s3test.Server
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = s3test.NewServer()
+		DeferCleanup(server.Close)
+	})
+
+	// newFaultyClient creates a client of the test server with fast retries and the given faults.
+	newFaultyClient := func(maxAttempts int, faults ...Fault) *S3 {
+		GinkgoHelper()
+		c, err := New(ctx, server.URL, "access-key", "secret-key", "", func(o *Options) {
+			o.Retry.MaxAttempts = maxAttempts
+			o.Retry.MaxBackoff = time.Millisecond
+			o.Faults = faults
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.CreateBucket(ctx, "bucket-a")).To(Succeed())
+		return c
+	}
+
+	put := func(c *S3, key string) error {
+		return c.PutObject(ctx, "bucket-a", key, bytes.NewReader([]byte("payload")))
+	}
+
+	It("retries injected server errors", func() {
+		fault := FaultInternalError
+		fault.Operations = []string{"PutObject"}
+		fault.Times = 2
+		c := newFaultyClient(3, fault)
+
+		Expect(put(c, "a.txt")).To(Succeed())
+		data, err := c.FetchObject(ctx, "a.txt", "bucket-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("payload"))
+	})
+
+	It("reports the injected error once attempts run out", func() {
+		fault := FaultInternalError
+		fault.Operations = []string{"PutObject"}
+		c := newFaultyClient(2, fault)
+
+		err := put(c, "a.txt")
+		var s3Err *Error
+		Expect(errors.As(err, &s3Err)).To(BeTrue())
+		Expect(s3Err.Code).To(Equal("InternalError"))
+		Expect(s3Err.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(s3Err.RequestID).To(Equal("fault-injected"))
+		Expect(s3Err.Attempts).To(Equal(2))
+	})
+
+	It("injects throttling", func() {
+		fault := FaultSlowDown
+		fault.Operations = []string{"GetObject"}
+		c := newFaultyClient(1, fault)
+		Expect(put(c, "a.txt")).To(Succeed())
+
+		_, err := c.FetchObject(ctx, "a.txt", "bucket-a")
+		Expect(err).To(MatchError(ErrThrottled))
+	})
+
+	It("injects errors into HEAD requests", func() {
+		fault := Fault{Operations: []string{"HeadObject"}, StatusCode: http.StatusForbidden}
+		c := newFaultyClient(1, fault)
+		Expect(put(c, "a.txt")).To(Succeed())
+
+		_, err := c.StatObject(ctx, "bucket-a", "a.txt")
+		Expect(err).To(MatchError(ErrAccessDenied))
+	})
+
+	It("resets connections", func() {
+		fault := FaultConnectionReset
+		fault.Operations = []string{"PutObject"}
+		fault.Times = 1
+		c := newFaultyClient(1, fault)
+
+		Expect(put(c, "a.txt")).To(MatchError(ContainSubstring("connection reset")))
+		Expect(put(c, "a.txt")).To(Succeed())
+	})
+
+	It("limits faults to the selected keys", func() {
+		fault := FaultInternalError
+		fault.Keys = []string{"broken.txt"}
+		c := newFaultyClient(1, fault)
+
+		Expect(put(c, "a.txt")).To(Succeed())
+		Expect(put(c, "broken.txt")).To(HaveOccurred())
+		_, err := c.ListObject(ctx, "bucket-a", "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("delays requests until the context is done", func() {
+		c := newFaultyClient(1, Fault{Operations: []string{"GetObject"}, Latency: time.Minute})
+		Expect(put(c, "a.txt")).To(Succeed())
+
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := c.FetchObject(timeout, "a.txt", "bucket-a")
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+	})
+
+	It("adds latency to successful requests", func() {
+		c := newFaultyClient(1, Fault{Operations: []string{"HeadObject"}, Latency: 20 * time.Millisecond})
+		Expect(put(c, "a.txt")).To(Succeed())
+
+		start := time.Now()
+		_, err := c.StatObject(ctx, "bucket-a", "a.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+	})
+
+	It("truncates response bodies", func() {
+		c := newFaultyClient(1, Fault{Operations: []string{"GetObject"}, TruncateBody: true, TruncateAfter: 3})
+		Expect(put(c, "a.txt")).To(Succeed())
+
+		var buf bytes.Buffer
+		_, err := c.DownloadObject(ctx, "bucket-a", "a.txt", &buf)
+		Expect(err).To(MatchError(io.ErrUnexpectedEOF))
+		Expect(buf.String()).To(Equal("pay"))
+	})
+})
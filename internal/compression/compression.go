@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compression encodes and decodes the gzip and zstd object bodies written with the
+// Compression upload option. It is shared by the S3 wrapper and the in-memory fake so both store
+// and read compressed objects the same way.
+package compression
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// OriginalSizeMetadata is the user metadata key recording the uncompressed size of a compressed object.
+const OriginalSizeMetadata = "original-size"
+
+// NewWriter returns a writer compressing to w with codec. Closing it flushes the encoder but does
+// not close w.
+func NewWriter(codec types.Compression, w io.Writer) (io.WriteCloser, error) {
+	switch codec {
+	case types.CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case types.CompressionZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", codec)
+	}
+}
+
+// NewReader returns a reader decompressing r with codec. Closing it releases the decoder but not r.
+func NewReader(codec types.Compression, r io.Reader) (io.ReadCloser, error) {
+	switch codec {
+	case types.CompressionGzip:
+		zr, err := gzip.NewReader(r)
+		if err == io.EOF {
+			// An empty object has no gzip header and decodes to nothing.
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+		return zr, err
+	case types.CompressionZstd:
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", codec)
+	}
+}
+
+// Decoding returns the codec named by a Content-Encoding, or "" if it is not one that is decoded.
+func Decoding(contentEncoding string) types.Compression {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "gzip", "x-gzip":
+		return types.CompressionGzip
+	case "zstd":
+		return types.CompressionZstd
+	default:
+		return ""
+	}
+}
+
+// OriginalSize returns the uncompressed size recorded in object metadata, or -1 if it is absent.
+func OriginalSize(metadata map[string]string) int64 {
+	size, err := strconv.ParseInt(metadata[OriginalSizeMetadata], 10, 64)
+	if err != nil || size < 0 {
+		return -1
+	}
+	return size
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package contenttype maps object keys to content types by their extension. It is shared by the
+// S3 wrapper and the in-memory fake so both store the same types.
+package contenttype
+
+import (
+	"mime"
+	"path"
+	"strings"
+)
+
+// defaultContentTypes takes precedence over mime.TypeByExtension, whose results depend on the host's
+// MIME database and on some systems map web assets to text/plain.
+var defaultContentTypes = map[string]string{
+	".css":   "text/css; charset=utf-8",
+	".csv":   "text/csv; charset=utf-8",
+	".gz":    "application/gzip",
+	".htm":   "text/html; charset=utf-8",
+	".html":  "text/html; charset=utf-8",
+	".ico":   "image/vnd.microsoft.icon",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".map":   "application/json",
+	".md":    "text/markdown; charset=utf-8",
+	".mjs":   "text/javascript; charset=utf-8",
+	".svg":   "image/svg+xml",
+	".tar":   "application/x-tar",
+	".txt":   "text/plain; charset=utf-8",
+	".wasm":  "application/wasm",
+	".webp":  "image/webp",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".xml":   "text/xml; charset=utf-8",
+	".yaml":  "application/yaml",
+	".yml":   "application/yaml",
+	".zip":   "application/zip",
+	".zst":   "application/zstd",
+}
+
+// ByExtension returns the content type registered for the extension of key in overrides, the
+// default table or the system MIME database, in that order, or "" if none has it.
+func ByExtension(key string, overrides map[string]string) string {
+	ext := strings.ToLower(path.Ext(key))
+	if ext == "" {
+		return ""
+	}
+	if ct, ok := overrides[ext]; ok {
+		return ct
+	}
+	if ct, ok := defaultContentTypes[ext]; ok {
+		return ct
+	}
+	return mime.TypeByExtension(ext)
+}
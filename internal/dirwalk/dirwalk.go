@@ -0,0 +1,252 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dirwalk collects the files of a local directory tree for transfer and matches their
+// relative paths against glob filters. It is shared by the S3 wrapper and the in-memory fake so both
+// select the same files.
+package dirwalk
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// Walker collects the files to upload from a directory tree.
+type Walker struct {
+	options types.UploadDirectoryOptions
+	prefix  string
+	// ancestors holds the resolved paths of the directories on the current walk path, so a link to
+	// one of them is reported as a loop instead of being followed.
+	ancestors map[string]bool
+
+	// Files are the regular files selected for transfer.
+	Files []types.FileTransfer
+	// Skipped are the files excluded by the filters and the symbolic links not followed, including
+	// links that would loop back to a directory being walked.
+	Skipped []types.FileTransfer
+	// Failed are the links that could not be followed and the files and directories that could
+	// not be read. The walk continues past them.
+	Failed []types.FileTransfer
+}
+
+// Walk collects the files below dir, keyed by their slash-separated path relative to dir under
+// prefix. Only the Include, Exclude and FollowSymlinks options are used. An error is returned only
+// when dir itself cannot be read; problems below it are recorded in Failed.
+func Walk(dir, prefix string, o types.UploadDirectoryOptions) (*Walker, error) {
+	w := &Walker{options: o, prefix: prefix, ancestors: map[string]bool{}}
+	if err := w.walk(dir, ""); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// errSymlinkLoop reports a linked directory that is already being walked.
+var errSymlinkLoop = errors.New("symbolic link loops back to a parent directory")
+
+// walk adds the entries of dir, whose slash-separated path relative to the root is rel. A directory
+// reached through several links is walked once for each of them.
+func (w *Walker) walk(dir, rel string) error {
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if w.ancestors[resolved] {
+		return errSymlinkLoop
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	w.ancestors[resolved] = true
+	defer delete(w.ancestors, resolved)
+	for _, e := range entries {
+		p := filepath.Join(dir, e.Name())
+		r := path.Join(rel, e.Name())
+		if MatchAny(w.options.Exclude, r) {
+			if !e.IsDir() {
+				w.Skipped = append(w.Skipped, w.transfer(p, r, 0))
+			}
+			continue
+		}
+
+		mode := e.Type()
+		if mode&fs.ModeSymlink != 0 {
+			if !w.options.FollowSymlinks {
+				w.Skipped = append(w.Skipped, w.transfer(p, r, 0))
+				continue
+			}
+			info, err := os.Stat(p)
+			if err != nil {
+				w.fail(p, r, err)
+				continue
+			}
+			mode = info.Mode().Type()
+		}
+
+		switch {
+		case mode.IsDir():
+			err := w.walk(p, r)
+			switch {
+			case errors.Is(err, errSymlinkLoop):
+				f := w.transfer(p, r, 0)
+				f.Err = err
+				w.Skipped = append(w.Skipped, f)
+			case err != nil:
+				w.fail(p, r, err)
+			}
+		case mode.IsRegular():
+			if err := w.add(p, r); err != nil {
+				w.fail(p, r, err)
+			}
+		}
+	}
+	return nil
+}
+
+// fail records the entry at p as one that could not be read.
+func (w *Walker) fail(p, rel string, err error) {
+	f := w.transfer(p, rel, 0)
+	f.Err = err
+	w.Failed = append(w.Failed, f)
+}
+
+// add records the regular file at p if it passes the include filter.
+func (w *Walker) add(p, rel string) error {
+	info, err := os.Stat(p)
+	if err != nil {
+		return err
+	}
+	f := w.transfer(p, rel, info.Size())
+	if len(w.options.Include) > 0 && !MatchAny(w.options.Include, rel) {
+		w.Skipped = append(w.Skipped, f)
+		return nil
+	}
+	w.Files = append(w.Files, f)
+	return nil
+}
+
+func (w *Walker) transfer(p, rel string, size int64) types.FileTransfer {
+	key := rel
+	if w.prefix != "" {
+		key = strings.TrimSuffix(w.prefix, "/") + "/" + rel
+	}
+	return types.FileTransfer{Path: p, Key: key, Size: size}
+}
+
+// SortTransfers orders each list in a summary by key, as files complete in no particular order.
+func SortTransfers(summary *types.TransferSummary) {
+	for _, list := range [][]types.FileTransfer{summary.Transferred, summary.Failed, summary.Skipped} {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Key < list[j].Key
+		})
+	}
+}
+
+// LocalPath returns the path below dir for the slash-separated relative key rel. Keys with ".."
+// elements are rejected even when they would stay below dir, as are absolute paths.
+func LocalPath(dir, rel string) (string, error) {
+	for _, elem := range strings.FieldsFunc(rel, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if elem == ".." {
+			return "", fmt.Errorf("key path %q contains a parent directory reference", rel)
+		}
+	}
+
+	name := filepath.FromSlash(rel)
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("key path %q is not within the destination directory", rel)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// ValidateGlobs reports the first malformed pattern in any of the lists.
+func ValidateGlobs(lists ...[]string) error {
+	for _, patterns := range lists {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Selected reports whether a remote key with the slash-separated relative path rel is one the
+// filters would have selected locally, so that excluded keys are never deleted.
+func Selected(include, exclude []string, rel string) bool {
+	if len(include) > 0 && !MatchAny(include, rel) {
+		return false
+	}
+	// Excluded directories are not walked, so a match on any parent excludes the key.
+	for p := rel; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+		if MatchAny(exclude, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchAny reports whether rel matches any of the glob patterns.
+func MatchAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob reports whether the slash-separated path rel matches pattern. Patterns without a slash
+// match the last element of rel; otherwise each element is matched in turn and "**" matches any
+// number of elements.
+func matchGlob(pattern, rel string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return matchElements(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+func matchElements(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchElements(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dirwalk
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("matchGlob",
+	func(pattern, rel string, expected bool) {
+		Expect(matchGlob(pattern, rel)).To(Equal(expected))
+	},
+	Entry("name at any depth", "*.map", "a/b/c.js.map", true),
+	Entry("name mismatch", "*.map", "a/b/c.js", false),
+	Entry("anchored path", "assets/*.js", "assets/app.js", true),
+	Entry("anchored path at wrong depth", "assets/*.js", "assets/x/app.js", false),
+	Entry("leading slash", "/assets/*.js", "assets/app.js", true),
+	Entry("double star spans directories", "assets/**/*.js", "assets/a/b/app.js", true),
+	Entry("double star matches no directories", "assets/**/*.js", "assets/app.js", true),
+	Entry("trailing double star", "build/**", "build/a/b", true),
+	Entry("leading double star", "**/tmp/*", "a/tmp/x", true),
+	Entry("double star needs the rest to match", "**/tmp/*", "a/tmp", false),
+)
+
+var _ = DescribeTable("Selected",
+	func(include, exclude []string, rel string, expected bool) {
+		Expect(Selected(include, exclude, rel)).To(Equal(expected))
+	},
+	Entry("no filters", nil, nil, "a/b.txt", true),
+	Entry("include mismatch", []string{"*.js"}, nil, "a/b.txt", false),
+	Entry("excluded name", nil, []string{"*.txt"}, "a/b.txt", false),
+	Entry("excluded parent directory", nil, []string{"a"}, "a/b/c.txt", false),
+)
+
+var _ = DescribeTable("LocalPath",
+	func(rel string, valid bool) {
+		_, err := LocalPath("/dst", rel)
+		Expect(err == nil).To(Equal(valid))
+	},
+	Entry("nested key", "a/b.txt", true),
+	Entry("parent reference", "a/../b.txt", false),
+	Entry("backslash parent reference", `a\..\b.txt`, false),
+	Entry("absolute path", "/etc/passwd", false),
+)
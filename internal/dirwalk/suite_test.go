@@ -0,0 +1,13 @@
+package dirwalk
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDirwalk(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dirwalk Suite")
+}
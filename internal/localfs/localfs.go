@@ -0,0 +1,104 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package localfs writes downloaded objects below a destination directory through an os.Root, so
+// symbolic links inside the directory cannot redirect files outside it. It is shared by the S3
+// wrapper and the in-memory fake so both write files the same way.
+package localfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// maxTempAttempts bounds the search for an unused temporary file name.
+const maxTempAttempts = 100
+
+// OpenRoot creates dir if needed and opens it as the root for later writes.
+func OpenRoot(dir string) (*os.Root, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenRoot(dir)
+}
+
+// OpenRegular opens the regular file name below root for reading. It returns nil without an error
+// when nothing exists at name and nil with the file's information when it is not a regular file.
+func OpenRegular(root *os.Root, name string) (*os.File, fs.FileInfo, error) {
+	f, err := root.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		_ = f.Close()
+		return nil, info, err
+	}
+	return f, info, nil
+}
+
+// WriteFile writes r to name below root through a temporary file in the same directory that is
+// renamed into place once complete, so readers never see a partial file. The file is made readable
+// by everyone and, unless modified is zero, given that modification time.
+func WriteFile(root *os.Root, name string, r io.Reader, modified time.Time) error {
+	dir := filepath.Dir(name)
+	if err := root.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, tmpName, err := createTemp(root, dir, filepath.Base(name))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, r)
+	if err == nil {
+		err = tmp.Chmod(0o644)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && !modified.IsZero() {
+		err = root.Chtimes(tmpName, modified, modified)
+	}
+	if err == nil {
+		err = root.Rename(tmpName, name)
+	}
+	if err != nil {
+		_ = root.Remove(tmpName)
+	}
+	return err
+}
+
+// createTemp creates a new hidden file named after base in dir below root, as os.CreateTemp does.
+func createTemp(root *os.Root, dir, base string) (*os.File, string, error) {
+	for try := 1; ; try++ {
+		name := filepath.Join(dir, "."+base+"."+strconv.FormatUint(uint64(rand.Uint32()), 10))
+		f, err := root.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, fs.ErrExist) && try < maxTempAttempts {
+			continue
+		}
+		return f, name, err
+	}
+}
@@ -0,0 +1,13 @@
+package syncplan
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSyncplan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Syncplan Suite")
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package syncplan decides which files a sync uploads and which objects it deletes. It is shared by
+// the S3 wrapper and the in-memory fake so both plan the same operations.
+package syncplan
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drewbernetes/simple-s3/internal/dirwalk"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// Object is a listed object under the synced prefix.
+type Object struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// Reason returns why file needs uploading over obj, or "" if it does not. A nil obj is a new file.
+//
+// Files whose size differs are uploaded. As a listing reports the stored size, originalSize is
+// asked for the uncompressed size recorded for an object whose size differs, or -1 if it is not
+// compressed. Otherwise, with checksum, matches compares the content of the file with the object's
+// ETag; without it, or for compressed objects whose ETag covers the compressed bytes, files
+// modified after the object are uploaded.
+func Reason(file types.FileTransfer, obj *Object, checksum bool, matches func(path, etag string) (bool, error), originalSize func(key string) (int64, error)) (string, error) {
+	if obj == nil {
+		return "new", nil
+	}
+	compressed := false
+	if obj.Size != file.Size {
+		size, err := originalSize(obj.Key)
+		if err != nil {
+			return "", err
+		}
+		if size != file.Size {
+			return "size changed", nil
+		}
+		compressed = true
+	}
+
+	if checksum && !compressed {
+		same, err := matches(file.Path, obj.ETag)
+		if err != nil || same {
+			return "", err
+		}
+		return "content changed", nil
+	}
+
+	info, err := os.Stat(file.Path)
+	if err != nil {
+		return "", err
+	}
+	if info.ModTime().After(obj.LastModified) {
+		return "modified", nil
+	}
+	return "", nil
+}
+
+// Orphans returns the objects a sync with Delete removes: those under listPrefix with no local
+// file in w that are selected by the filters. Folder markers and objects below a local directory
+// that could not be read are kept.
+func Orphans(w *dirwalk.Walker, objects []Object, listPrefix string, include, exclude []string) []Object {
+	local := make(map[string]bool, len(w.Files)+len(w.Skipped)+len(w.Failed))
+	for _, list := range [][]types.FileTransfer{w.Files, w.Skipped, w.Failed} {
+		for _, f := range list {
+			local[f.Key] = true
+		}
+	}
+
+	var orphans []Object
+	for _, obj := range objects {
+		rel := strings.TrimPrefix(obj.Key, listPrefix)
+		if local[obj.Key] || strings.HasSuffix(obj.Key, "/") || underAny(w.Failed, obj.Key) || !dirwalk.Selected(include, exclude, rel) {
+			continue
+		}
+		orphans = append(orphans, obj)
+	}
+	return orphans
+}
+
+// underAny reports whether key lies below the key of one of the transfers, such as a local
+// directory that could not be read and so whose remote copies must not be deleted.
+func underAny(transfers []types.FileTransfer, key string) bool {
+	for _, f := range transfers {
+		if strings.HasPrefix(key, f.Key+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Sort orders uploads before deletions, each by key.
+func Sort(ops []types.SyncOperation) {
+	sort.SliceStable(ops, func(i, j int) bool {
+		a, b := ops[i], ops[j]
+		if a.Action != b.Action {
+			return a.Action == types.SyncUpload
+		}
+		return a.Key < b.Key
+	})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncplan
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/internal/dirwalk"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("Reason", func() {
+	var file types.FileTransfer
+
+	BeforeEach(func() {
+		file = types.FileTransfer{Path: filepath.Join(GinkgoT().TempDir(), "a.txt"), Key: "a.txt", Size: 1}
+		Expect(os.WriteFile(file.Path, []byte("a"), 0o644)).To(Succeed())
+	})
+
+	noMatch := func(string, string) (bool, error) { return false, nil }
+	uncompressed := func(string) (int64, error) { return -1, nil }
+
+	It("compares sizes, then modification times", func() {
+		Expect(Reason(file, nil, false, noMatch, uncompressed)).To(Equal("new"))
+		Expect(Reason(file, &Object{Size: 2}, false, noMatch, uncompressed)).To(Equal("size changed"))
+		Expect(Reason(file, &Object{Size: 1, LastModified: time.Now().Add(-time.Hour)}, false, noMatch, uncompressed)).To(Equal("modified"))
+		Expect(Reason(file, &Object{Size: 1, LastModified: time.Now().Add(time.Hour)}, false, noMatch, uncompressed)).To(BeEmpty())
+	})
+
+	It("compares contents with the checksum option", func() {
+		obj := &Object{Size: 1, ETag: `"etag"`, LastModified: time.Now().Add(-time.Hour)}
+		Expect(Reason(file, obj, true, noMatch, uncompressed)).To(Equal("content changed"))
+		Expect(Reason(file, obj, true, func(path, etag string) (bool, error) {
+			return path == file.Path && etag == `"etag"`, nil
+		}, uncompressed)).To(BeEmpty())
+	})
+
+	It("compares compressed objects by their original size and modification time", func() {
+		obj := &Object{Key: "a.txt", Size: 20, LastModified: time.Now().Add(time.Hour)}
+		originalSize := func(key string) (int64, error) {
+			Expect(key).To(Equal("a.txt"))
+			return 1, nil
+		}
+		Expect(Reason(file, obj, true, noMatch, originalSize)).To(BeEmpty())
+		Expect(Reason(file, obj, false, noMatch, func(string) (int64, error) { return 2, nil })).To(Equal("size changed"))
+
+		obj.LastModified = time.Now().Add(-time.Hour)
+		Expect(Reason(file, obj, true, noMatch, originalSize)).To(Equal("modified"))
+	})
+})
+
+var _ = Describe("Orphans", func() {
+	It("keeps local, filtered and folder keys and keys below unreadable directories", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644)).To(Succeed())
+		Expect(os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "gone"))).To(Succeed())
+		w, err := dirwalk.Walk(dir, "site", types.UploadDirectoryOptions{FollowSymlinks: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Failed).To(HaveLen(1))
+
+		var keys []string
+		for _, obj := range Orphans(w, []Object{
+			{Key: "site/a.txt"},
+			{Key: "site/old.txt"},
+			{Key: "site/keep.log"},
+			{Key: "site/folder/"},
+			{Key: "site/gone/b.txt"},
+		}, "site/", nil, []string{"*.log"}) {
+			keys = append(keys, obj.Key)
+		}
+		Expect(keys).To(Equal([]string{"site/old.txt"}))
+	})
+})
+
+var _ = Describe("Sort", func() {
+	It("orders uploads before deletions, each by key", func() {
+		ops := []types.SyncOperation{
+			{Action: types.SyncDelete, Key: "a"},
+			{Action: types.SyncUpload, Key: "c"},
+			{Action: types.SyncUpload, Key: "b"},
+		}
+		Sort(ops)
+		Expect(ops).To(Equal([]types.SyncOperation{
+			{Action: types.SyncUpload, Key: "b"},
+			{Action: types.SyncUpload, Key: "c"},
+			{Action: types.SyncDelete, Key: "a"},
+		}))
+	})
+})
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// discardLogger is used when no Logger is configured.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// logger returns the configured logger, or one that discards everything.
+func (s *S3) logger() *slog.Logger {
+	if s.options.Logger == nil {
+		return discardLogger
+	}
+	return s.options.Logger
+}
+
+// Credentials that appear in request dumps, replaced before they are logged.
+var (
+	sensitiveHeaders = regexp.MustCompile(`(?im)^(Authorization|X-Amz-Security-Token|X-Amz(?:-Copy-Source)?-Server-Side-Encryption-Customer-Key):[^\r\n]*`)
+	sensitiveQuery   = regexp.MustCompile(`(?i)(X-Amz-(?:Credential|Signature|Security-Token)=)[^&\s]+`)
+)
+
+// redactCredentials removes signatures, session tokens and encryption keys from a request dump.
+func redactCredentials(s string) string {
+	s = sensitiveHeaders.ReplaceAllString(s, "$1: REDACTED")
+	return sensitiveQuery.ReplaceAllString(s, "${1}REDACTED")
+}
+
+// sdkLogger forwards the AWS SDK's log output, including request and response dumps, to a
+// slog.Logger with credentials redacted.
+type sdkLogger struct {
+	ctx context.Context
+	log *slog.Logger
+}
+
+func (l sdkLogger) Logf(classification logging.Classification, format string, v ...any) {
+	level := slog.LevelDebug
+	if classification == logging.Warn {
+		level = slog.LevelWarn
+	}
+	ctx := l.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	l.log.Log(ctx, level, "AWS SDK", slog.String("detail", redactCredentials(fmt.Sprintf(format, v...))))
+}
+
+// WithContext returns a logger recording entries against ctx, so handlers can read trace IDs and
+// other values from it.
+func (l sdkLogger) WithContext(ctx context.Context) logging.Logger {
+	return sdkLogger{ctx: ctx, log: l.log}
+}
+
+// attemptsKey is the stack value holding the retryState of a request.
+type attemptsKey struct{}
+
+// retryState tracks the attempts the SDK has made for one request.
+type retryState struct {
+	attempts int
+	lastErr  error
+}
+
+// retryTracker records a retryState for each request ahead of the SDK retry middleware.
+type retryTracker struct{}
+
+func (retryTracker) ID() string { return "SimpleS3RetryTracker" }
+
+func (retryTracker) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	middleware.FinalizeOutput, middleware.Metadata, error,
+) {
+	return next.HandleFinalize(middleware.WithStackValue(ctx, attemptsKey{}, &retryState{}), in)
+}
+
+// retryLogger runs inside the SDK retry loop and logs each attempt after the first with the error
+// that caused it.
+type retryLogger struct {
+	log *slog.Logger
+}
+
+func (retryLogger) ID() string { return "SimpleS3RetryLogger" }
+
+func (l retryLogger) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	middleware.FinalizeOutput, middleware.Metadata, error,
+) {
+	state, ok := middleware.GetStackValue(ctx, attemptsKey{}).(*retryState)
+	if !ok {
+		return next.HandleFinalize(ctx, in)
+	}
+
+	state.attempts++
+	if state.attempts > 1 {
+		l.log.WarnContext(ctx, "retrying S3 request",
+			slog.String("operation", awsmiddleware.GetOperationName(ctx)),
+			slog.Int("attempt", state.attempts),
+			slog.Any("error", state.lastErr))
+	}
+	out, metadata, err := next.HandleFinalize(ctx, in)
+	state.lastErr = err
+	return out, metadata, err
+}
+
+// addRetryLogging installs retryTracker and retryLogger around the SDK retry middleware.
+func addRetryLogging(log *slog.Logger) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		if _, ok := stack.Finalize.Get("Retry"); !ok {
+			return nil
+		}
+		if err := stack.Finalize.Insert(retryTracker{}, "Retry", middleware.Before); err != nil {
+			return err
+		}
+		return stack.Finalize.Insert(retryLogger{log: log}, "Retry", middleware.After)
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/s3test"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("Logging", func() {
+	var (
+		ctx    context.Context
+		buf    *bytes.Buffer
+		logger *slog.Logger
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		buf = &bytes.Buffer{}
+		logger = slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	})
+
+	// entries decodes every record logged so far.
+	entries := func() []map[string]any {
+		GinkgoHelper()
+		var out []map[string]any
+		for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+			if len(line) == 0 {
+				continue
+			}
+			var e map[string]any
+			Expect(json.Unmarshal(line, &e)).To(Succeed())
+			out = append(out, e)
+		}
+		return out
+	}
+
+	// entriesWith returns the records logged with msg.
+	entriesWith := func(msg string) []map[string]any {
+		GinkgoHelper()
+		var out []map[string]any
+		for _, e := range entries() {
+			if e["msg"] == msg {
+				out = append(out, e)
+			}
+		}
+		return out
+	}
+
+	Context("with a stubbed API", func() {
+		var (
+			sut *S3
+			api *stubAPI
+		)
+
+		BeforeEach(func() {
+			api = &stubAPI{}
+			sut = NewFromAPI(api, func(o *Options) { o.Logger = logger })
+		})
+
+		It("logs the start and completion of each call at debug level", func() {
+			api.deleteObject = func(ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+				return &s3.DeleteObjectOutput{}, nil
+			}
+			Expect(sut.DeleteObject(ctx, "bucket-a", "key-a")).To(Succeed())
+
+			Expect(entries()).To(HaveLen(2))
+			started := entriesWith("starting S3 operation")
+			Expect(started).To(ConsistOf(SatisfyAll(
+				HaveKeyWithValue("level", "DEBUG"),
+				HaveKeyWithValue("operation", "DeleteObject"),
+				HaveKeyWithValue("bucket", "bucket-a"),
+				HaveKeyWithValue("key", "key-a"),
+			)))
+			Expect(entriesWith("S3 operation completed")).To(ConsistOf(SatisfyAll(
+				HaveKeyWithValue("level", "DEBUG"),
+				HaveKey("duration"),
+			)))
+		})
+
+		It("logs failures as warnings", func() {
+			api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+				return nil, apiErr{code: "NoSuchKey"}
+			}
+			_, err := sut.StatObject(ctx, "bucket-a", "key-a")
+			Expect(err).To(HaveOccurred())
+
+			Expect(entriesWith("S3 operation failed")).To(ConsistOf(SatisfyAll(
+				HaveKeyWithValue("level", "WARN"),
+				HaveKeyWithValue("operation", "StatObject"),
+				HaveKeyWithValue("error", ContainSubstring("NoSuchKey")),
+			)))
+		})
+
+		It("logs batch deletion progress and keys that could not be deleted", func() {
+			api.headBucket = func(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+				return &s3.HeadBucketOutput{}, nil
+			}
+			api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
+				objects := make([]s3types.Object, 1500)
+				for i := range objects {
+					objects[i] = s3types.Object{Key: aws.String(fmt.Sprintf("key-%04d", i))}
+				}
+				return objects, nil
+			}
+			batch := 0
+			api.deleteObjects = func(ctx context.Context, params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+				batch++
+				if batch == 2 {
+					return &s3.DeleteObjectsOutput{Errors: []s3types.Error{
+						{Key: aws.String("key-1200"), Code: aws.String("AccessDenied"), Message: aws.String("Access Denied")},
+					}}, nil
+				}
+				return &s3.DeleteObjectsOutput{}, nil
+			}
+			api.deleteBucket = func(ctx context.Context, params *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+				return nil, apiErr{code: "BucketNotEmpty"}
+			}
+
+			Expect(sut.DeleteBucket(ctx, "bucket-a")).To(MatchError(ErrBucketNotEmpty))
+
+			Expect(entriesWith("deleting objects before removing bucket")).To(ConsistOf(HaveKeyWithValue("objects", BeNumerically("==", 1500))))
+			progress := entriesWith("deleted batch of objects")
+			Expect(progress).To(HaveLen(2))
+			Expect(progress[0]).To(HaveKeyWithValue("deleted", BeNumerically("==", 1000)))
+			Expect(progress[1]).To(HaveKeyWithValue("deleted", BeNumerically("==", 1500)))
+			Expect(progress[1]).To(HaveKeyWithValue("level", "INFO"))
+			Expect(entriesWith("objects could not be deleted")).To(ConsistOf(SatisfyAll(
+				HaveKeyWithValue("level", "WARN"),
+				HaveKeyWithValue("failed", BeNumerically("==", 1)),
+				HaveKeyWithValue("first_key", "key-1200"),
+				HaveKeyWithValue("code", "AccessDenied"),
+			)))
+		})
+
+		It("logs multipart upload events", func() {
+			fake := &fakeMultipart{failPart: 2}
+			fake.install(api)
+			payload := bytes.Repeat([]byte("d"), 12*mib)
+			store := &memStateStore{}
+			multipart := func(o *types.PutObjectOptions) {
+				o.Resumable = true
+				o.StateStore = store
+				o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib, Concurrency: 1}
+			}
+
+			Expect(sut.PutObject(ctx, "bucket-a", "key-a", bytes.NewReader(payload), multipart)).NotTo(Succeed())
+			Expect(entriesWith("created multipart upload")).To(ConsistOf(SatisfyAll(
+				HaveKeyWithValue("level", "INFO"),
+				HaveKeyWithValue("upload_id", "upload-1"),
+				HaveKeyWithValue("part_size", BeNumerically("==", 5*mib)),
+			)))
+			uploaded := entriesWith("uploaded part")
+			Expect(uploaded).To(ContainElement(HaveKeyWithValue("part", BeNumerically("==", 1))))
+			Expect(uploaded).NotTo(ContainElement(HaveKeyWithValue("part", BeNumerically("==", 2))))
+			Expect(entriesWith("multipart upload left in place to resume")).To(HaveLen(1))
+
+			fake.failPart = 0
+			Expect(sut.PutObject(ctx, "bucket-a", "key-a", bytes.NewReader(payload), multipart)).To(Succeed())
+			Expect(entriesWith("resuming multipart upload")).To(ConsistOf(
+				HaveKeyWithValue("parts_remaining", BeNumerically(">=", 1)),
+			))
+			Expect(entriesWith("completed multipart upload")).To(ConsistOf(HaveKeyWithValue("parts", BeNumerically("==", 3))))
+		})
+
+		It("logs aborted uploads", func() {
+			api.listUploads = func(ctx context.Context, bucket, prefix string) ([]s3types.MultipartUpload, error) {
+				return []s3types.MultipartUpload{{
+					Key:       aws.String("key-a"),
+					UploadId:  aws.String("upload-1"),
+					Initiated: aws.Time(time.Now().Add(-48 * time.Hour)),
+				}}, nil
+			}
+			api.abortMultipartUpload = func(ctx context.Context, params *s3.AbortMultipartUploadInput) (*s3.AbortMultipartUploadOutput, error) {
+				return &s3.AbortMultipartUploadOutput{}, nil
+			}
+
+			_, err := sut.AbortStaleMultipartUploads(ctx, "bucket-a", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entriesWith("aborted stale multipart upload")).To(ConsistOf(SatisfyAll(
+				HaveKeyWithValue("level", "INFO"),
+				HaveKeyWithValue("key", "key-a"),
+				HaveKeyWithValue("upload_id", "upload-1"),
+			)))
+		})
+	})
+
+	Context("with the SDK client", func() {
+		var server *s3test.Server
+
+		BeforeEach(func() {
+			server = s3test.NewServer()
+			DeferCleanup(server.Close)
+		})
+
+		It("logs retries with the error that caused them", func() {
+			sut, err := New(ctx, server.URL, "access-key", "secret-key", "", func(o *Options) {
+				o.Logger = logger
+				o.Retry.MaxBackoff = time.Millisecond
+				o.Faults = []Fault{{Operations: []string{"PutObject"}, Times: 1, StatusCode: 500, ErrorCode: "InternalError"}}
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sut.CreateBucket(ctx, "bucket-a")).To(Succeed())
+			Expect(sut.PutObject(ctx, "bucket-a", "key-a", bytes.NewReader([]byte("payload")))).To(Succeed())
+
+			Expect(entriesWith("retrying S3 request")).To(ConsistOf(SatisfyAll(
+				HaveKeyWithValue("level", "WARN"),
+				HaveKeyWithValue("operation", "PutObject"),
+				HaveKeyWithValue("attempt", BeNumerically("==", 2)),
+				HaveKeyWithValue("error", ContainSubstring("InternalError")),
+			)))
+		})
+
+		It("logs SDK requests with credentials redacted when enabled", func() {
+			sut, err := New(ctx, server.URL, "access-key", "secret-key", "", func(o *Options) {
+				o.Logger = logger
+				o.LogRequests = true
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sut.CreateBucket(ctx, "bucket-a")).To(Succeed())
+
+			sdk := entriesWith("AWS SDK")
+			Expect(sdk).NotTo(BeEmpty())
+			Expect(sdk).To(ContainElement(HaveKeyWithValue("detail", ContainSubstring("PUT /bucket-a"))))
+			Expect(sdk).To(ContainElement(HaveKeyWithValue("detail", ContainSubstring("Authorization: REDACTED"))))
+			Expect(buf.String()).NotTo(ContainSubstring("Signature="))
+			Expect(buf.String()).NotTo(ContainSubstring("access-key/"))
+		})
+
+		It("does not log SDK requests by default", func() {
+			sut, err := New(ctx, server.URL, "access-key", "secret-key", "", func(o *Options) {
+				o.Logger = logger
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sut.CreateBucket(ctx, "bucket-a")).To(Succeed())
+			Expect(entriesWith("AWS SDK")).To(BeEmpty())
+		})
+	})
+
+	Describe("redactCredentials", func() {
+		It("redacts signing headers, session tokens and encryption keys", func() {
+			dump := "GET /bucket-a/key-a HTTP/1.1\r\n" +
+				"Authorization: AWS4-HMAC-SHA256 Credential=AKID/20260101/us-east-1/s3/aws4_request, Signature=abc\r\n" +
+				"X-Amz-Security-Token: token\r\n" +
+				"x-amz-server-side-encryption-customer-key: key\r\n" +
+				"X-Amz-Date: 20260101T000000Z\r\n"
+			redacted := redactCredentials(dump)
+			Expect(redacted).To(ContainSubstring("Authorization: REDACTED\r\n"))
+			Expect(redacted).To(ContainSubstring("X-Amz-Security-Token: REDACTED\r\n"))
+			Expect(redacted).To(ContainSubstring("x-amz-server-side-encryption-customer-key: REDACTED\r\n"))
+			Expect(redacted).To(ContainSubstring("X-Amz-Date: 20260101T000000Z"))
+			Expect(redacted).NotTo(ContainSubstring("AKID"))
+		})
+
+		It("redacts presigned query parameters", func() {
+			redacted := redactCredentials("GET /k?X-Amz-Credential=AKID%2F20260101&X-Amz-Signature=abc&x-id=GetObject")
+			Expect(redacted).To(Equal("GET /k?X-Amz-Credential=REDACTED&X-Amz-Signature=REDACTED&x-id=GetObject"))
+		})
+	})
+})
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// ListMultipartUploads lists the incomplete multipart uploads in a bucket filtered by key prefix.
+func (s *S3) ListMultipartUploads(ctx context.Context, bucket, prefix string) (_ []types.MultipartUpload, err error) {
+	ctx, call := s.startOperation(ctx, "ListMultipartUploads", bucket, "")
+	defer func() { call.end(ctx, err) }()
+
+	uploads, err := listMultipartUploadsAll(ctx, s.client(), bucket, prefix)
+	if err != nil {
+		return nil, newError("ListMultipartUploads", bucket, "", err)
+	}
+
+	out := make([]types.MultipartUpload, 0, len(uploads))
+	for _, u := range uploads {
+		out = append(out, types.MultipartUpload{
+			Key:       aws.ToString(u.Key),
+			UploadID:  aws.ToString(u.UploadId),
+			Initiated: aws.ToTime(u.Initiated),
+		})
+	}
+	return out, nil
+}
+
+// AbortStaleMultipartUploads aborts every incomplete multipart upload in a bucket started more than
+// olderThan ago, releasing the storage held by its parts.
+//
+// It returns the uploads that were aborted. Failing to abort one upload does not stop the others;
+// the failures are joined into the returned error.
+func (s *S3) AbortStaleMultipartUploads(ctx context.Context, bucket string, olderThan time.Duration) (_ []types.MultipartUpload, err error) {
+	ctx, call := s.startOperation(ctx, "AbortStaleMultipartUploads", bucket, "")
+	defer func() { call.end(ctx, err) }()
+
+	uploads, err := s.ListMultipartUploads(ctx, bucket, "")
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	aborted := make([]types.MultipartUpload, 0)
+	var errs []error
+	for _, u := range uploads {
+		if !u.Initiated.Before(cutoff) {
+			continue
+		}
+
+		_, err := s.client().AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(u.Key),
+			UploadId: aws.String(u.UploadID),
+		})
+		// An upload completed or aborted since it was listed no longer needs cleaning up.
+		if isUploadNotFound(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, newError("AbortStaleMultipartUploads", bucket, u.Key, err))
+			continue
+		}
+		call.log.InfoContext(ctx, "aborted stale multipart upload", slog.String("key", u.Key),
+			slog.String("upload_id", u.UploadID), slog.Time("initiated", u.Initiated))
+		aborted = append(aborted, u)
+	}
+
+	return aborted, errors.Join(errs...)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("Incomplete multipart uploads", func() {
+	var (
+		sut     *S3
+		api     *stubAPI
+		now     time.Time
+		uploads []s3types.MultipartUpload
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		now = time.Now()
+		uploads = []s3types.MultipartUpload{
+			{Key: aws.String("backups/old.tar"), UploadId: aws.String("old"), Initiated: aws.Time(now.Add(-72 * time.Hour))},
+			{Key: aws.String("backups/new.tar"), UploadId: aws.String("new"), Initiated: aws.Time(now.Add(-time.Hour))},
+			{Key: aws.String("logs/old.log"), UploadId: aws.String("old-log"), Initiated: aws.Time(now.Add(-48 * time.Hour))},
+		}
+		api.listUploads = func(ctx context.Context, bucket, prefix string) ([]s3types.MultipartUpload, error) {
+			Expect(bucket).To(Equal("bucket-a"))
+			return uploads, nil
+		}
+	})
+
+	It("lists uploads with their key, upload ID and initiation time", func() {
+		api.listUploads = func(ctx context.Context, bucket, prefix string) ([]s3types.MultipartUpload, error) {
+			Expect(prefix).To(Equal("backups/"))
+			return uploads[:2], nil
+		}
+
+		got, err := sut.ListMultipartUploads(context.Background(), "bucket-a", "backups/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal([]types.MultipartUpload{
+			{Key: "backups/old.tar", UploadID: "old", Initiated: now.Add(-72 * time.Hour)},
+			{Key: "backups/new.tar", UploadID: "new", Initiated: now.Add(-time.Hour)},
+		}))
+	})
+
+	It("wraps listing errors", func() {
+		api.listUploads = func(ctx context.Context, bucket, prefix string) ([]s3types.MultipartUpload, error) {
+			return nil, apiErr{code: "NoSuchBucket"}
+		}
+
+		_, err := sut.ListMultipartUploads(context.Background(), "bucket-a", "")
+		Expect(err).To(MatchError(ErrBucketNotFound))
+	})
+
+	It("aborts only uploads older than the cutoff and reports them", func() {
+		var abortedIDs []string
+		api.abortMultipartUpload = func(ctx context.Context, params *s3.AbortMultipartUploadInput) (*s3.AbortMultipartUploadOutput, error) {
+			abortedIDs = append(abortedIDs, aws.ToString(params.UploadId))
+			return &s3.AbortMultipartUploadOutput{}, nil
+		}
+
+		aborted, err := sut.AbortStaleMultipartUploads(context.Background(), "bucket-a", 24*time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(abortedIDs).To(Equal([]string{"old", "old-log"}))
+		Expect(aborted).To(HaveLen(2))
+		Expect(aborted[0].Key).To(Equal("backups/old.tar"))
+		Expect(aborted[1].Key).To(Equal("logs/old.log"))
+	})
+
+	It("continues past failures and skips uploads that have already gone", func() {
+		api.abortMultipartUpload = func(ctx context.Context, params *s3.AbortMultipartUploadInput) (*s3.AbortMultipartUploadOutput, error) {
+			switch aws.ToString(params.UploadId) {
+			case "old":
+				return nil, apiErr{code: "AccessDenied"}
+			case "old-log":
+				return nil, apiErr{code: "NoSuchUpload"}
+			}
+			return &s3.AbortMultipartUploadOutput{}, nil
+		}
+
+		aborted, err := sut.AbortStaleMultipartUploads(context.Background(), "bucket-a", 0)
+		Expect(err).To(MatchError(ErrAccessDenied))
+		Expect(aborted).To(HaveLen(1))
+		Expect(aborted[0].UploadID).To(Equal("new"))
+
+		var s3Err *Error
+		Expect(errors.As(err, &s3Err)).To(BeTrue())
+		Expect(s3Err.Key).To(Equal("backups/old.tar"))
+	})
+})
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// Options configures the S3 wrapper created by New.
+type Options struct {
+	// Retry configures how failed requests are retried.
+	Retry RetryOptions
+
+	// Multipart configures the default part size, threshold and concurrency for uploads.
+	Multipart types.MultipartOptions
+
+	// ContentTypes maps lower-case file extensions, including the leading dot, to the content type
+	// set on uploaded keys with that extension. Entries override the built-in table and the system
+	// MIME database.
+	ContentTypes map[string]string
+
+	// Faults are injected into the client's requests to test retry and timeout handling. They are
+	// intended for tests only.
+	Faults []Fault
+
+	// Telemetry enables OpenTelemetry spans and metrics for every S3 method.
+	Telemetry TelemetryOptions
+
+	// Logger receives the start and outcome of every S3 method at debug level, with failures,
+	// retries and the AWS SDK's own warnings logged as warnings. Batch deletions and multipart
+	// uploads also log their progress. Nil disables logging.
+	Logger *slog.Logger
+
+	// LogRequests logs every HTTP request and response the AWS SDK sends, without bodies, to
+	// Logger at debug level. Signatures, session tokens and encryption keys are redacted.
+	LogRequests bool
+}
+
+// RetryOptions configures the retry, backoff and throttling policy applied to every request.
+//
+// The zero value keeps the AWS SDK defaults.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts made for a request, including the first.
+	// Zero uses the SDK default of 3 and 1 disables retries.
+	MaxAttempts int
+
+	// MaxBackoff caps the delay between attempts. Zero uses the SDK default of 20 seconds.
+	MaxBackoff time.Duration
+
+	// Adaptive enables client-side rate limiting which delays requests when the service throttles.
+	Adaptive bool
+
+	// RetryableErrorCodes lists additional API error codes that are always retried.
+	// Throttling codes such as SlowDown are already retried by default.
+	RetryableErrorCodes []string
+
+	// DisableRetryQuota removes the client-side retry token bucket so retries are never refused
+	// locally once the quota is exhausted.
+	DisableRetryQuota bool
+}
+
+// isZero reports whether no retry setting has been changed from the SDK defaults.
+func (o RetryOptions) isZero() bool {
+	return o.MaxAttempts == 0 && o.MaxBackoff == 0 && !o.Adaptive &&
+		len(o.RetryableErrorCodes) == 0 && !o.DisableRetryQuota
+}
+
+// newRetryer returns a retryer factory honouring the provided options.
+func newRetryer(o RetryOptions) func() aws.Retryer {
+	standard := func(so *retry.StandardOptions) {
+		if o.MaxAttempts > 0 {
+			so.MaxAttempts = o.MaxAttempts
+		}
+		if o.MaxBackoff > 0 {
+			so.MaxBackoff = o.MaxBackoff
+			so.Backoff = retry.NewExponentialJitterBackoff(o.MaxBackoff)
+		}
+		if len(o.RetryableErrorCodes) > 0 {
+			codes := make(map[string]struct{}, len(o.RetryableErrorCodes))
+			for _, code := range o.RetryableErrorCodes {
+				codes[code] = struct{}{}
+			}
+			so.Retryables = append([]retry.IsErrorRetryable{retry.RetryableErrorCode{Codes: codes}}, so.Retryables...)
+		}
+		if o.DisableRetryQuota {
+			so.RateLimiter = ratelimit.None
+		}
+	}
+
+	return func() aws.Retryer {
+		if o.Adaptive {
+			return retry.NewAdaptiveMode(func(ao *retry.AdaptiveModeOptions) {
+				ao.StandardOptions = append(ao.StandardOptions, standard)
+			})
+		}
+		return retry.NewStandard(standard)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Options", func() {
+	Describe("RetryOptions", func() {
+		It("reports the zero value", func() {
+			Expect(RetryOptions{}.isZero()).To(BeTrue())
+			Expect(RetryOptions{MaxAttempts: 1}.isZero()).To(BeFalse())
+			Expect(RetryOptions{DisableRetryQuota: true}.isZero()).To(BeFalse())
+		})
+	})
+
+	Describe("newRetryer", func() {
+		It("builds a standard retryer with the configured limits", func() {
+			r := newRetryer(RetryOptions{MaxAttempts: 7, MaxBackoff: time.Second})()
+			Expect(r).To(BeAssignableToTypeOf(&retry.Standard{}))
+			Expect(r.MaxAttempts()).To(Equal(7))
+
+			delay, err := r.RetryDelay(10, apiErr{code: "SlowDown"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delay).To(BeNumerically("<=", time.Second))
+		})
+
+		It("builds an adaptive retryer when requested", func() {
+			r := newRetryer(RetryOptions{Adaptive: true, MaxAttempts: 4})()
+			Expect(r).To(BeAssignableToTypeOf(&retry.AdaptiveMode{}))
+			Expect(r.MaxAttempts()).To(Equal(4))
+		})
+
+		It("retries additional error codes", func() {
+			r := newRetryer(RetryOptions{RetryableErrorCodes: []string{"XMinioServerNotInitialized"}})()
+			Expect(r.IsErrorRetryable(apiErr{code: "XMinioServerNotInitialized"})).To(BeTrue())
+			Expect(r.IsErrorRetryable(apiErr{code: "AccessDenied"})).To(BeFalse())
+		})
+
+		It("retries SlowDown by default", func() {
+			r := newRetryer(RetryOptions{MaxAttempts: 2})()
+			Expect(r.IsErrorRetryable(apiErr{code: "SlowDown"})).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drewbernetes/simple-s3/internal/compression"
+	"github.com/drewbernetes/simple-s3/internal/dirwalk"
+	"github.com/drewbernetes/simple-s3/internal/localfs"
+	"github.com/drewbernetes/simple-s3/internal/syncplan"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// errDeleteSkipped marks deletions not made because an upload in the same sync failed.
+var errDeleteSkipped = errors.New("deletion skipped because an upload failed")
+
+// UploadDirectory uploads the files below localDir to keys under prefix with PutObject, selecting
+// files as the real client does. Files are uploaded one at a time.
+func (f *S3) UploadDirectory(ctx context.Context, localDir, bucket, prefix string, optFns ...func(*types.UploadDirectoryOptions)) (*types.TransferSummary, error) {
+	if err := f.begin(ctx, "UploadDirectory", bucket, prefix); err != nil {
+		return nil, err
+	}
+	o := types.UploadDirectoryOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if err := dirwalk.ValidateGlobs(o.Include, o.Exclude); err != nil {
+		return nil, fail("UploadDirectory", bucket, prefix, err)
+	}
+	w, err := dirwalk.Walk(localDir, prefix, o)
+	if err != nil {
+		return nil, fail("UploadDirectory", bucket, prefix, err)
+	}
+
+	summary := &types.TransferSummary{Skipped: w.Skipped, Failed: w.Failed}
+	var errs []error
+	for _, file := range w.Files {
+		if file.Err = f.uploadFile(ctx, bucket, file, o.ObjectOptions); file.Err != nil {
+			summary.Failed = append(summary.Failed, file)
+			errs = append(errs, file.Err)
+			continue
+		}
+		summary.Transferred = append(summary.Transferred, file)
+		summary.Bytes += file.Size
+	}
+	dirwalk.SortTransfers(summary)
+	return summary, errors.Join(errs...)
+}
+
+func (f *S3) uploadFile(ctx context.Context, bucket string, file types.FileTransfer, optFns []func(*types.PutObjectOptions)) error {
+	r, err := os.Open(file.Path)
+	if err != nil {
+		return fail("PutObject", bucket, file.Key, err)
+	}
+	defer r.Close() //nolint:all
+
+	return f.PutObject(ctx, bucket, file.Key, r, optFns...)
+}
+
+// DownloadPrefix downloads the objects under prefix into localDir byte for byte, skipping folder
+// markers and files that already have the size and ETag of their object, as the real client does.
+func (f *S3) DownloadPrefix(ctx context.Context, bucket, prefix, localDir string, _ ...func(*types.DownloadPrefixOptions)) (*types.TransferSummary, error) {
+	if err := f.begin(ctx, "DownloadPrefix", bucket, prefix); err != nil {
+		return nil, err
+	}
+	listPrefix := ""
+	if prefix != "" {
+		listPrefix = strings.TrimSuffix(prefix, "/") + "/"
+	}
+	keys, err := f.ListObject(ctx, bucket, listPrefix)
+	if err != nil {
+		return nil, fail("DownloadPrefix", bucket, prefix, err)
+	}
+
+	root, err := localfs.OpenRoot(localDir)
+	if err != nil {
+		return nil, fail("DownloadPrefix", bucket, prefix, err)
+	}
+	defer root.Close() //nolint:all
+
+	summary := &types.TransferSummary{}
+	var errs []error
+	for _, key := range keys {
+		rel := strings.TrimPrefix(key, listPrefix)
+		if rel == "" || strings.HasSuffix(key, "/") {
+			continue
+		}
+
+		obj, err := f.object("DownloadPrefix", bucket, key, errNoSuchKey)
+		if errors.Is(err, errNoSuchKey) {
+			// Deleted since it was listed.
+			continue
+		}
+		file := types.FileTransfer{Key: key}
+		skip := false
+		if err == nil {
+			file.Size = int64(len(obj.data))
+			file.Path, err = dirwalk.LocalPath(localDir, rel)
+		}
+		name := filepath.FromSlash(rel)
+		if err == nil {
+			skip, err = upToDate(root, name, obj)
+		}
+		if err == nil && !skip {
+			err = localfs.WriteFile(root, name, bytes.NewReader(obj.data), obj.modified)
+		}
+
+		switch {
+		case err != nil:
+			file.Err = fail("DownloadPrefix", bucket, key, err)
+			summary.Failed = append(summary.Failed, file)
+			errs = append(errs, file.Err)
+		case skip:
+			summary.Skipped = append(summary.Skipped, file)
+		default:
+			summary.Transferred = append(summary.Transferred, file)
+			summary.Bytes += file.Size
+		}
+	}
+	return summary, errors.Join(errs...)
+}
+
+// Sync uploads new and changed files below localDir to keys under prefix and, with Delete, removes
+// objects with no local file, following the real client's rules for choosing what to transfer.
+func (f *S3) Sync(ctx context.Context, localDir, bucket, prefix string, optFns ...func(*types.SyncOptions)) (*types.SyncResult, error) {
+	if err := f.begin(ctx, "Sync", bucket, prefix); err != nil {
+		return nil, err
+	}
+	o := types.SyncOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if err := dirwalk.ValidateGlobs(o.Include, o.Exclude); err != nil {
+		return nil, fail("Sync", bucket, prefix, err)
+	}
+	w, err := dirwalk.Walk(localDir, prefix, types.UploadDirectoryOptions{Include: o.Include, Exclude: o.Exclude, FollowSymlinks: o.FollowSymlinks})
+	if err != nil {
+		return nil, fail("Sync", bucket, prefix, err)
+	}
+
+	listPrefix := ""
+	if prefix != "" {
+		listPrefix = strings.TrimSuffix(prefix, "/") + "/"
+	}
+	snapshot, err := f.snapshot("Sync", bucket, listPrefix)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]syncplan.Object, 0, len(snapshot))
+	for key, obj := range snapshot {
+		objects = append(objects, syncplan.Object{Key: key, Size: int64(len(obj.data)), ETag: obj.etag, LastModified: obj.modified})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	remote := make(map[string]*syncplan.Object, len(objects))
+	for i := range objects {
+		remote[objects[i].Key] = &objects[i]
+	}
+
+	result := &types.SyncResult{DryRun: o.DryRun}
+	for _, file := range w.Files {
+		reason, err := syncplan.Reason(file, remote[file.Key], o.Checksum, fileMatches, func(key string) (int64, error) {
+			if obj := snapshot[key]; compression.Decoding(obj.contentEncoding) != "" {
+				return compression.OriginalSize(obj.metadata), nil
+			}
+			return -1, nil
+		})
+		if err != nil {
+			return nil, fail("Sync", bucket, file.Key, err)
+		}
+		if reason == "" {
+			result.Unchanged++
+			continue
+		}
+		result.Operations = append(result.Operations, types.SyncOperation{Action: types.SyncUpload, Path: file.Path, Key: file.Key, Size: file.Size, Reason: reason})
+		result.Bytes += file.Size
+	}
+	uploads := len(result.Operations)
+
+	if o.Delete {
+		for _, obj := range syncplan.Orphans(w, objects, listPrefix, o.Include, o.Exclude) {
+			result.Operations = append(result.Operations, types.SyncOperation{Action: types.SyncDelete, Key: obj.Key, Size: obj.Size, Reason: "not present locally"})
+		}
+	}
+	syncplan.Sort(result.Operations)
+
+	var errs []error
+	for _, file := range w.Failed {
+		errs = append(errs, fail("Sync", bucket, file.Key, file.Err))
+	}
+	if o.DryRun {
+		return result, errors.Join(errs...)
+	}
+
+	ops := result.Operations
+	failed := false
+	for i := range ops[:uploads] {
+		op := &ops[i]
+		if op.Err = f.uploadFile(ctx, bucket, types.FileTransfer{Path: op.Path, Key: op.Key}, o.ObjectOptions); op.Err != nil {
+			failed = true
+			result.Bytes -= op.Size
+			errs = append(errs, op.Err)
+		}
+	}
+	for i := range ops[uploads:] {
+		op := &ops[uploads+i]
+		if failed {
+			op.Err = errDeleteSkipped
+			continue
+		}
+		if op.Err = f.DeleteObject(ctx, bucket, op.Key); op.Err != nil {
+			errs = append(errs, op.Err)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// snapshot returns the objects under prefix keyed by key.
+func (f *S3) snapshot(op, bucket, prefix string) (map[string]*object, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.buckets[bucket]
+	if !ok {
+		return nil, fail(op, bucket, prefix, errNoSuchBucket)
+	}
+	objects := map[string]*object{}
+	for key, obj := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects[key] = obj
+		}
+	}
+	return objects, nil
+}
+
+// fileMatches reports whether the regular file at path has the content identified by etag.
+func fileMatches(path, etag string) (bool, error) {
+	r, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer r.Close() //nolint:all
+
+	info, err := r.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return false, err
+	}
+	return readerMatches(r, etag)
+}
+
+// readerMatches reports whether the content of r is identified by etag.
+func readerMatches(r io.Reader, etag string) (bool, error) {
+	h := md5.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return false, err
+	}
+	return hex.EncodeToString(h.Sum(nil)) == strings.Trim(etag, `"`), nil
+}
+
+// upToDate reports whether the regular file name below root has the stored bytes of obj.
+func upToDate(root *os.Root, name string, obj *object) (bool, error) {
+	r, info, err := localfs.OpenRegular(root, name)
+	if err != nil || r == nil {
+		return false, err
+	}
+	defer r.Close() //nolint:all
+
+	if info.Size() != int64(len(obj.data)) {
+		return false, nil
+	}
+	return readerMatches(r, obj.etag)
+}
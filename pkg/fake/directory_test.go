@@ -0,0 +1,199 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("directory transfers", func() {
+	var (
+		ctx context.Context
+		sut *S3
+		dir string
+		now time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Now().Add(-time.Hour).Truncate(time.Second)
+		sut = New(func(o *Options) {
+			o.Clock = func() time.Time { return now }
+		})
+		Expect(sut.CreateBucket(ctx, "bucket-a")).To(Succeed())
+		dir = GinkgoT().TempDir()
+	})
+
+	write := func(rel, data string) {
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		Expect(os.MkdirAll(filepath.Dir(p), 0o755)).To(Succeed())
+		Expect(os.WriteFile(p, []byte(data), 0o644)).To(Succeed())
+	}
+
+	list := func(prefix string) []string {
+		keys, err := sut.ListObject(ctx, "bucket-a", prefix)
+		Expect(err).NotTo(HaveOccurred())
+		return keys
+	}
+
+	It("uploads a directory with filters", func() {
+		write("a.txt", "a")
+		write("sub/b.txt", "bb")
+		write("sub/c.log", "c")
+
+		summary, err := sut.UploadDirectory(ctx, dir, "bucket-a", "site", func(o *types.UploadDirectoryOptions) {
+			o.Exclude = []string{"*.log"}
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summary.Bytes).To(Equal(int64(3)))
+		Expect(summary.Skipped).To(HaveLen(1))
+		Expect(list("")).To(Equal([]string{"site/a.txt", "site/sub/b.txt"}))
+	})
+
+	It("reports files that fail to upload", func() {
+		write("a.txt", "a")
+		write("b.txt", "b")
+		sut.InjectFault(FailKey("b.txt", errors.New("boom")))
+
+		summary, err := sut.UploadDirectory(ctx, dir, "bucket-a", "")
+		Expect(err).To(MatchError(ContainSubstring("boom")))
+		Expect(summary.Transferred).To(HaveLen(1))
+		Expect(summary.Failed).To(HaveLen(1))
+		Expect(summary.Failed[0].Key).To(Equal("b.txt"))
+	})
+
+	It("downloads a prefix and skips files already up to date", func() {
+		Expect(sut.PutObject(ctx, "bucket-a", "site/a.txt", strings.NewReader("a"))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "site/sub/b.txt", strings.NewReader("bb"))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "site/sub/", strings.NewReader(""))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "site/../escape", strings.NewReader("x"))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "site2/c.txt", strings.NewReader("c"))).To(Succeed())
+
+		summary, err := sut.DownloadPrefix(ctx, "bucket-a", "site", dir)
+		Expect(err).To(MatchError(ContainSubstring("parent directory reference")))
+		Expect(summary.Transferred).To(HaveLen(2))
+		Expect(summary.Failed).To(HaveLen(1))
+
+		data, err := os.ReadFile(filepath.Join(dir, "sub", "b.txt"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("bb"))
+		Expect(filepath.Join(dir, "2")).NotTo(BeAnExistingFile())
+		info, err := os.Stat(filepath.Join(dir, "a.txt"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.ModTime()).To(BeTemporally("==", now))
+
+		Expect(sut.DeleteObject(ctx, "bucket-a", "site/../escape")).To(Succeed())
+		summary, err = sut.DownloadPrefix(ctx, "bucket-a", "site/", dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summary.Skipped).To(HaveLen(2))
+	})
+
+	It("does not download through links leading outside the directory", func() {
+		outside := GinkgoT().TempDir()
+		Expect(os.Symlink(outside, filepath.Join(dir, "sub"))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "sub/a.txt", strings.NewReader("a"))).To(Succeed())
+
+		summary, err := sut.DownloadPrefix(ctx, "bucket-a", "", dir)
+		Expect(err).To(HaveOccurred())
+		Expect(summary.Failed).To(HaveLen(1))
+		Expect(os.ReadDir(outside)).To(BeEmpty())
+	})
+
+	It("syncs changes and deletes objects with no local file", func() {
+		write("a.txt", "a")
+		write("b.txt", "b")
+		Expect(sut.PutObject(ctx, "bucket-a", "site/b.txt", strings.NewReader("b"))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "site/old.txt", strings.NewReader("old"))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "site/keep.log", strings.NewReader("log"))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "site10/other", strings.NewReader("x"))).To(Succeed())
+		// Comparing checksums leaves b.txt unchanged although the local file is newer than its object.
+		opts := func(o *types.SyncOptions) {
+			o.Delete = true
+			o.Checksum = true
+			o.Exclude = []string{"*.log"}
+		}
+
+		plan, err := sut.Sync(ctx, dir, "bucket-a", "site", func(o *types.SyncOptions) {
+			opts(o)
+			o.DryRun = true
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Operations).To(HaveLen(2))
+		Expect(plan.Operations[0].String()).To(Equal("upload site/a.txt (new)"))
+		Expect(plan.Operations[1].Key).To(Equal("site/old.txt"))
+		Expect(plan.Unchanged).To(Equal(1))
+		Expect(list("site/")).To(HaveLen(3))
+
+		result, err := sut.Sync(ctx, dir, "bucket-a", "site", opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Operations).To(HaveLen(2))
+		Expect(list("")).To(Equal([]string{"site/a.txt", "site/b.txt", "site/keep.log", "site10/other"}))
+	})
+
+	It("leaves compressed uploads of unchanged files alone", func() {
+		write("a.txt", strings.Repeat("a", 100))
+		earlier := now.Add(-time.Hour)
+		Expect(os.Chtimes(filepath.Join(dir, "a.txt"), earlier, earlier)).To(Succeed())
+		opts := func(o *types.SyncOptions) {
+			o.ObjectOptions = append(o.ObjectOptions, func(o *types.PutObjectOptions) { o.Compression = types.CompressionGzip })
+		}
+
+		result, err := sut.Sync(ctx, dir, "bucket-a", "", opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Operations).To(HaveLen(1))
+
+		result, err = sut.Sync(ctx, dir, "bucket-a", "", opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Operations).To(BeEmpty())
+		Expect(result.Unchanged).To(Equal(1))
+	})
+
+	It("keeps objects below a directory that could not be read", func() {
+		write("a.txt", "a")
+		Expect(os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "gone"))).To(Succeed())
+		Expect(sut.PutObject(ctx, "bucket-a", "gone/b.txt", strings.NewReader("b"))).To(Succeed())
+
+		result, err := sut.Sync(ctx, dir, "bucket-a", "", func(o *types.SyncOptions) {
+			o.Delete = true
+			o.FollowSymlinks = true
+		})
+		Expect(err).To(MatchError(os.ErrNotExist))
+		Expect(result.Operations).To(HaveLen(1))
+		Expect(list("")).To(Equal([]string{"a.txt", "gone/b.txt"}))
+	})
+
+	It("skips deletions when an upload fails", func() {
+		write("a.txt", "a")
+		Expect(sut.PutObject(ctx, "bucket-a", "old.txt", strings.NewReader("old"))).To(Succeed())
+		sut.InjectFault(FailOperation("PutObject", errors.New("boom"), 0))
+
+		result, err := sut.Sync(ctx, dir, "bucket-a", "", func(o *types.SyncOptions) { o.Delete = true })
+		Expect(err).To(MatchError(ContainSubstring("boom")))
+		Expect(result.Operations[1].Err).To(MatchError(errDeleteSkipped))
+		Expect(list("")).To(Equal([]string{"old.txt"}))
+	})
+})
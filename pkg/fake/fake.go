@@ -0,0 +1,282 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides a stateful in-memory implementation of util.S3Interface for tests.
+//
+// Unlike the mock in pkg/mock, the fake keeps real buckets and objects, so code under test can be
+// exercised end to end without scripting each call. Failures are reported as *simple_s3.Error values
+// matching the same sentinel errors as the real client, and faults can be injected into any
+// operation.
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+
+	simple_s3 "github.com/drewbernetes/simple-s3"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+	"github.com/drewbernetes/simple-s3/pkg/util"
+)
+
+var _ util.S3Interface = (*S3)(nil)
+
+// Fault decides whether an operation fails. It is called with the name of the S3Interface method
+// and the bucket and key it targets before the operation takes effect, and a non-nil error fails
+// the call. Errors implementing smithy.APIError, such as those from APIError, are classified like
+// service errors.
+type Fault func(op, bucket, key string) error
+
+// Options configures the fake.
+type Options struct {
+	// Clock returns the time recorded for created buckets and written objects. Nil uses time.Now.
+	Clock func() time.Time
+}
+
+// S3 is an in-memory S3 store. It is safe for concurrent use.
+type S3 struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	faults  []Fault
+	clock   func() time.Time
+}
+
+type bucket struct {
+	created time.Time
+	objects map[string]*object
+}
+
+type object struct {
+	data            []byte
+	etag            string
+	modified        time.Time
+	contentType     string
+	contentEncoding string
+	metadata        map[string]string
+}
+
+// New creates an empty fake.
+func New(optFns ...func(*Options)) *S3 {
+	o := Options{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.Clock == nil {
+		o.Clock = time.Now
+	}
+	return &S3{buckets: map[string]*bucket{}, clock: o.Clock}
+}
+
+// InjectFault adds a fault consulted by every subsequent operation. Faults are consulted in the
+// order they were added and the first error is returned.
+func (f *S3) InjectFault(fault Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = append(f.faults, fault)
+}
+
+// ClearFaults removes every injected fault.
+func (f *S3) ClearFaults() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = nil
+}
+
+// FailOperation returns a fault failing the first times calls of op with err, or every call when
+// times is zero or negative. An empty op matches every operation.
+func FailOperation(op string, err error, times int) Fault {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	return func(o, _, _ string) error {
+		if op != "" && o != op {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if times > 0 && calls > times {
+			return nil
+		}
+		return err
+	}
+}
+
+// FailKey returns a fault failing every operation on key with err.
+func FailKey(key string, err error) Fault {
+	return func(_, _, k string) error {
+		if k == key {
+			return err
+		}
+		return nil
+	}
+}
+
+// APIError returns an error carrying a service error code and HTTP status code, such as
+// APIError("SlowDown", http.StatusServiceUnavailable), for use with faults.
+func APIError(code string, statusCode int) error {
+	return &apiError{code: code, status: statusCode}
+}
+
+type apiError struct {
+	code   string
+	status int
+}
+
+func (e *apiError) Error() string {
+	return "api error " + e.code + ": " + http.StatusText(e.status)
+}
+
+func (e *apiError) ErrorCode() string             { return e.code }
+func (e *apiError) ErrorMessage() string          { return http.StatusText(e.status) }
+func (e *apiError) ErrorFault() smithy.ErrorFault { return smithy.FaultUnknown }
+func (e *apiError) HTTPStatusCode() int           { return e.status }
+
+// fail wraps err in a *simple_s3.Error as the real client does. It returns nil if err is nil.
+func fail(op, bucket, key string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *simple_s3.Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	e = &simple_s3.Error{Op: op, Bucket: bucket, Key: key, Err: err}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		e.Code = apiErr.ErrorCode()
+	}
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		e.StatusCode = statusErr.HTTPStatusCode()
+	}
+	return e
+}
+
+// Errors returned for missing and conflicting resources, using the codes the service returns.
+var (
+	errNoSuchBucket       = APIError("NoSuchBucket", http.StatusNotFound)
+	errNoSuchKey          = APIError("NoSuchKey", http.StatusNotFound)
+	errNotFound           = APIError("NotFound", http.StatusNotFound)
+	errBucketExists       = APIError("BucketAlreadyOwnedByYou", http.StatusConflict)
+	errPreconditionFailed = APIError("PreconditionFailed", http.StatusPreconditionFailed)
+	errNotModified        = APIError("NotModified", http.StatusNotModified)
+	errInvalidRange       = APIError("InvalidRange", http.StatusRequestedRangeNotSatisfiable)
+)
+
+// begin checks for cancellation and injected faults before an operation. It must be called without
+// f.mu held.
+func (f *S3) begin(ctx context.Context, op, bucket, key string) error {
+	if err := ctx.Err(); err != nil {
+		return fail(op, bucket, key, err)
+	}
+
+	f.mu.Lock()
+	faults := append([]Fault(nil), f.faults...)
+	f.mu.Unlock()
+	for _, fault := range faults {
+		if err := fault(op, bucket, key); err != nil {
+			return fail(op, bucket, key, err)
+		}
+	}
+	return nil
+}
+
+// CreateBucket creates a bucket.
+func (f *S3) CreateBucket(ctx context.Context, name string) error {
+	if err := f.begin(ctx, "CreateBucket", name, ""); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.buckets[name]; ok {
+		return fail("CreateBucket", name, "", errBucketExists)
+	}
+	f.buckets[name] = &bucket{created: f.clock(), objects: map[string]*object{}}
+	return nil
+}
+
+// ListBuckets lists the buckets whose names start with prefix, ordered by name.
+func (f *S3) ListBuckets(ctx context.Context, prefix string) (*s3.ListBucketsOutput, error) {
+	if err := f.begin(ctx, "ListBuckets", "", ""); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := &s3.ListBucketsOutput{Prefix: aws.String(prefix)}
+	for name, b := range f.buckets {
+		if strings.HasPrefix(name, prefix) {
+			out.Buckets = append(out.Buckets, s3types.Bucket{Name: aws.String(name), CreationDate: aws.Time(b.created)})
+		}
+	}
+	sort.Slice(out.Buckets, func(i, j int) bool {
+		return aws.ToString(out.Buckets[i].Name) < aws.ToString(out.Buckets[j].Name)
+	})
+	return out, nil
+}
+
+// DeleteBucket deletes a bucket and the objects it contains. Deleting a missing bucket succeeds.
+func (f *S3) DeleteBucket(ctx context.Context, name string) error {
+	if err := f.begin(ctx, "DeleteBucket", name, ""); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.buckets, name)
+	return nil
+}
+
+// ListMultipartUploads lists incomplete multipart uploads. Uploads to the fake complete
+// atomically, so there are none.
+func (f *S3) ListMultipartUploads(ctx context.Context, bucket, prefix string) ([]types.MultipartUpload, error) {
+	if err := f.begin(ctx, "ListMultipartUploads", bucket, prefix); err != nil {
+		return nil, err
+	}
+	return nil, f.checkBucket("ListMultipartUploads", bucket, prefix)
+}
+
+// AbortStaleMultipartUploads aborts stale multipart uploads. Uploads to the fake complete
+// atomically, so there are none.
+func (f *S3) AbortStaleMultipartUploads(ctx context.Context, bucket string, _ time.Duration) ([]types.MultipartUpload, error) {
+	if err := f.begin(ctx, "AbortStaleMultipartUploads", bucket, ""); err != nil {
+		return nil, err
+	}
+	return nil, f.checkBucket("AbortStaleMultipartUploads", bucket, "")
+}
+
+// checkBucket returns an error matching ErrBucketNotFound if the bucket does not exist.
+func (f *S3) checkBucket(op, name, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.buckets[name]; !ok {
+		return fail(op, name, key, errNoSuchBucket)
+	}
+	return nil
+}
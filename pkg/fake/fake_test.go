@@ -0,0 +1,317 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	simple_s3 "github.com/drewbernetes/simple-s3"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("S3", func() {
+	var (
+		ctx context.Context
+		sut *S3
+		now time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		sut = New(func(o *Options) {
+			o.Clock = func() time.Time { return now }
+		})
+		Expect(sut.CreateBucket(ctx, "bucket-a")).To(Succeed())
+	})
+
+	put := func(key, data string, optFns ...func(*types.PutObjectOptions)) error {
+		return sut.PutObject(ctx, "bucket-a", key, strings.NewReader(data), optFns...)
+	}
+
+	Describe("buckets", func() {
+		It("creates, lists and deletes buckets", func() {
+			Expect(sut.CreateBucket(ctx, "bucket-b")).To(Succeed())
+			Expect(sut.CreateBucket(ctx, "other")).To(Succeed())
+
+			out, err := sut.ListBuckets(ctx, "bucket-")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Buckets).To(HaveLen(2))
+			Expect(aws.ToString(out.Buckets[0].Name)).To(Equal("bucket-a"))
+			Expect(aws.ToString(out.Buckets[1].Name)).To(Equal("bucket-b"))
+			Expect(aws.ToTime(out.Buckets[0].CreationDate)).To(Equal(now))
+
+			Expect(put("key-a", "payload")).To(Succeed())
+			Expect(sut.DeleteBucket(ctx, "bucket-a")).To(Succeed())
+			_, err = sut.ListObject(ctx, "bucket-a", "")
+			Expect(err).To(MatchError(simple_s3.ErrBucketNotFound))
+		})
+
+		It("matches the real client's bucket errors", func() {
+			err := sut.CreateBucket(ctx, "bucket-a")
+			Expect(err).To(MatchError(simple_s3.ErrBucketAlreadyExists))
+			var e *simple_s3.Error
+			Expect(errors.As(err, &e)).To(BeTrue())
+			Expect(e.Op).To(Equal("CreateBucket"))
+			Expect(e.Bucket).To(Equal("bucket-a"))
+			Expect(e.StatusCode).To(Equal(http.StatusConflict))
+
+			Expect(sut.DeleteBucket(ctx, "missing")).To(Succeed())
+			Expect(sut.PutObject(ctx, "missing", "key-a", strings.NewReader(""))).To(MatchError(simple_s3.ErrBucketNotFound))
+			_, err = sut.ListMultipartUploads(ctx, "missing", "")
+			Expect(err).To(MatchError(simple_s3.ErrBucketNotFound))
+		})
+	})
+
+	Describe("objects", func() {
+		It("stores and returns objects", func() {
+			Expect(put("dir/a.json", "{}")).To(Succeed())
+
+			data, err := sut.FetchObject(ctx, "dir/a.json", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("{}"))
+
+			var buf bytes.Buffer
+			n, err := sut.DownloadObject(ctx, "bucket-a", "dir/a.json", &buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+			Expect(buf.String()).To(Equal("{}"))
+
+			info, err := sut.StatObject(ctx, "bucket-a", "dir/a.json")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Size).To(Equal(int64(2)))
+			Expect(info.ETag).To(Equal("99914b932bd37a50b983c5e7c90ae93b"))
+			Expect(info.ContentType).To(Equal("application/json"))
+			Expect(info.LastModified).To(Equal(now))
+		})
+
+		It("reports missing objects like the real client", func() {
+			_, err := sut.FetchObject(ctx, "missing", "bucket-a")
+			Expect(err).To(MatchError(simple_s3.ErrObjectNotFound))
+			_, err = sut.StatObject(ctx, "bucket-a", "missing")
+			Expect(err).To(MatchError(simple_s3.ErrObjectNotFound))
+			Expect(sut.DeleteObject(ctx, "bucket-a", "missing")).To(Succeed())
+		})
+
+		It("lists keys by prefix in order", func() {
+			for _, key := range []string{"b/2", "a/1", "b/1", "c"} {
+				Expect(put(key, "x")).To(Succeed())
+			}
+
+			keys, err := sut.ListObject(ctx, "bucket-a", "b/")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"b/1", "b/2"}))
+
+			Expect(sut.DeleteObject(ctx, "bucket-a", "b/1")).To(Succeed())
+			keys, err = sut.ListObject(ctx, "bucket-a", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"a/1", "b/2", "c"}))
+		})
+
+		It("reports whether a bucket is empty", func() {
+			empty, err := sut.IsBucketEmpty(ctx, "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(empty).To(BeTrue())
+
+			Expect(put("key-a", "x")).To(Succeed())
+			empty, err = sut.IsBucketEmpty(ctx, "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(empty).To(BeFalse())
+
+			_, err = sut.IsBucketEmpty(ctx, "missing")
+			Expect(err).To(MatchError(simple_s3.ErrBucketNotFound))
+		})
+
+		It("applies put conditions", func() {
+			Expect(put("key-a", "v1", func(o *types.PutObjectOptions) { o.IfNoneMatch = types.ETagAny })).To(Succeed())
+			Expect(put("key-a", "v2", func(o *types.PutObjectOptions) { o.IfNoneMatch = types.ETagAny })).To(MatchError(simple_s3.ErrPreconditionFailed))
+
+			info, err := sut.StatObject(ctx, "bucket-a", "key-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(put("key-a", "v3", func(o *types.PutObjectOptions) { o.IfMatch = "\"wrong\"" })).To(MatchError(simple_s3.ErrPreconditionFailed))
+			Expect(put("key-a", "v3", func(o *types.PutObjectOptions) { o.IfMatch = info.ETag })).To(Succeed())
+			Expect(put("missing", "v1", func(o *types.PutObjectOptions) { o.IfMatch = info.ETag })).To(MatchError(simple_s3.ErrObjectNotFound))
+		})
+
+		It("applies fetch and delete conditions", func() {
+			Expect(put("key-a", "v1")).To(Succeed())
+			info, err := sut.StatObject(ctx, "bucket-a", "key-a")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sut.FetchObject(ctx, "key-a", "bucket-a", func(o *types.FetchObjectOptions) { o.IfNoneMatch = `"` + info.ETag + `"` })
+			Expect(err).To(MatchError(simple_s3.ErrNotModified))
+			_, err = sut.FetchObject(ctx, "key-a", "bucket-a", func(o *types.FetchObjectOptions) { o.IfModifiedSince = now })
+			Expect(err).To(MatchError(simple_s3.ErrNotModified))
+			_, err = sut.FetchObject(ctx, "key-a", "bucket-a", func(o *types.FetchObjectOptions) { o.IfUnmodifiedSince = now.Add(-time.Second) })
+			Expect(err).To(MatchError(simple_s3.ErrPreconditionFailed))
+			_, err = sut.FetchObject(ctx, "key-a", "bucket-a", func(o *types.FetchObjectOptions) { o.IfMatch = info.ETag })
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sut.DeleteObject(ctx, "bucket-a", "key-a", func(o *types.DeleteObjectOptions) { o.IfMatch = "other" })).To(MatchError(simple_s3.ErrPreconditionFailed))
+			Expect(sut.DeleteObject(ctx, "bucket-a", "key-a", func(o *types.DeleteObjectOptions) { o.IfMatch = info.ETag })).To(Succeed())
+		})
+
+		It("returns ranges of the stored bytes", func() {
+			Expect(put("key-a", "0123456789")).To(Succeed())
+
+			for _, tc := range []struct {
+				offset, length int64
+				expected       string
+			}{{2, 3, "234"}, {8, 0, "89"}, {8, 5, "89"}, {-3, 0, "789"}, {-20, 0, "0123456789"}} {
+				data, err := sut.FetchRange(ctx, "bucket-a", "key-a", tc.offset, tc.length)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal(tc.expected), fmt.Sprintf("offset %d length %d", tc.offset, tc.length))
+			}
+			_, err := sut.FetchRange(ctx, "bucket-a", "key-a", 10, 1)
+			Expect(err).To(MatchError(simple_s3.ErrInvalidRange))
+		})
+
+		DescribeTable("compresses and decodes objects",
+			func(codec types.Compression) {
+				payload := strings.Repeat("compressible ", 100)
+				Expect(put("key-a", payload, func(o *types.PutObjectOptions) { o.Compression = codec })).To(Succeed())
+
+				info, err := sut.StatObject(ctx, "bucket-a", "key-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.ContentEncoding).To(Equal(string(codec)))
+				Expect(info.Size).To(BeNumerically("<", len(payload)))
+				Expect(info.Metadata).To(HaveKeyWithValue("original-size", "1300"))
+
+				data, err := sut.FetchObject(ctx, "key-a", "bucket-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal(payload))
+
+				raw, err := sut.FetchObject(ctx, "key-a", "bucket-a", func(o *types.FetchObjectOptions) { o.DisableDecompression = true })
+				Expect(err).NotTo(HaveOccurred())
+				Expect(int64(len(raw))).To(Equal(info.Size))
+			},
+			Entry("gzip", types.CompressionGzip),
+			Entry("zstd", types.CompressionZstd),
+		)
+
+		It("copies objects between buckets", func() {
+			Expect(sut.CreateBucket(ctx, "bucket-b")).To(Succeed())
+			Expect(put("key-a", "payload", func(o *types.PutObjectOptions) { o.ContentType = "text/csv" })).To(Succeed())
+			now = now.Add(time.Hour)
+
+			Expect(sut.CopyObject(ctx, "bucket-a", "key-a", "bucket-b", "key-b")).To(Succeed())
+			info, err := sut.StatObject(ctx, "bucket-b", "key-b")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.ContentType).To(Equal("text/csv"))
+			Expect(info.LastModified).To(Equal(now))
+
+			Expect(sut.CopyObject(ctx, "bucket-a", "missing", "bucket-b", "key-b")).To(MatchError(simple_s3.ErrObjectNotFound))
+			Expect(sut.CopyObject(ctx, "bucket-a", "key-a", "missing", "key-b")).To(MatchError(simple_s3.ErrBucketNotFound))
+		})
+
+		It("presigns URLs", func() {
+			raw, err := sut.PresignObject(ctx, "bucket-a", "dir/key-a", func(o *types.PresignOptions) {
+				o.Method = "put"
+				o.Expires = time.Hour
+			})
+			Expect(err).NotTo(HaveOccurred())
+			u, err := url.Parse(raw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(u.Path).To(Equal("/bucket-a/dir/key-a"))
+			Expect(u.Query().Get("X-Amz-Expires")).To(Equal("3600"))
+			Expect(u.Query().Get("X-Fake-Method")).To(Equal("PUT"))
+
+			_, err = sut.PresignObject(ctx, "bucket-a", "key-a", func(o *types.PresignOptions) { o.Method = "DELETE" })
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("is safe for concurrent use", func() {
+			var wg sync.WaitGroup
+			for i := range 50 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					Expect(put(fmt.Sprintf("key-%02d", i), "x")).To(Succeed())
+					_, err := sut.ListObject(ctx, "bucket-a", "")
+					Expect(err).NotTo(HaveOccurred())
+				}()
+			}
+			wg.Wait()
+
+			keys, err := sut.ListObject(ctx, "bucket-a", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(50))
+		})
+	})
+
+	Describe("faults", func() {
+		It("fails an operation a limited number of times", func() {
+			sut.InjectFault(FailOperation("PutObject", APIError("SlowDown", http.StatusServiceUnavailable), 2))
+
+			err := put("key-a", "v1")
+			Expect(err).To(MatchError(simple_s3.ErrThrottled))
+			var e *simple_s3.Error
+			Expect(errors.As(err, &e)).To(BeTrue())
+			Expect(e.Code).To(Equal("SlowDown"))
+			Expect(e.StatusCode).To(Equal(http.StatusServiceUnavailable))
+
+			Expect(put("key-a", "v1")).To(MatchError(simple_s3.ErrThrottled))
+			Expect(put("key-a", "v1")).To(Succeed())
+			Expect(sut.DeleteObject(ctx, "bucket-a", "key-a")).To(Succeed())
+		})
+
+		It("fails operations on a key until cleared", func() {
+			boom := errors.New("connection reset")
+			sut.InjectFault(FailKey("key-b", boom))
+
+			Expect(put("key-a", "v1")).To(Succeed())
+			Expect(put("key-b", "v1")).To(MatchError(boom))
+			_, err := sut.FetchObject(ctx, "key-b", "bucket-a")
+			Expect(err).To(MatchError(boom))
+
+			sut.ClearFaults()
+			Expect(put("key-b", "v1")).To(Succeed())
+		})
+
+		It("leaves state unchanged when an operation fails", func() {
+			Expect(put("key-a", "v1")).To(Succeed())
+			sut.InjectFault(FailOperation("", errors.New("boom"), 0))
+
+			Expect(sut.DeleteObject(ctx, "bucket-a", "key-a")).To(HaveOccurred())
+			sut.ClearFaults()
+			data, err := sut.FetchObject(ctx, "key-a", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("v1"))
+		})
+
+		It("honours cancellation", func() {
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			err := sut.PutObject(cancelled, "bucket-a", "key-a", strings.NewReader("v1"))
+			Expect(err).To(MatchError(context.Canceled))
+		})
+	})
+})
@@ -0,0 +1,378 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewbernetes/simple-s3/internal/compression"
+	"github.com/drewbernetes/simple-s3/internal/contenttype"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// PutObject stores the content of body. The content type, conditions and compression options are
+// honoured; checksum, multipart, resumable and progress options have no effect.
+func (f *S3) PutObject(ctx context.Context, bucket, key string, body io.ReadSeeker, optFns ...func(*types.PutObjectOptions)) error {
+	return f.put(ctx, "PutObject", bucket, key, body, optFns)
+}
+
+// PutObjectStream stores the content read from body, as PutObject does.
+func (f *S3) PutObjectStream(ctx context.Context, bucket, key string, body io.Reader, optFns ...func(*types.PutObjectOptions)) error {
+	return f.put(ctx, "PutObjectStream", bucket, key, body, optFns)
+}
+
+func (f *S3) put(ctx context.Context, op, bucket, key string, body io.Reader, optFns []func(*types.PutObjectOptions)) error {
+	if err := f.begin(ctx, op, bucket, key); err != nil {
+		return err
+	}
+	o := types.PutObjectOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fail(op, bucket, key, err)
+	}
+	obj := &object{contentType: o.ContentType}
+	if obj.contentType == "" {
+		obj.contentType = contentType(key, data)
+	}
+	if o.Compression != "" {
+		obj.metadata = map[string]string{compression.OriginalSizeMetadata: strconv.Itoa(len(data))}
+		obj.contentEncoding = string(o.Compression)
+		if data, err = compress(o.Compression, data); err != nil {
+			return fail(op, bucket, key, err)
+		}
+	}
+	obj.data = data
+	obj.etag = etag(data)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.buckets[bucket]
+	if !ok {
+		return fail(op, bucket, key, errNoSuchBucket)
+	}
+	current := b.objects[key]
+	switch {
+	case o.IfMatch != "" && current == nil:
+		return fail(op, bucket, key, errNoSuchKey)
+	case o.IfMatch != "" && !etagMatches(o.IfMatch, current.etag):
+		return fail(op, bucket, key, errPreconditionFailed)
+	case o.IfNoneMatch != "" && current != nil && etagMatches(o.IfNoneMatch, current.etag):
+		return fail(op, bucket, key, errPreconditionFailed)
+	}
+	obj.modified = f.clock()
+	b.objects[key] = obj
+	return nil
+}
+
+// FetchObject returns the content of an object, decompressing gzip and zstd encoded objects unless
+// DisableDecompression is set. Note that the key precedes the bucket, as in the real client.
+func (f *S3) FetchObject(ctx context.Context, fileName, bucket string, optFns ...func(*types.FetchObjectOptions)) ([]byte, error) {
+	return f.fetch(ctx, "FetchObject", bucket, fileName, optFns)
+}
+
+// DownloadObject writes the content of an object to w, as FetchObject returns it.
+func (f *S3) DownloadObject(ctx context.Context, bucket, key string, w io.Writer, optFns ...func(*types.FetchObjectOptions)) (int64, error) {
+	data, err := f.fetch(ctx, "DownloadObject", bucket, key, optFns)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), fail("DownloadObject", bucket, key, err)
+}
+
+func (f *S3) fetch(ctx context.Context, op, bucket, key string, optFns []func(*types.FetchObjectOptions)) ([]byte, error) {
+	if err := f.begin(ctx, op, bucket, key); err != nil {
+		return nil, err
+	}
+	o := types.FetchObjectOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	obj, err := f.object(op, bucket, key, errNoSuchKey)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case o.IfMatch != "" && !etagMatches(o.IfMatch, obj.etag):
+		return nil, fail(op, bucket, key, errPreconditionFailed)
+	case o.IfMatch == "" && !o.IfUnmodifiedSince.IsZero() && obj.modified.After(o.IfUnmodifiedSince):
+		return nil, fail(op, bucket, key, errPreconditionFailed)
+	case o.IfNoneMatch != "" && etagMatches(o.IfNoneMatch, obj.etag):
+		return nil, fail(op, bucket, key, errNotModified)
+	case o.IfNoneMatch == "" && !o.IfModifiedSince.IsZero() && !obj.modified.After(o.IfModifiedSince):
+		return nil, fail(op, bucket, key, errNotModified)
+	}
+
+	data := obj.data
+	// Other encodings are returned as stored, as the real client does.
+	if codec := compression.Decoding(obj.contentEncoding); codec != "" && !o.DisableDecompression {
+		if data, err = decompress(codec, data); err != nil {
+			return nil, fail(op, bucket, key, err)
+		}
+	}
+	return bytes.Clone(data), nil
+}
+
+// FetchRange returns part of the stored bytes of an object, following the real client's offset and
+// length semantics.
+func (f *S3) FetchRange(ctx context.Context, bucket, key string, offset, length int64) ([]byte, error) {
+	if err := f.begin(ctx, "FetchRange", bucket, key); err != nil {
+		return nil, err
+	}
+	obj, err := f.object("FetchRange", bucket, key, errNoSuchKey)
+	if err != nil {
+		return nil, err
+	}
+
+	size := int64(len(obj.data))
+	start, end := offset, size
+	switch {
+	case offset < 0:
+		start = max(size+offset, 0)
+	case offset >= size:
+		return nil, fail("FetchRange", bucket, key, errInvalidRange)
+	case length > 0:
+		end = min(offset+length, size)
+	}
+	return bytes.Clone(obj.data[start:end]), nil
+}
+
+// ListObject lists the keys starting with prefix in lexical order.
+func (f *S3) ListObject(ctx context.Context, bucket, prefix string) ([]string, error) {
+	if err := f.begin(ctx, "ListObject", bucket, prefix); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.buckets[bucket]
+	if !ok {
+		return nil, fail("ListObject", bucket, prefix, errNoSuchBucket)
+	}
+	keys := []string{}
+	for key := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// IsBucketEmpty reports whether the bucket holds no objects.
+func (f *S3) IsBucketEmpty(ctx context.Context, bucket string) (bool, error) {
+	if err := f.begin(ctx, "IsBucketEmpty", bucket, ""); err != nil {
+		return false, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.buckets[bucket]
+	if !ok {
+		return false, fail("IsBucketEmpty", bucket, "", errNoSuchBucket)
+	}
+	return len(b.objects) == 0, nil
+}
+
+// DeleteObject deletes an object. Deleting a missing key succeeds unless IfMatch is set.
+func (f *S3) DeleteObject(ctx context.Context, bucket, key string, optFns ...func(*types.DeleteObjectOptions)) error {
+	if err := f.begin(ctx, "DeleteObject", bucket, key); err != nil {
+		return err
+	}
+	o := types.DeleteObjectOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.buckets[bucket]
+	if !ok {
+		return fail("DeleteObject", bucket, key, errNoSuchBucket)
+	}
+	if o.IfMatch != "" {
+		current, ok := b.objects[key]
+		if !ok {
+			return fail("DeleteObject", bucket, key, errNoSuchKey)
+		}
+		if !etagMatches(o.IfMatch, current.etag) {
+			return fail("DeleteObject", bucket, key, errPreconditionFailed)
+		}
+	}
+	delete(b.objects, key)
+	return nil
+}
+
+// StatObject describes an object without returning its content.
+func (f *S3) StatObject(ctx context.Context, bucket, key string) (*types.ObjectInfo, error) {
+	if err := f.begin(ctx, "StatObject", bucket, key); err != nil {
+		return nil, err
+	}
+	// HEAD responses have no body, so a missing key is reported without the NoSuchKey code.
+	obj, err := f.object("StatObject", bucket, key, errNotFound)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ObjectInfo{
+		Bucket:          bucket,
+		Key:             key,
+		Size:            int64(len(obj.data)),
+		ETag:            strings.Trim(obj.etag, `"`),
+		LastModified:    obj.modified,
+		ContentType:     obj.contentType,
+		ContentEncoding: obj.contentEncoding,
+		Metadata:        maps.Clone(obj.metadata),
+	}, nil
+}
+
+// CopyObject copies an object with its content headers and metadata.
+func (f *S3) CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) error {
+	if err := f.begin(ctx, "CopyObject", dstBucket, dstKey); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	src, ok := f.buckets[srcBucket]
+	if !ok {
+		return fail("CopyObject", dstBucket, dstKey, errNoSuchBucket)
+	}
+	obj, ok := src.objects[srcKey]
+	if !ok {
+		return fail("CopyObject", dstBucket, dstKey, errNoSuchKey)
+	}
+	dst, ok := f.buckets[dstBucket]
+	if !ok {
+		return fail("CopyObject", dstBucket, dstKey, errNoSuchBucket)
+	}
+	cp := *obj
+	cp.modified = f.clock()
+	dst.objects[dstKey] = &cp
+	return nil
+}
+
+// PresignObject returns a URL on an unresolvable host recording the bucket, key, method and expiry.
+// The object does not need to exist.
+func (f *S3) PresignObject(ctx context.Context, bucket, key string, optFns ...func(*types.PresignOptions)) (string, error) {
+	if err := f.begin(ctx, "PresignObject", bucket, key); err != nil {
+		return "", err
+	}
+	o := types.PresignOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	method := strings.ToUpper(o.Method)
+	switch method {
+	case "":
+		method = http.MethodGet
+	case http.MethodGet, http.MethodPut:
+	default:
+		return "", fail("PresignObject", bucket, key, fmt.Errorf("cannot presign %s requests", method))
+	}
+	expires := o.Expires
+	if expires <= 0 {
+		expires = 15 * time.Minute
+	}
+
+	u := url.URL{
+		Scheme:   "https",
+		Host:     "s3.fake.invalid",
+		Path:     "/" + bucket + "/" + key,
+		RawQuery: url.Values{"X-Amz-Expires": {strconv.Itoa(int(expires.Seconds()))}, "X-Fake-Method": {method}}.Encode(),
+	}
+	return u.String(), nil
+}
+
+// object returns the stored object, or notFound if the key does not exist. Stored objects are never
+// modified, so the result may be read without the lock.
+func (f *S3) object(op, bucket, key string, notFound error) (*object, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.buckets[bucket]
+	if !ok {
+		return nil, fail(op, bucket, key, errNoSuchBucket)
+	}
+	obj, ok := b.objects[key]
+	if !ok {
+		return nil, fail(op, bucket, key, notFound)
+	}
+	return obj, nil
+}
+
+// etag returns the quoted MD5 ETag the service assigns to single-part uploads.
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// etagMatches reports whether the condition matches the ETag, ignoring quotes.
+func etagMatches(condition, etag string) bool {
+	return condition == types.ETagAny || strings.Trim(condition, `"`) == strings.Trim(etag, `"`)
+}
+
+// contentType derives a content type from the key's extension as the real client does without
+// client overrides, falling back to sniffing the data.
+func contentType(key string, data []byte) string {
+	if t := contenttype.ByExtension(key, nil); t != "" {
+		return t
+	}
+	return http.DetectContentType(data)
+}
+
+// compress returns data compressed with codec.
+func compress(codec types.Compression, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := compression.NewWriter(codec, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress returns data decompressed with codec.
+func decompress(codec types.Compression, data []byte) ([]byte, error) {
+	zr, err := compression.NewReader(codec, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close() //nolint:all
+	return io.ReadAll(zr)
+}
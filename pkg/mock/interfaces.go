@@ -13,8 +13,10 @@ import (
 	context "context"
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	s3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	types "github.com/drewbernetes/simple-s3/pkg/types"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,6 +44,35 @@ func (m *MockS3Interface) EXPECT() *MockS3InterfaceMockRecorder {
 	return m.recorder
 }
 
+// AbortStaleMultipartUploads mocks base method.
+func (m *MockS3Interface) AbortStaleMultipartUploads(arg0 context.Context, arg1 string, arg2 time.Duration) ([]types.MultipartUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortStaleMultipartUploads", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.MultipartUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbortStaleMultipartUploads indicates an expected call of AbortStaleMultipartUploads.
+func (mr *MockS3InterfaceMockRecorder) AbortStaleMultipartUploads(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortStaleMultipartUploads", reflect.TypeOf((*MockS3Interface)(nil).AbortStaleMultipartUploads), arg0, arg1, arg2)
+}
+
+// CopyObject mocks base method.
+func (m *MockS3Interface) CopyObject(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyObject", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyObject indicates an expected call of CopyObject.
+func (mr *MockS3InterfaceMockRecorder) CopyObject(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyObject", reflect.TypeOf((*MockS3Interface)(nil).CopyObject), arg0, arg1, arg2, arg3, arg4)
+}
+
 // CreateBucket mocks base method.
 func (m *MockS3Interface) CreateBucket(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -71,32 +102,112 @@ func (mr *MockS3InterfaceMockRecorder) DeleteBucket(arg0, arg1 any) *gomock.Call
 }
 
 // DeleteObject mocks base method.
-func (m *MockS3Interface) DeleteObject(arg0 context.Context, arg1, arg2 string) error {
+func (m *MockS3Interface) DeleteObject(arg0 context.Context, arg1, arg2 string, arg3 ...func(*types.DeleteObjectOptions)) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteObject", arg0, arg1, arg2)
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteObject", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteObject indicates an expected call of DeleteObject.
-func (mr *MockS3InterfaceMockRecorder) DeleteObject(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockS3InterfaceMockRecorder) DeleteObject(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObject", reflect.TypeOf((*MockS3Interface)(nil).DeleteObject), varargs...)
+}
+
+// DownloadObject mocks base method.
+func (m *MockS3Interface) DownloadObject(arg0 context.Context, arg1, arg2 string, arg3 io.Writer, arg4 ...func(*types.FetchObjectOptions)) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadObject", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadObject indicates an expected call of DownloadObject.
+func (mr *MockS3InterfaceMockRecorder) DownloadObject(arg0, arg1, arg2, arg3 any, arg4 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObject", reflect.TypeOf((*MockS3Interface)(nil).DeleteObject), arg0, arg1, arg2)
+	varargs := append([]any{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadObject", reflect.TypeOf((*MockS3Interface)(nil).DownloadObject), varargs...)
+}
+
+// DownloadPrefix mocks base method.
+func (m *MockS3Interface) DownloadPrefix(arg0 context.Context, arg1, arg2, arg3 string, arg4 ...func(*types.DownloadPrefixOptions)) (*types.TransferSummary, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadPrefix", varargs...)
+	ret0, _ := ret[0].(*types.TransferSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadPrefix indicates an expected call of DownloadPrefix.
+func (mr *MockS3InterfaceMockRecorder) DownloadPrefix(arg0, arg1, arg2, arg3 any, arg4 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadPrefix", reflect.TypeOf((*MockS3Interface)(nil).DownloadPrefix), varargs...)
 }
 
 // FetchObject mocks base method.
-func (m *MockS3Interface) FetchObject(arg0 context.Context, arg1, arg2 string) ([]byte, error) {
+func (m *MockS3Interface) FetchObject(arg0 context.Context, arg1, arg2 string, arg3 ...func(*types.FetchObjectOptions)) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchObject", arg0, arg1, arg2)
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchObject", varargs...)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchObject indicates an expected call of FetchObject.
-func (mr *MockS3InterfaceMockRecorder) FetchObject(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockS3InterfaceMockRecorder) FetchObject(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchObject", reflect.TypeOf((*MockS3Interface)(nil).FetchObject), varargs...)
+}
+
+// FetchRange mocks base method.
+func (m *MockS3Interface) FetchRange(arg0 context.Context, arg1, arg2 string, arg3, arg4 int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRange", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRange indicates an expected call of FetchRange.
+func (mr *MockS3InterfaceMockRecorder) FetchRange(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRange", reflect.TypeOf((*MockS3Interface)(nil).FetchRange), arg0, arg1, arg2, arg3, arg4)
+}
+
+// IsBucketEmpty mocks base method.
+func (m *MockS3Interface) IsBucketEmpty(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBucketEmpty", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBucketEmpty indicates an expected call of IsBucketEmpty.
+func (mr *MockS3InterfaceMockRecorder) IsBucketEmpty(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchObject", reflect.TypeOf((*MockS3Interface)(nil).FetchObject), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBucketEmpty", reflect.TypeOf((*MockS3Interface)(nil).IsBucketEmpty), arg0, arg1)
 }
 
 // ListBuckets mocks base method.
@@ -114,6 +225,21 @@ func (mr *MockS3InterfaceMockRecorder) ListBuckets(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBuckets", reflect.TypeOf((*MockS3Interface)(nil).ListBuckets), arg0, arg1)
 }
 
+// ListMultipartUploads mocks base method.
+func (m *MockS3Interface) ListMultipartUploads(arg0 context.Context, arg1, arg2 string) ([]types.MultipartUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMultipartUploads", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.MultipartUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMultipartUploads indicates an expected call of ListMultipartUploads.
+func (mr *MockS3InterfaceMockRecorder) ListMultipartUploads(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMultipartUploads", reflect.TypeOf((*MockS3Interface)(nil).ListMultipartUploads), arg0, arg1, arg2)
+}
+
 // ListObject mocks base method.
 func (m *MockS3Interface) ListObject(arg0 context.Context, arg1, arg2 string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -129,16 +255,115 @@ func (mr *MockS3InterfaceMockRecorder) ListObject(arg0, arg1, arg2 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObject", reflect.TypeOf((*MockS3Interface)(nil).ListObject), arg0, arg1, arg2)
 }
 
+// PresignObject mocks base method.
+func (m *MockS3Interface) PresignObject(arg0 context.Context, arg1, arg2 string, arg3 ...func(*types.PresignOptions)) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PresignObject", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignObject indicates an expected call of PresignObject.
+func (mr *MockS3InterfaceMockRecorder) PresignObject(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignObject", reflect.TypeOf((*MockS3Interface)(nil).PresignObject), varargs...)
+}
+
 // PutObject mocks base method.
-func (m *MockS3Interface) PutObject(arg0 context.Context, arg1, arg2 string, arg3 io.ReadSeeker) error {
+func (m *MockS3Interface) PutObject(arg0 context.Context, arg1, arg2 string, arg3 io.ReadSeeker, arg4 ...func(*types.PutObjectOptions)) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PutObject", arg0, arg1, arg2, arg3)
+	varargs := []any{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutObject", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PutObject indicates an expected call of PutObject.
-func (mr *MockS3InterfaceMockRecorder) PutObject(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockS3InterfaceMockRecorder) PutObject(arg0, arg1, arg2, arg3 any, arg4 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockS3Interface)(nil).PutObject), varargs...)
+}
+
+// PutObjectStream mocks base method.
+func (m *MockS3Interface) PutObjectStream(arg0 context.Context, arg1, arg2 string, arg3 io.Reader, arg4 ...func(*types.PutObjectOptions)) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutObjectStream", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutObjectStream indicates an expected call of PutObjectStream.
+func (mr *MockS3InterfaceMockRecorder) PutObjectStream(arg0, arg1, arg2, arg3 any, arg4 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObjectStream", reflect.TypeOf((*MockS3Interface)(nil).PutObjectStream), varargs...)
+}
+
+// StatObject mocks base method.
+func (m *MockS3Interface) StatObject(arg0 context.Context, arg1, arg2 string) (*types.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatObject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatObject indicates an expected call of StatObject.
+func (mr *MockS3InterfaceMockRecorder) StatObject(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatObject", reflect.TypeOf((*MockS3Interface)(nil).StatObject), arg0, arg1, arg2)
+}
+
+// Sync mocks base method.
+func (m *MockS3Interface) Sync(arg0 context.Context, arg1, arg2, arg3 string, arg4 ...func(*types.SyncOptions)) (*types.SyncResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Sync", varargs...)
+	ret0, _ := ret[0].(*types.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockS3InterfaceMockRecorder) Sync(arg0, arg1, arg2, arg3 any, arg4 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockS3Interface)(nil).Sync), varargs...)
+}
+
+// UploadDirectory mocks base method.
+func (m *MockS3Interface) UploadDirectory(arg0 context.Context, arg1, arg2, arg3 string, arg4 ...func(*types.UploadDirectoryOptions)) (*types.TransferSummary, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadDirectory", varargs...)
+	ret0, _ := ret[0].(*types.TransferSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadDirectory indicates an expected call of UploadDirectory.
+func (mr *MockS3InterfaceMockRecorder) UploadDirectory(arg0, arg1, arg2, arg3 any, arg4 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockS3Interface)(nil).PutObject), arg0, arg1, arg2, arg3)
+	varargs := append([]any{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadDirectory", reflect.TypeOf((*MockS3Interface)(nil).UploadDirectory), varargs...)
 }
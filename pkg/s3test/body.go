@@ -0,0 +1,229 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1" //nolint:gosec // SHA1 is one of the checksum algorithms S3 supports
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// checksumAlgorithms lists the supported checksum algorithms by the suffix of their header name.
+var checksumAlgorithms = []string{"crc32", "crc32c", "crc64nvme", "sha1", "sha256"}
+
+// payload is a request body with the checksum sent alongside it, if any.
+type payload struct {
+	data        []byte
+	checksumAlg string
+	checksum    string
+}
+
+// readPayload reads the request body, decoding aws-chunked bodies, and verifies it against any
+// Content-MD5 header and checksum sent in a header or trailer.
+func readPayload(r *request) (*payload, error) {
+	var (
+		data     []byte
+		trailers http.Header
+		err      error
+	)
+	if isChunked(r.Header) {
+		data, trailers, err = decodeChunked(r.Body)
+	} else {
+		data, err = io.ReadAll(r.Body)
+	}
+	if err != nil {
+		return nil, &s3Error{http.StatusBadRequest, "IncompleteBody", err.Error()}
+	}
+
+	if v := r.Header.Get("Content-MD5"); v != "" {
+		want, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || len(want) != md5.Size {
+			return nil, errInvalidDigest
+		}
+		if sum := md5.Sum(data); !bytes.Equal(sum[:], want) {
+			return nil, errBadDigest
+		}
+	}
+
+	p := &payload{data: data}
+	for _, alg := range checksumAlgorithms {
+		name := "x-amz-checksum-" + alg
+		v := r.Header.Get(name)
+		if v == "" {
+			v = trailers.Get(name)
+		}
+		if v == "" {
+			continue
+		}
+		if checksum(alg, data) != v {
+			return nil, errBadDigest
+		}
+		p.checksumAlg, p.checksum = alg, v
+		break
+	}
+	if p.checksumAlg == "" {
+		// The algorithm may be named without a value, asking the service to compute it.
+		if alg := strings.ToLower(r.Header.Get("x-amz-sdk-checksum-algorithm")); hasAlgorithm(alg) {
+			p.checksumAlg, p.checksum = alg, checksum(alg, data)
+		}
+	}
+	return p, nil
+}
+
+// isChunked reports whether the body uses the aws-chunked encoding.
+func isChunked(h http.Header) bool {
+	return strings.HasPrefix(h.Get("x-amz-content-sha256"), "STREAMING-") ||
+		strings.Contains(h.Get("Content-Encoding"), "aws-chunked")
+}
+
+// contentEncoding returns the Content-Encoding with the aws-chunked transport encoding removed.
+func contentEncoding(h http.Header) string {
+	var kept []string
+	for _, v := range strings.Split(h.Get("Content-Encoding"), ",") {
+		if v = strings.TrimSpace(v); v != "" && v != "aws-chunked" {
+			kept = append(kept, v)
+		}
+	}
+	return strings.Join(kept, ",")
+}
+
+// decodeChunked decodes an aws-chunked body, returning the data and any trailing headers. Chunk
+// signatures are ignored.
+func decodeChunked(r io.Reader) ([]byte, http.Header, error) {
+	br := bufio.NewReader(r)
+	var buf bytes.Buffer
+	for {
+		line, err := readLine(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		sizeHex, _, _ := strings.Cut(line, ";")
+		size, err := strconv.ParseInt(strings.TrimSpace(sizeHex), 16, 64)
+		if err != nil || size < 0 {
+			return nil, nil, fmt.Errorf("invalid chunk size %q", line)
+		}
+		if size == 0 {
+			break
+		}
+		if _, err := io.CopyN(&buf, br, size); err != nil {
+			return nil, nil, err
+		}
+		if line, err := readLine(br); err != nil || line != "" {
+			return nil, nil, errors.New("chunk not terminated by CRLF")
+		}
+	}
+
+	trailers := http.Header{}
+	for {
+		line, err := readLine(br)
+		if errors.Is(err, io.EOF) || line == "" {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid trailer %q", line)
+		}
+		trailers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return buf.Bytes(), trailers, nil
+}
+
+func readLine(br *bufio.Reader) (string, error) {
+	line, err := br.ReadString('\n')
+	if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func hasAlgorithm(alg string) bool {
+	for _, a := range checksumAlgorithms {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
+
+// checksum returns the base64 checksum of data using alg.
+func checksum(alg string, data []byte) string {
+	h := newHash(alg)
+	if h == nil {
+		return ""
+	}
+	h.Write(data)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func newHash(alg string) hash.Hash {
+	switch alg {
+	case "crc32":
+		return crc32.NewIEEE()
+	case "crc32c":
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case "crc64nvme":
+		return crc64.New(crc64.MakeTable(0x9a6c9329ac4bc9b5))
+	case "sha1":
+		return sha1.New() //nolint:gosec
+	case "sha256":
+		return sha256.New()
+	}
+	return nil
+}
+
+// etag returns the quoted MD5 ETag of data.
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// multipartETag returns the quoted ETag of an object assembled from parts with the given ETags.
+func multipartETag(partETags []string) string {
+	h := md5.New()
+	for _, e := range partETags {
+		sum, _ := hex.DecodeString(strings.Trim(e, `"`))
+		h.Write(sum)
+	}
+	return fmt.Sprintf(`"%s-%d"`, hex.EncodeToString(h.Sum(nil)), len(partETags))
+}
+
+// etagMatches reports whether an If-Match or If-None-Match condition matches etag.
+func etagMatches(condition, etag string) bool {
+	for _, c := range strings.Split(condition, ",") {
+		c = strings.TrimSpace(c)
+		if c == "*" || strings.Trim(strings.TrimPrefix(c, "W/"), `"`) == strings.Trim(etag, `"`) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3test
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name      `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+	Owner   owner         `xml:"Owner"`
+	Buckets []bucketEntry `xml:"Buckets>Bucket"`
+	Prefix  string        `xml:"Prefix,omitempty"`
+}
+
+type owner struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+type bucketEntry struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+var testOwner = owner{ID: "s3test", DisplayName: "s3test"}
+
+func (s *Server) listBuckets(r *request) error {
+	prefix := r.URL.Query().Get("prefix")
+
+	s.mu.Lock()
+	result := listAllMyBucketsResult{Owner: testOwner, Prefix: prefix}
+	for name, b := range s.buckets {
+		if strings.HasPrefix(name, prefix) {
+			result.Buckets = append(result.Buckets, bucketEntry{Name: name, CreationDate: timestamp(b.created)})
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(result.Buckets, func(i, j int) bool {
+		return result.Buckets[i].Name < result.Buckets[j].Name
+	})
+	writeXML(r.w, http.StatusOK, result)
+	return nil
+}
+
+func (s *Server) createBucket(r *request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.buckets[r.bucket]; ok {
+		return errBucketExists
+	}
+	s.buckets[r.bucket] = &bucket{created: s.clock(), objects: map[string]*object{}, uploads: map[string]*upload{}}
+	r.w.Header().Set("Location", "/"+r.bucket)
+	r.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Server) headBucket(r *request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.bucketLocked(r.bucket); err != nil {
+		return err
+	}
+	r.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Server) deleteBucket(r *request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return err
+	}
+	if len(b.objects) > 0 || len(b.uploads) > 0 {
+		return errBucketNotEmpty
+	}
+	delete(s.buckets, r.bucket)
+	r.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	Delimiter             string         `xml:"Delimiter,omitempty"`
+	StartAfter            string         `xml:"StartAfter,omitempty"`
+	ContinuationToken     string         `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	MaxKeys               int            `xml:"MaxKeys"`
+	KeyCount              int            `xml:"KeyCount"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	Contents              []objectEntry  `xml:"Contents"`
+	CommonPrefixes        []commonPrefix `xml:"CommonPrefixes"`
+}
+
+type objectEntry struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type commonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+func (s *Server) listObjectsV2(r *request) error {
+	q := r.URL.Query()
+	result := listBucketResult{
+		Name:              r.bucket,
+		Prefix:            q.Get("prefix"),
+		Delimiter:         q.Get("delimiter"),
+		StartAfter:        q.Get("start-after"),
+		ContinuationToken: q.Get("continuation-token"),
+		MaxKeys:           1000,
+	}
+	if v := q.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return invalidArgument("max-keys must be a non-negative integer")
+		}
+		result.MaxKeys = min(n, 1000)
+	}
+	after := result.StartAfter
+	if result.ContinuationToken != "" {
+		token, err := base64.RawURLEncoding.DecodeString(result.ContinuationToken)
+		if err != nil {
+			return invalidArgument("The continuation token provided is incorrect")
+		}
+		after = string(token)
+	}
+
+	s.mu.Lock()
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	keys := make([]string, 0, len(b.objects))
+	for key := range b.objects {
+		if strings.HasPrefix(key, result.Prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	objects := make([]*object, len(keys))
+	for i, key := range keys {
+		objects[i] = b.objects[key]
+	}
+	s.mu.Unlock()
+
+	last := ""
+	for i, key := range keys {
+		entry := key
+		if result.Delimiter != "" {
+			if j := strings.Index(key[len(result.Prefix):], result.Delimiter); j >= 0 {
+				entry = key[:len(result.Prefix)+j+len(result.Delimiter)]
+				if entry == last {
+					continue
+				}
+			}
+		}
+		if result.KeyCount == result.MaxKeys {
+			result.IsTruncated = true
+			break
+		}
+		last = entry
+		result.KeyCount++
+		if entry != key {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: entry})
+			continue
+		}
+		obj := objects[i]
+		result.Contents = append(result.Contents, objectEntry{
+			Key:          key,
+			LastModified: timestamp(obj.modified),
+			ETag:         obj.etag,
+			Size:         len(obj.data),
+			StorageClass: "STANDARD",
+		})
+	}
+	if result.IsTruncated {
+		// Keys below a returned common prefix sort before any key after it, so resuming after the
+		// prefix with a high sentinel skips them.
+		resume := last
+		if strings.HasSuffix(last, result.Delimiter) && result.Delimiter != "" {
+			resume = last + "\U0010FFFF"
+		}
+		result.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(resume))
+	}
+	writeXML(r.w, http.StatusOK, result)
+	return nil
+}
+
+type deleteRequest struct {
+	Quiet   bool `xml:"Quiet"`
+	Objects []struct {
+		Key string `xml:"Key"`
+	} `xml:"Object"`
+}
+
+type deleteResult struct {
+	XMLName xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteResult"`
+	Deleted []deletedEntry  `xml:"Deleted"`
+	Errors  []deleteFailure `xml:"Error"`
+}
+
+type deletedEntry struct {
+	Key string `xml:"Key"`
+}
+
+type deleteFailure struct {
+	Key     string `xml:"Key"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (s *Server) deleteObjects(r *request) error {
+	var req deleteRequest
+	if err := readXML(r, &req); err != nil {
+		return err
+	}
+	if len(req.Objects) > 1000 {
+		return errMalformedXML
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return err
+	}
+	result := deleteResult{}
+	for _, o := range req.Objects {
+		delete(b.objects, o.Key)
+		if !req.Quiet {
+			result.Deleted = append(result.Deleted, deletedEntry{Key: o.Key})
+		}
+	}
+	writeXML(r.w, http.StatusOK, result)
+	return nil
+}
@@ -0,0 +1,430 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPartNumber is the highest part number S3 accepts.
+const maxPartNumber = 10000
+
+type upload struct {
+	id        string
+	key       string
+	initiated time.Time
+	header    http.Header
+	tags      []tag
+	// checksumAlg and checksumType are the checksum settings given when the upload was created.
+	checksumAlg  string
+	checksumType string
+	parts        map[int]*part
+}
+
+type part struct {
+	data     []byte
+	etag     string
+	modified time.Time
+	checksum string
+}
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ InitiateMultipartUploadResult"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+func (s *Server) createMultipartUpload(r *request) error {
+	tags, err := parseTags(r.Header.Get("x-amz-tagging"))
+	if err != nil {
+		return err
+	}
+	alg := strings.ToLower(r.Header.Get("x-amz-checksum-algorithm"))
+	if alg != "" && !hasAlgorithm(alg) {
+		return invalidArgument("Checksum algorithm provided is unsupported.")
+	}
+	checksumType := strings.ToUpper(r.Header.Get("x-amz-checksum-type"))
+	if checksumType == "" && alg != "" {
+		checksumType = "COMPOSITE"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return err
+	}
+	u := &upload{
+		id:           strconv.FormatUint(s.uploadID.Add(1), 36) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		key:          r.key,
+		initiated:    s.clock(),
+		header:       objectHeader(r.Header),
+		tags:         tags,
+		checksumAlg:  alg,
+		checksumType: checksumType,
+		parts:        map[int]*part{},
+	}
+	b.uploads[u.id] = u
+
+	if alg != "" {
+		r.w.Header().Set("x-amz-checksum-algorithm", strings.ToUpper(alg))
+		r.w.Header().Set("x-amz-checksum-type", checksumType)
+	}
+	writeXML(r.w, http.StatusOK, initiateMultipartUploadResult{Bucket: r.bucket, Key: r.key, UploadID: u.id})
+	return nil
+}
+
+// uploadLocked returns the upload named by the request. s.mu must be held.
+func (s *Server) uploadLocked(r *request) (*upload, error) {
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return nil, err
+	}
+	u, ok := b.uploads[r.URL.Query().Get("uploadId")]
+	if !ok || u.key != r.key {
+		return nil, errNoSuchUpload
+	}
+	return u, nil
+}
+
+func (s *Server) uploadPart(r *request) error {
+	n, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || n < 1 || n > maxPartNumber {
+		return invalidArgument("Part number must be an integer between 1 and %d, inclusive", maxPartNumber)
+	}
+	p, err := readPayload(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, err := s.uploadLocked(r)
+	if err != nil {
+		return err
+	}
+	pt := &part{data: p.data, etag: etag(p.data), modified: s.clock()}
+	if u.checksumAlg != "" {
+		pt.checksum = checksum(u.checksumAlg, p.data)
+	}
+	u.parts[n] = pt
+
+	r.w.Header().Set("ETag", pt.etag)
+	writeChecksum(r.w.Header(), u.checksumAlg, pt.checksum, "")
+	r.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+type listPartsResult struct {
+	XMLName              xml.Name    `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListPartsResult"`
+	Bucket               string      `xml:"Bucket"`
+	Key                  string      `xml:"Key"`
+	UploadID             string      `xml:"UploadId"`
+	PartNumberMarker     int         `xml:"PartNumberMarker"`
+	NextPartNumberMarker int         `xml:"NextPartNumberMarker"`
+	MaxParts             int         `xml:"MaxParts"`
+	IsTruncated          bool        `xml:"IsTruncated"`
+	ChecksumAlgorithm    string      `xml:"ChecksumAlgorithm,omitempty"`
+	ChecksumType         string      `xml:"ChecksumType,omitempty"`
+	Parts                []partEntry `xml:"Part"`
+}
+
+type partEntry struct {
+	PartNumber   int    `xml:"PartNumber"`
+	LastModified string `xml:"LastModified,omitempty"`
+	ETag         string `xml:"ETag,omitempty"`
+	Size         int    `xml:"Size"`
+	checksums
+}
+
+// checksums holds a checksum in the element named for its algorithm.
+type checksums struct {
+	ChecksumCRC32     string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C    string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumCRC64NVME string `xml:"ChecksumCRC64NVME,omitempty"`
+	ChecksumSHA1      string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256    string `xml:"ChecksumSHA256,omitempty"`
+}
+
+// setChecksum stores value in the field for alg.
+func (p *checksums) setChecksum(alg, value string) {
+	switch alg {
+	case "crc32":
+		p.ChecksumCRC32 = value
+	case "crc32c":
+		p.ChecksumCRC32C = value
+	case "crc64nvme":
+		p.ChecksumCRC64NVME = value
+	case "sha1":
+		p.ChecksumSHA1 = value
+	case "sha256":
+		p.ChecksumSHA256 = value
+	}
+}
+
+// checksum returns the value of the field for alg.
+func (p *checksums) checksum(alg string) string {
+	switch alg {
+	case "crc32":
+		return p.ChecksumCRC32
+	case "crc32c":
+		return p.ChecksumCRC32C
+	case "crc64nvme":
+		return p.ChecksumCRC64NVME
+	case "sha1":
+		return p.ChecksumSHA1
+	case "sha256":
+		return p.ChecksumSHA256
+	}
+	return ""
+}
+
+func (s *Server) listParts(r *request) error {
+	q := r.URL.Query()
+	result := listPartsResult{Bucket: r.bucket, Key: r.key, UploadID: q.Get("uploadId"), MaxParts: 1000}
+	if v := q.Get("max-parts"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return invalidArgument("max-parts must be a non-negative integer")
+		}
+		result.MaxParts = min(n, 1000)
+	}
+	if v := q.Get("part-number-marker"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return invalidArgument("part-number-marker must be a non-negative integer")
+		}
+		result.PartNumberMarker = n
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, err := s.uploadLocked(r)
+	if err != nil {
+		return err
+	}
+	result.ChecksumAlgorithm = strings.ToUpper(u.checksumAlg)
+	result.ChecksumType = u.checksumType
+
+	numbers := make([]int, 0, len(u.parts))
+	for n := range u.parts {
+		if n > result.PartNumberMarker {
+			numbers = append(numbers, n)
+		}
+	}
+	sort.Ints(numbers)
+	for _, n := range numbers {
+		if len(result.Parts) == result.MaxParts {
+			result.IsTruncated = true
+			break
+		}
+		p := u.parts[n]
+		entry := partEntry{PartNumber: n, LastModified: timestamp(p.modified), ETag: p.etag, Size: len(p.data)}
+		entry.setChecksum(u.checksumAlg, p.checksum)
+		result.Parts = append(result.Parts, entry)
+		result.NextPartNumberMarker = n
+	}
+	writeXML(r.w, http.StatusOK, result)
+	return nil
+}
+
+type completeMultipartUpload struct {
+	Parts []partEntry `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CompleteMultipartUploadResult"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+func (s *Server) completeMultipartUpload(r *request) error {
+	var req completeMultipartUpload
+	if err := readXML(r, &req); err != nil {
+		return err
+	}
+	if len(req.Parts) == 0 {
+		return errMalformedXML
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, err := s.uploadLocked(r)
+	if err != nil {
+		return err
+	}
+	b := s.buckets[r.bucket]
+	if err := checkWrite(r.Header, b.objects[r.key]); err != nil {
+		return err
+	}
+
+	var (
+		data        bytes.Buffer
+		partETags   = make([]string, 0, len(req.Parts))
+		objectParts = make([]partEntry, 0, len(req.Parts))
+		composite   []byte
+	)
+	for i, entry := range req.Parts {
+		if i > 0 && entry.PartNumber <= req.Parts[i-1].PartNumber {
+			return errInvalidPartOrder
+		}
+		p, ok := u.parts[entry.PartNumber]
+		if !ok || !etagMatches(entry.ETag, p.etag) {
+			return errInvalidPart
+		}
+		if c := entry.checksum(u.checksumAlg); c != "" && c != p.checksum {
+			return errInvalidPart
+		}
+		if i < len(req.Parts)-1 && int64(len(p.data)) < s.minPart {
+			return errEntityTooSmall
+		}
+		data.Write(p.data)
+		objectPart := partEntry{PartNumber: entry.PartNumber, Size: len(p.data)}
+		objectPart.setChecksum(u.checksumAlg, p.checksum)
+		objectParts = append(objectParts, objectPart)
+		partETags = append(partETags, p.etag)
+		raw, _ := base64.StdEncoding.DecodeString(p.checksum)
+		composite = append(composite, raw...)
+	}
+
+	obj := &object{
+		data:     data.Bytes(),
+		etag:     multipartETag(partETags),
+		modified: s.clock(),
+		header:   u.header,
+		tags:     u.tags,
+		parts:    objectParts,
+	}
+	switch {
+	case u.checksumAlg == "":
+	case u.checksumType == "FULL_OBJECT":
+		obj.checksumAlg, obj.checksumType = u.checksumAlg, u.checksumType
+		obj.checksum = checksum(u.checksumAlg, obj.data)
+		if v := r.Header.Get("x-amz-checksum-" + u.checksumAlg); v != "" && v != obj.checksum {
+			return errBadDigest
+		}
+	default:
+		h := newHash(u.checksumAlg)
+		h.Write(composite)
+		obj.checksumAlg, obj.checksumType = u.checksumAlg, "COMPOSITE"
+		obj.checksum = base64.StdEncoding.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(req.Parts))
+	}
+	b.objects[r.key] = obj
+	delete(b.uploads, u.id)
+
+	writeChecksum(r.w.Header(), obj.checksumAlg, obj.checksum, obj.checksumType)
+	writeXML(r.w, http.StatusOK, completeMultipartUploadResult{
+		Location: "/" + r.bucket + "/" + r.key,
+		Bucket:   r.bucket,
+		Key:      r.key,
+		ETag:     obj.etag,
+	})
+	return nil
+}
+
+func (s *Server) abortMultipartUpload(r *request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, err := s.uploadLocked(r)
+	if err != nil {
+		return err
+	}
+	delete(s.buckets[r.bucket].uploads, u.id)
+	r.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+type listMultipartUploadsResult struct {
+	XMLName            xml.Name      `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListMultipartUploadsResult"`
+	Bucket             string        `xml:"Bucket"`
+	Prefix             string        `xml:"Prefix"`
+	KeyMarker          string        `xml:"KeyMarker"`
+	UploadIDMarker     string        `xml:"UploadIdMarker"`
+	NextKeyMarker      string        `xml:"NextKeyMarker,omitempty"`
+	NextUploadIDMarker string        `xml:"NextUploadIdMarker,omitempty"`
+	MaxUploads         int           `xml:"MaxUploads"`
+	IsTruncated        bool          `xml:"IsTruncated"`
+	Uploads            []uploadEntry `xml:"Upload"`
+}
+
+type uploadEntry struct {
+	Key       string `xml:"Key"`
+	UploadID  string `xml:"UploadId"`
+	Initiated string `xml:"Initiated"`
+}
+
+func (s *Server) listMultipartUploads(r *request) error {
+	q := r.URL.Query()
+	result := listMultipartUploadsResult{
+		Bucket:         r.bucket,
+		Prefix:         q.Get("prefix"),
+		KeyMarker:      q.Get("key-marker"),
+		UploadIDMarker: q.Get("upload-id-marker"),
+		MaxUploads:     1000,
+	}
+	if v := q.Get("max-uploads"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return invalidArgument("max-uploads must be a non-negative integer")
+		}
+		result.MaxUploads = min(n, 1000)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return err
+	}
+	uploads := make([]*upload, 0, len(b.uploads))
+	for _, u := range b.uploads {
+		if !strings.HasPrefix(u.key, result.Prefix) {
+			continue
+		}
+		// Uploads are ordered by key and then ID, and listing resumes after the markers.
+		if u.key < result.KeyMarker || (u.key == result.KeyMarker && (result.UploadIDMarker == "" || u.id <= result.UploadIDMarker)) {
+			continue
+		}
+		uploads = append(uploads, u)
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		if uploads[i].key != uploads[j].key {
+			return uploads[i].key < uploads[j].key
+		}
+		return uploads[i].id < uploads[j].id
+	})
+	for _, u := range uploads {
+		if len(result.Uploads) == result.MaxUploads {
+			result.IsTruncated = true
+			break
+		}
+		result.Uploads = append(result.Uploads, uploadEntry{Key: u.key, UploadID: u.id, Initiated: timestamp(u.initiated)})
+		result.NextKeyMarker, result.NextUploadIDMarker = u.key, u.id
+	}
+	writeXML(r.w, http.StatusOK, result)
+	return nil
+}
@@ -0,0 +1,472 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// contentHeaders are the standard headers stored with an object and returned when it is read.
+var contentHeaders = []string{"Content-Type", "Content-Disposition", "Content-Language", "Cache-Control", "Expires"}
+
+type tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  []tag    `xml:"TagSet>Tag"`
+}
+
+// objectHeader returns the content headers and user metadata of a write request.
+func objectHeader(h http.Header) http.Header {
+	out := http.Header{}
+	for _, name := range contentHeaders {
+		if v := h.Get(name); v != "" {
+			out.Set(name, v)
+		}
+	}
+	if out.Get("Content-Type") == "" {
+		out.Set("Content-Type", "binary/octet-stream")
+	}
+	if v := contentEncoding(h); v != "" {
+		out.Set("Content-Encoding", v)
+	}
+	for name, values := range h {
+		if strings.HasPrefix(strings.ToLower(name), "x-amz-meta-") {
+			out[name] = values
+		}
+	}
+	return out
+}
+
+// parseTags parses the URL-encoded x-amz-tagging header.
+func parseTags(v string) ([]tag, error) {
+	if v == "" {
+		return nil, nil
+	}
+	values, err := url.ParseQuery(v)
+	if err != nil {
+		return nil, invalidArgument("The header 'x-amz-tagging' shall be encoded as UTF-8 then URLEncoded URL query parameters without tag name duplicates.")
+	}
+	tags := make([]tag, 0, len(values))
+	for k, vs := range values {
+		tags = append(tags, tag{Key: k, Value: vs[0]})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags, nil
+}
+
+// checkWrite applies the If-Match and If-None-Match conditions of a write to the current object.
+func checkWrite(h http.Header, current *object) error {
+	if v := h.Get("If-Match"); v != "" {
+		if current == nil {
+			return errNoSuchKey
+		}
+		if !etagMatches(v, current.etag) {
+			return errPreconditionFailed
+		}
+	}
+	if v := h.Get("If-None-Match"); v != "" && current != nil && etagMatches(v, current.etag) {
+		return errPreconditionFailed
+	}
+	return nil
+}
+
+func (s *Server) putObject(r *request) error {
+	p, err := readPayload(r)
+	if err != nil {
+		return err
+	}
+	tags, err := parseTags(r.Header.Get("x-amz-tagging"))
+	if err != nil {
+		return err
+	}
+	obj := &object{
+		data:         p.data,
+		etag:         etag(p.data),
+		header:       objectHeader(r.Header),
+		tags:         tags,
+		checksumAlg:  p.checksumAlg,
+		checksum:     p.checksum,
+		checksumType: "FULL_OBJECT",
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return err
+	}
+	if err := checkWrite(r.Header, b.objects[r.key]); err != nil {
+		return err
+	}
+	obj.modified = s.clock()
+	b.objects[r.key] = obj
+
+	r.w.Header().Set("ETag", obj.etag)
+	writeChecksum(r.w.Header(), obj.checksumAlg, obj.checksum, "")
+	r.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// writeChecksum sets the checksum response header for alg, if any.
+func writeChecksum(h http.Header, alg, value, checksumType string) {
+	if alg == "" || value == "" {
+		return
+	}
+	h.Set("x-amz-checksum-"+alg, value)
+	if checksumType != "" {
+		h.Set("x-amz-checksum-type", checksumType)
+	}
+}
+
+func (s *Server) lookup(bucketName, key string) (*object, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucketLocked(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := b.objects[key]
+	if !ok {
+		return nil, errNoSuchKey
+	}
+	return obj, nil
+}
+
+func (s *Server) getObject(r *request) error {
+	obj, err := s.lookup(r.bucket, r.key)
+	if err != nil {
+		return err
+	}
+
+	h := r.w.Header()
+	h.Set("ETag", obj.etag)
+	h.Set("Last-Modified", obj.modified.UTC().Format(http.TimeFormat))
+	h.Set("Accept-Ranges", "bytes")
+	if status, err := checkRead(r.Header, obj); err != nil || status != 0 {
+		if err != nil {
+			return err
+		}
+		r.w.WriteHeader(status)
+		return nil
+	}
+
+	for name, values := range obj.header {
+		h[name] = values
+	}
+	if len(obj.tags) > 0 {
+		h.Set("x-amz-tagging-count", strconv.Itoa(len(obj.tags)))
+	}
+
+	size := int64(len(obj.data))
+	start, end, ranged, err := parseRange(r.Header.Get("Range"), size)
+	if err != nil {
+		h.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		return err
+	}
+	status := http.StatusOK
+	if ranged {
+		status = http.StatusPartialContent
+		h.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, size))
+	} else if strings.EqualFold(r.Header.Get("x-amz-checksum-mode"), "ENABLED") {
+		// Checksums describe the whole object, so they are only returned with it.
+		writeChecksum(h, obj.checksumAlg, obj.checksum, obj.checksumType)
+	}
+	h.Set("Content-Length", strconv.FormatInt(end-start, 10))
+	r.w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		_, _ = r.w.Write(obj.data[start:end])
+	}
+	return nil
+}
+
+// checkRead applies the conditional headers of a read. It returns a non-zero status when the read
+// should end with that status and no body.
+func checkRead(h http.Header, obj *object) (int, error) {
+	modified := obj.modified.Truncate(time.Second)
+	ifMatch, ifNoneMatch := h.Get("If-Match"), h.Get("If-None-Match")
+	if ifMatch != "" && !etagMatches(ifMatch, obj.etag) {
+		return 0, errPreconditionFailed
+	}
+	if t, err := http.ParseTime(h.Get("If-Unmodified-Since")); ifMatch == "" && err == nil && modified.After(t) {
+		return 0, errPreconditionFailed
+	}
+	if ifNoneMatch != "" && etagMatches(ifNoneMatch, obj.etag) {
+		return http.StatusNotModified, nil
+	}
+	if t, err := http.ParseTime(h.Get("If-Modified-Since")); ifNoneMatch == "" && err == nil && !modified.After(t) {
+		return http.StatusNotModified, nil
+	}
+	return 0, nil
+}
+
+// parseRange parses a single-range Range header, returning the half-open byte range to serve.
+// Multiple ranges are not supported and the whole object is served, as the service does.
+func parseRange(header string, size int64) (int64, int64, bool, error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, size, false, nil
+	}
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, size, false, nil
+	}
+
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil {
+			return 0, size, false, nil
+		}
+		if n <= 0 || size == 0 {
+			return 0, 0, false, errInvalidRange
+		}
+		return max(size-n, 0), size, true, nil
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, size, false, nil
+	}
+	end := size
+	if last != "" {
+		e, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || e < start {
+			return 0, size, false, nil
+		}
+		end = min(e+1, size)
+	}
+	if start >= size {
+		return 0, 0, false, errInvalidRange
+	}
+	return start, end, true, nil
+}
+
+func (s *Server) deleteObject(r *request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return err
+	}
+	if v := r.Header.Get("If-Match"); v != "" {
+		if err := checkWrite(r.Header, b.objects[r.key]); err != nil {
+			return err
+		}
+	}
+	delete(b.objects, r.key)
+	r.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+type copyObjectResult struct {
+	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyObjectResult"`
+	LastModified string   `xml:"LastModified"`
+	ETag         string   `xml:"ETag"`
+}
+
+// copySource parses the x-amz-copy-source header into a bucket and key.
+func copySource(h http.Header) (string, string, error) {
+	v, _, _ := strings.Cut(h.Get("x-amz-copy-source"), "?")
+	src, err := url.PathUnescape(strings.TrimPrefix(v, "/"))
+	if err != nil {
+		return "", "", invalidArgument("Copy Source must mention the source bucket and key: sourcebucket/sourcekey")
+	}
+	bucketName, key, ok := strings.Cut(src, "/")
+	if !ok || bucketName == "" || key == "" {
+		return "", "", invalidArgument("Copy Source must mention the source bucket and key: sourcebucket/sourcekey")
+	}
+	return bucketName, key, nil
+}
+
+func (s *Server) copyObject(r *request) error {
+	srcBucket, srcKey, err := copySource(r.Header)
+	if err != nil {
+		return err
+	}
+	tags, err := parseTags(r.Header.Get("x-amz-tagging"))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sb, err := s.bucketLocked(srcBucket)
+	if err != nil {
+		return err
+	}
+	src, ok := sb.objects[srcKey]
+	if !ok {
+		return errNoSuchKey
+	}
+	if v := r.Header.Get("x-amz-copy-source-if-match"); v != "" && !etagMatches(v, src.etag) {
+		return errPreconditionFailed
+	}
+	if v := r.Header.Get("x-amz-copy-source-if-none-match"); v != "" && etagMatches(v, src.etag) {
+		return errPreconditionFailed
+	}
+	db, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return err
+	}
+	if err := checkWrite(r.Header, db.objects[r.key]); err != nil {
+		return err
+	}
+
+	obj := *src
+	if strings.EqualFold(r.Header.Get("x-amz-metadata-directive"), "REPLACE") {
+		obj.header = objectHeader(r.Header)
+	}
+	if strings.EqualFold(r.Header.Get("x-amz-tagging-directive"), "REPLACE") {
+		obj.tags = tags
+	}
+	obj.modified = s.clock()
+	db.objects[r.key] = &obj
+
+	writeXML(r.w, http.StatusOK, copyObjectResult{LastModified: timestamp(obj.modified), ETag: obj.etag})
+	return nil
+}
+
+func (s *Server) getObjectTagging(r *request) error {
+	obj, err := s.lookup(r.bucket, r.key)
+	if err != nil {
+		return err
+	}
+	writeXML(r.w, http.StatusOK, tagging{TagSet: obj.tags})
+	return nil
+}
+
+func (s *Server) putObjectTagging(r *request) error {
+	var t tagging
+	if err := readXML(r, &t); err != nil {
+		return err
+	}
+	return s.replaceTags(r, t.TagSet)
+}
+
+func (s *Server) deleteObjectTagging(r *request) error {
+	return s.replaceTags(r, nil)
+}
+
+func (s *Server) replaceTags(r *request, tags []tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucketLocked(r.bucket)
+	if err != nil {
+		return err
+	}
+	current, ok := b.objects[r.key]
+	if !ok {
+		return errNoSuchKey
+	}
+	obj := *current
+	obj.tags = tags
+	b.objects[r.key] = &obj
+
+	status := http.StatusOK
+	if tags == nil {
+		status = http.StatusNoContent
+	}
+	r.w.WriteHeader(status)
+	return nil
+}
+
+type objectAttributes struct {
+	XMLName      xml.Name     `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse"`
+	ETag         string       `xml:"ETag,omitempty"`
+	Checksum     *checksums   `xml:"Checksum,omitempty"`
+	ObjectParts  *objectParts `xml:"ObjectParts,omitempty"`
+	StorageClass string       `xml:"StorageClass,omitempty"`
+	ObjectSize   *int         `xml:"ObjectSize,omitempty"`
+}
+
+type objectParts struct {
+	TotalPartsCount      int         `xml:"PartsCount"`
+	PartNumberMarker     int         `xml:"PartNumberMarker"`
+	NextPartNumberMarker int         `xml:"NextPartNumberMarker"`
+	MaxParts             int         `xml:"MaxParts"`
+	IsTruncated          bool        `xml:"IsTruncated"`
+	Parts                []partEntry `xml:"Part"`
+}
+
+func (s *Server) getObjectAttributes(r *request) error {
+	obj, err := s.lookup(r.bucket, r.key)
+	if err != nil {
+		return err
+	}
+
+	maxParts, marker := 1000, 0
+	if v := r.Header.Get("x-amz-max-parts"); v != "" {
+		if maxParts, err = strconv.Atoi(v); err != nil || maxParts < 0 {
+			return invalidArgument("x-amz-max-parts must be a non-negative integer")
+		}
+	}
+	if v := r.Header.Get("x-amz-part-number-marker"); v != "" {
+		if marker, err = strconv.Atoi(v); err != nil || marker < 0 {
+			return invalidArgument("x-amz-part-number-marker must be a non-negative integer")
+		}
+	}
+
+	var result objectAttributes
+	for _, name := range strings.Split(r.Header.Get("x-amz-object-attributes"), ",") {
+		switch strings.TrimSpace(name) {
+		case "ETag":
+			result.ETag = strings.Trim(obj.etag, `"`)
+		case "Checksum":
+			if obj.checksumAlg != "" {
+				result.Checksum = &checksums{}
+				result.Checksum.setChecksum(obj.checksumAlg, obj.checksum)
+			}
+		case "ObjectParts":
+			if obj.parts == nil {
+				continue
+			}
+			parts := &objectParts{TotalPartsCount: len(obj.parts), PartNumberMarker: marker, MaxParts: maxParts}
+			for _, p := range obj.parts {
+				if p.PartNumber <= marker {
+					continue
+				}
+				if len(parts.Parts) == maxParts {
+					parts.IsTruncated = true
+					break
+				}
+				parts.Parts = append(parts.Parts, p)
+				parts.NextPartNumberMarker = p.PartNumber
+			}
+			result.ObjectParts = parts
+		case "StorageClass":
+			result.StorageClass = "STANDARD"
+		case "ObjectSize":
+			size := len(obj.data)
+			result.ObjectSize = &size
+		default:
+			return invalidArgument("Invalid attribute name specified.")
+		}
+	}
+
+	r.w.Header().Set("Last-Modified", obj.modified.UTC().Format(http.TimeFormat))
+	writeXML(r.w, http.StatusOK, result)
+	return nil
+}
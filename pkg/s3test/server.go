@@ -0,0 +1,314 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3test provides an in-process S3-compatible HTTP server for tests.
+//
+// The server speaks enough of the S3 REST protocol for the S3 wrapper and the AWS SDK to work end to
+// end against it with path-style addressing: bucket create, head, list and delete; object put, get,
+// head, copy and delete, including conditional and ranged requests; ListObjectsV2; DeleteObjects;
+// object tagging; and multipart uploads. Data is held in memory. Request signatures are not
+// verified, so any credentials are accepted.
+//
+//	server := s3test.NewServer()
+//	defer server.Close()
+//	client, err := simple_s3.New(ctx, server.URL, "access-key", "secret-key", "")
+package s3test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Options configures a Server.
+type Options struct {
+	// Clock returns the time recorded for created buckets, written objects and started uploads.
+	// Nil uses time.Now.
+	Clock func() time.Time
+	// MinPartSize is the smallest size in bytes allowed for every part but the last of a multipart
+	// upload. Zero uses the 5 MiB S3 minimum.
+	MinPartSize int64
+}
+
+// Server is an S3-compatible HTTP server backed by memory.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	clock     func() time.Time
+	minPart   int64
+	requestID atomic.Uint64
+	uploadID  atomic.Uint64
+}
+
+type bucket struct {
+	created time.Time
+	objects map[string]*object
+	uploads map[string]*upload
+}
+
+// object is a stored object. Objects are replaced rather than modified, so one may be read without
+// the lock once looked up.
+type object struct {
+	data     []byte
+	etag     string
+	modified time.Time
+	// header holds the content headers and user metadata returned with the object.
+	header       http.Header
+	tags         []tag
+	checksumAlg  string
+	checksum     string
+	checksumType string
+	// parts holds the size and checksum of each part of an object created by a multipart upload.
+	parts []partEntry
+}
+
+// NewServer starts a server. Close it when done.
+func NewServer(optFns ...func(*Options)) *Server {
+	o := Options{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.Clock == nil {
+		o.Clock = time.Now
+	}
+	if o.MinPartSize <= 0 {
+		o.MinPartSize = 5 << 20
+	}
+
+	s := &Server{buckets: map[string]*bucket{}, clock: o.Clock, minPart: o.MinPartSize}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// s3Error is an error response in the S3 XML format.
+type s3Error struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *s3Error) Error() string { return e.code + ": " + e.message }
+
+// Errors returned by the server, using the codes and status codes of the service.
+var (
+	errNoSuchBucket       = &s3Error{http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist"}
+	errNoSuchKey          = &s3Error{http.StatusNotFound, "NoSuchKey", "The specified key does not exist."}
+	errNoSuchUpload       = &s3Error{http.StatusNotFound, "NoSuchUpload", "The specified multipart upload does not exist."}
+	errBucketExists       = &s3Error{http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it."}
+	errBucketNotEmpty     = &s3Error{http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty"}
+	errPreconditionFailed = &s3Error{http.StatusPreconditionFailed, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold"}
+	errInvalidRange       = &s3Error{http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "The requested range is not satisfiable"}
+	errBadDigest          = &s3Error{http.StatusBadRequest, "BadDigest", "The checksum you specified did not match what we received."}
+	errInvalidDigest      = &s3Error{http.StatusBadRequest, "InvalidDigest", "The checksum you specified is not valid."}
+	errMalformedXML       = &s3Error{http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema"}
+	errInvalidPart        = &s3Error{http.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found."}
+	errInvalidPartOrder   = &s3Error{http.StatusBadRequest, "InvalidPartOrder", "The list of parts was not in ascending order."}
+	errEntityTooSmall     = &s3Error{http.StatusBadRequest, "EntityTooSmall", "Your proposed upload is smaller than the minimum allowed object size."}
+	errNotImplemented     = &s3Error{http.StatusNotImplemented, "NotImplemented", "A header or query you provided implies functionality that is not implemented."}
+)
+
+func invalidArgument(format string, args ...any) *s3Error {
+	return &s3Error{http.StatusBadRequest, "InvalidArgument", fmt.Sprintf(format, args...)}
+}
+
+// request is an incoming request with its parsed target.
+type request struct {
+	*http.Request
+	w      http.ResponseWriter
+	bucket string
+	key    string
+}
+
+// has reports whether the query string contains name, with or without a value.
+func (r *request) has(name string) bool {
+	_, ok := r.URL.Query()[name]
+	return ok
+}
+
+// subresource reports whether the query string selects a subresource, such as ?tagging. The SDK's
+// x-id parameter naming the operation is ignored.
+func (r *request) subresource() bool {
+	for name := range r.URL.Query() {
+		if name != "x-id" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, hr *http.Request) {
+	id := strconv.FormatUint(s.requestID.Add(1), 16)
+	w.Header().Set("x-amz-request-id", id)
+	w.Header().Set("x-amz-id-2", id)
+
+	bucketName, key, _ := strings.Cut(strings.TrimPrefix(hr.URL.Path, "/"), "/")
+	r := &request{Request: hr, w: w, bucket: bucketName, key: key}
+
+	var err error
+	switch {
+	case r.bucket == "":
+		err = s.serviceRequest(r)
+	case r.key == "":
+		err = s.bucketRequest(r)
+	default:
+		err = s.objectRequest(r)
+	}
+	if err != nil {
+		s.writeError(r, err)
+	}
+}
+
+func (s *Server) serviceRequest(r *request) error {
+	if r.Method != http.MethodGet {
+		return errNotImplemented
+	}
+	return s.listBuckets(r)
+}
+
+func (s *Server) bucketRequest(r *request) error {
+	switch {
+	case r.Method == http.MethodPut && !r.subresource():
+		return s.createBucket(r)
+	case r.Method == http.MethodHead:
+		return s.headBucket(r)
+	case r.Method == http.MethodDelete && !r.subresource():
+		return s.deleteBucket(r)
+	case r.Method == http.MethodGet && r.has("uploads"):
+		return s.listMultipartUploads(r)
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		return s.listObjectsV2(r)
+	case r.Method == http.MethodPost && r.has("delete"):
+		return s.deleteObjects(r)
+	}
+	return errNotImplemented
+}
+
+func (s *Server) objectRequest(r *request) error {
+	switch r.Method {
+	case http.MethodPut:
+		switch {
+		case r.has("uploadId") && r.Header.Get("x-amz-copy-source") == "":
+			return s.uploadPart(r)
+		case r.has("tagging"):
+			return s.putObjectTagging(r)
+		case r.subresource():
+			return errNotImplemented
+		case r.Header.Get("x-amz-copy-source") != "":
+			return s.copyObject(r)
+		default:
+			return s.putObject(r)
+		}
+	case http.MethodGet, http.MethodHead:
+		switch {
+		case r.has("uploadId"):
+			return s.listParts(r)
+		case r.has("tagging"):
+			return s.getObjectTagging(r)
+		case r.has("attributes"):
+			return s.getObjectAttributes(r)
+		default:
+			return s.getObject(r)
+		}
+	case http.MethodPost:
+		switch {
+		case r.has("uploads"):
+			return s.createMultipartUpload(r)
+		case r.has("uploadId"):
+			return s.completeMultipartUpload(r)
+		}
+	case http.MethodDelete:
+		switch {
+		case r.has("uploadId"):
+			return s.abortMultipartUpload(r)
+		case r.has("tagging"):
+			return s.deleteObjectTagging(r)
+		default:
+			return s.deleteObject(r)
+		}
+	}
+	return errNotImplemented
+}
+
+// errorResponse is the body of an error response.
+type errorResponse struct {
+	XMLName   xml.Name `xml:"Error"`
+	Code      string   `xml:"Code"`
+	Message   string   `xml:"Message"`
+	Resource  string   `xml:"Resource"`
+	RequestID string   `xml:"RequestId"`
+}
+
+func (s *Server) writeError(r *request, err error) {
+	e, ok := err.(*s3Error)
+	if !ok {
+		e = &s3Error{http.StatusInternalServerError, "InternalError", err.Error()}
+	}
+	if r.Method == http.MethodHead {
+		// HEAD responses have no body, so clients only see the status code.
+		r.w.WriteHeader(e.status)
+		return
+	}
+	writeXML(r.w, e.status, errorResponse{
+		Code:      e.code,
+		Message:   e.message,
+		Resource:  r.URL.Path,
+		RequestID: r.w.Header().Get("x-amz-request-id"),
+	})
+}
+
+// writeXML writes v as an XML response body.
+func writeXML(w http.ResponseWriter, status int, v any) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Length", strconv.Itoa(len(xml.Header)+len(body)))
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(body)
+}
+
+// readXML decodes an XML request body into v.
+func readXML(r *request, v any) error {
+	if err := xml.NewDecoder(r.Body).Decode(v); err != nil {
+		return errMalformedXML
+	}
+	return nil
+}
+
+// bucketLocked returns the named bucket. s.mu must be held.
+func (s *Server) bucketLocked(name string) (*bucket, error) {
+	b, ok := s.buckets[name]
+	if !ok {
+		return nil, errNoSuchBucket
+	}
+	return b, nil
+}
+
+// timestamp formats t as the service does in XML bodies.
+func timestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
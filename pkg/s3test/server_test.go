@@ -0,0 +1,474 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	simple_s3 "github.com/drewbernetes/simple-s3"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// mapStateStore keeps resumable upload state in memory.
+type mapStateStore map[string]*types.UploadState
+
+func (m mapStateStore) Load(_ context.Context, id string) (*types.UploadState, error) {
+	return m[id], nil
+}
+
+func (m mapStateStore) Save(_ context.Context, id string, state *types.UploadState) error {
+	m[id] = state
+	return nil
+}
+
+func (m mapStateStore) Delete(_ context.Context, id string) error {
+	delete(m, id)
+	return nil
+}
+
+var _ = Describe("Server", func() {
+	var (
+		ctx    context.Context
+		server *Server
+		client *simple_s3.S3
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		server = NewServer(func(o *Options) {
+			o.Clock = func() time.Time { return now }
+			o.MinPartSize = 5 << 20
+		})
+		DeferCleanup(server.Close)
+
+		var err error
+		client, err = simple_s3.New(ctx, server.URL, "access-key", "secret-key", "us-east-1", func(o *simple_s3.Options) {
+			o.Retry.MaxAttempts = 1
+			o.Multipart.PartSize = 5 << 20
+			o.Multipart.Threshold = 5 << 20
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.CreateBucket(ctx, "bucket-a")).To(Succeed())
+	})
+
+	put := func(key, content string, optFns ...func(*types.PutObjectOptions)) {
+		GinkgoHelper()
+		Expect(client.PutObject(ctx, "bucket-a", key, bytes.NewReader([]byte(content)), optFns...)).To(Succeed())
+	}
+
+	Describe("buckets", func() {
+		It("lists buckets by prefix", func() {
+			Expect(client.CreateBucket(ctx, "other")).To(Succeed())
+
+			out, err := client.ListBuckets(ctx, "bucket-")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Buckets).To(HaveLen(1))
+			Expect(aws.ToString(out.Buckets[0].Name)).To(Equal("bucket-a"))
+			Expect(aws.ToTime(out.Buckets[0].CreationDate)).To(BeTemporally("==", now))
+		})
+
+		It("rejects a duplicate bucket", func() {
+			Expect(client.CreateBucket(ctx, "bucket-a")).To(MatchError(simple_s3.ErrBucketAlreadyExists))
+		})
+
+		It("refuses to delete a non-empty bucket directly", func() {
+			put("a.txt", "a")
+
+			_, err := client.Client.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String("bucket-a")})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("BucketNotEmpty"))
+		})
+
+		It("deletes a bucket with its objects", func() {
+			put("a.txt", "a")
+			put("b/c.txt", "c")
+
+			Expect(client.DeleteBucket(ctx, "bucket-a")).To(Succeed())
+			_, err := client.ListObject(ctx, "bucket-a", "")
+			Expect(err).To(MatchError(simple_s3.ErrBucketNotFound))
+		})
+	})
+
+	Describe("objects", func() {
+		It("stores and returns object content and metadata", func() {
+			put("docs/readme.txt", "hello world")
+
+			data, err := client.FetchObject(ctx, "docs/readme.txt", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("hello world"))
+
+			info, err := client.StatObject(ctx, "bucket-a", "docs/readme.txt")
+			Expect(err).NotTo(HaveOccurred())
+			sum := md5.Sum([]byte("hello world"))
+			Expect(info.ETag).To(Equal(hex.EncodeToString(sum[:])))
+			Expect(info.Size).To(Equal(int64(11)))
+			Expect(info.ContentType).To(HavePrefix("text/plain"))
+			Expect(info.LastModified).To(BeTemporally("==", now))
+		})
+
+		It("reports missing objects", func() {
+			_, err := client.FetchObject(ctx, "missing", "bucket-a")
+			Expect(err).To(MatchError(simple_s3.ErrObjectNotFound))
+			_, err = client.StatObject(ctx, "bucket-a", "missing")
+			Expect(err).To(MatchError(simple_s3.ErrObjectNotFound))
+		})
+
+		It("keeps keys that need escaping", func() {
+			put("dir/with space/+plus&amp%.txt", "x")
+
+			keys, err := client.ListObject(ctx, "bucket-a", "dir/")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"dir/with space/+plus&amp%.txt"}))
+		})
+
+		It("serves ranges", func() {
+			put("range.bin", "0123456789")
+
+			data, err := client.FetchRange(ctx, "bucket-a", "range.bin", 3, 4)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("3456"))
+
+			_, err = client.FetchRange(ctx, "bucket-a", "range.bin", 20, 1)
+			Expect(err).To(MatchError(simple_s3.ErrInvalidRange))
+		})
+
+		It("honours conditional writes and reads", func() {
+			put("cas.txt", "v1")
+			info, err := client.StatObject(ctx, "bucket-a", "cas.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.PutObject(ctx, "bucket-a", "cas.txt", bytes.NewReader([]byte("v2")), func(o *types.PutObjectOptions) {
+				o.IfNoneMatch = types.ETagAny
+			})
+			Expect(err).To(MatchError(simple_s3.ErrPreconditionFailed))
+
+			put("cas.txt", "v2", func(o *types.PutObjectOptions) { o.IfMatch = info.ETag })
+
+			_, err = client.FetchObject(ctx, "cas.txt", "bucket-a", func(o *types.FetchObjectOptions) { o.IfMatch = info.ETag })
+			Expect(err).To(MatchError(simple_s3.ErrPreconditionFailed))
+
+			current, err := client.StatObject(ctx, "bucket-a", "cas.txt")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.FetchObject(ctx, "cas.txt", "bucket-a", func(o *types.FetchObjectOptions) { o.IfNoneMatch = current.ETag })
+			Expect(err).To(MatchError(simple_s3.ErrNotModified))
+
+			Expect(client.DeleteObject(ctx, "bucket-a", "cas.txt", func(o *types.DeleteObjectOptions) { o.IfMatch = info.ETag })).
+				To(MatchError(simple_s3.ErrPreconditionFailed))
+			Expect(client.DeleteObject(ctx, "bucket-a", "cas.txt", func(o *types.DeleteObjectOptions) { o.IfMatch = current.ETag })).
+				To(Succeed())
+		})
+
+		DescribeTable("verifies checksums",
+			func(alg types.ChecksumAlgorithm) {
+				put("sum.txt", "checksummed", func(o *types.PutObjectOptions) { o.ChecksumAlgorithm = alg })
+
+				out, err := client.Client.HeadObject(ctx, &s3.HeadObjectInput{
+					Bucket:       aws.String("bucket-a"),
+					Key:          aws.String("sum.txt"),
+					ChecksumMode: s3types.ChecksumModeEnabled,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out.ChecksumType).To(Equal(s3types.ChecksumTypeFullObject))
+
+				data, err := client.FetchObject(ctx, "sum.txt", "bucket-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal("checksummed"))
+			},
+			Entry("CRC32", types.ChecksumCRC32),
+			Entry("CRC32C", types.ChecksumCRC32C),
+			Entry("CRC64NVME", types.ChecksumCRC64NVME),
+			Entry("SHA1", types.ChecksumSHA1),
+			Entry("SHA256", types.ChecksumSHA256),
+		)
+
+		It("rejects a precomputed checksum that does not match", func() {
+			err := client.PutObject(ctx, "bucket-a", "bad.txt", bytes.NewReader([]byte("data")), func(o *types.PutObjectOptions) {
+				o.ChecksumAlgorithm = types.ChecksumSHA256
+				o.Checksum = "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="
+			})
+			Expect(err).To(HaveOccurred())
+			_, err = client.StatObject(ctx, "bucket-a", "bad.txt")
+			Expect(err).To(MatchError(simple_s3.ErrObjectNotFound))
+		})
+
+		It("decodes compressed objects", func() {
+			put("log.txt", "compress me compress me compress me", func(o *types.PutObjectOptions) {
+				o.Compression = types.CompressionGzip
+			})
+
+			data, err := client.FetchObject(ctx, "log.txt", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("compress me compress me compress me"))
+		})
+
+		It("copies objects with their metadata", func() {
+			put("src.json", "{}")
+
+			Expect(client.CopyObject(ctx, "bucket-a", "src.json", "bucket-a", "copies/dst.json")).To(Succeed())
+
+			info, err := client.StatObject(ctx, "bucket-a", "copies/dst.json")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.ContentType).To(Equal("application/json"))
+			data, err := client.FetchObject(ctx, "copies/dst.json", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("{}"))
+		})
+
+		It("stores tags", func() {
+			put("tagged.txt", "t")
+			_, err := client.Client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+				Bucket:  aws.String("bucket-a"),
+				Key:     aws.String("tagged.txt"),
+				Tagging: &s3types.Tagging{TagSet: []s3types.Tag{{Key: aws.String("team"), Value: aws.String("storage")}}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			out, err := client.Client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{Bucket: aws.String("bucket-a"), Key: aws.String("tagged.txt")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.TagSet).To(HaveLen(1))
+			Expect(aws.ToString(out.TagSet[0].Key)).To(Equal("team"))
+			Expect(aws.ToString(out.TagSet[0].Value)).To(Equal("storage"))
+		})
+	})
+
+	Describe("listing", func() {
+		BeforeEach(func() {
+			for _, key := range []string{"a/1", "a/2", "b/1", "c", "d"} {
+				put(key, key)
+			}
+		})
+
+		It("pages through keys", func() {
+			var pages [][]string
+			paginator := s3.NewListObjectsV2Paginator(client.Client, &s3.ListObjectsV2Input{
+				Bucket:  aws.String("bucket-a"),
+				MaxKeys: aws.Int32(2),
+			})
+			for paginator.HasMorePages() {
+				out, err := paginator.NextPage(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				var keys []string
+				for _, obj := range out.Contents {
+					keys = append(keys, aws.ToString(obj.Key))
+				}
+				pages = append(pages, keys)
+			}
+			Expect(pages).To(Equal([][]string{{"a/1", "a/2"}, {"b/1", "c"}, {"d"}}))
+		})
+
+		It("groups keys by delimiter", func() {
+			var prefixes, keys []string
+			paginator := s3.NewListObjectsV2Paginator(client.Client, &s3.ListObjectsV2Input{
+				Bucket:    aws.String("bucket-a"),
+				Delimiter: aws.String("/"),
+				MaxKeys:   aws.Int32(1),
+			})
+			for paginator.HasMorePages() {
+				out, err := paginator.NextPage(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				for _, p := range out.CommonPrefixes {
+					prefixes = append(prefixes, aws.ToString(p.Prefix))
+				}
+				for _, obj := range out.Contents {
+					keys = append(keys, aws.ToString(obj.Key))
+				}
+			}
+			Expect(prefixes).To(Equal([]string{"a/", "b/"}))
+			Expect(keys).To(Equal([]string{"c", "d"}))
+		})
+
+		It("deletes keys in batches", func() {
+			out, err := client.Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String("bucket-a"),
+				Delete: &s3types.Delete{Objects: []s3types.ObjectIdentifier{{Key: aws.String("a/1")}, {Key: aws.String("missing")}}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Deleted).To(HaveLen(2))
+
+			keys, err := client.ListObject(ctx, "bucket-a", "a/")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"a/2"}))
+		})
+	})
+
+	Describe("multipart uploads", func() {
+		var data []byte
+
+		BeforeEach(func() {
+			data = make([]byte, 11<<20)
+			for i := range data {
+				data[i] = byte(i % 251)
+			}
+		})
+
+		It("assembles parts into an object", func() {
+			Expect(client.PutObject(ctx, "bucket-a", "big.bin", bytes.NewReader(data))).To(Succeed())
+
+			info, err := client.StatObject(ctx, "bucket-a", "big.bin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.ETag).To(HaveSuffix("-3"))
+			got, err := client.FetchObject(ctx, "big.bin", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bytes.Equal(got, data)).To(BeTrue())
+		})
+
+		It("verifies ETags with Content-MD5", func() {
+			store := mapStateStore{}
+			err := client.PutObject(ctx, "bucket-a", "md5.bin", bytes.NewReader(data), func(o *types.PutObjectOptions) {
+				o.ContentMD5 = true
+				o.Resumable = true
+				o.StateStore = store
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store).To(BeEmpty())
+		})
+
+		It("stores full-object checksums", func() {
+			put("full.bin", string(data), func(o *types.PutObjectOptions) { o.ChecksumAlgorithm = types.ChecksumCRC64NVME })
+
+			got, err := client.FetchObject(ctx, "full.bin", "bucket-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bytes.Equal(got, data)).To(BeTrue())
+		})
+
+		It("rejects undersized parts", func() {
+			out, err := client.Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{Bucket: aws.String("bucket-a"), Key: aws.String("small")})
+			Expect(err).NotTo(HaveOccurred())
+			var parts []s3types.CompletedPart
+			for n := int32(1); n <= 2; n++ {
+				part, err := client.Client.UploadPart(ctx, &s3.UploadPartInput{
+					Bucket:     aws.String("bucket-a"),
+					Key:        aws.String("small"),
+					UploadId:   out.UploadId,
+					PartNumber: aws.Int32(n),
+					Body:       bytes.NewReader([]byte("tiny")),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				parts = append(parts, s3types.CompletedPart{PartNumber: aws.Int32(n), ETag: part.ETag})
+			}
+
+			_, err = client.Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+				Bucket:          aws.String("bucket-a"),
+				Key:             aws.String("small"),
+				UploadId:        out.UploadId,
+				MultipartUpload: &s3types.CompletedMultipartUpload{Parts: parts},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("EntityTooSmall"))
+		})
+
+		It("lists and aborts incomplete uploads", func() {
+			for _, key := range []string{"tmp/a", "tmp/b", "keep"} {
+				_, err := client.Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{Bucket: aws.String("bucket-a"), Key: aws.String(key)})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			uploads, err := client.ListMultipartUploads(ctx, "bucket-a", "tmp/")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uploads).To(HaveLen(2))
+			Expect(uploads[0].Key).To(Equal("tmp/a"))
+			Expect(uploads[0].Initiated).To(BeTemporally("==", now))
+
+			now = now.Add(48 * time.Hour)
+			aborted, err := client.AbortStaleMultipartUploads(ctx, "bucket-a", 24*time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aborted).To(HaveLen(3))
+
+			uploads, err = client.ListMultipartUploads(ctx, "bucket-a", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uploads).To(BeEmpty())
+		})
+
+		It("reports unknown uploads", func() {
+			_, err := client.Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   aws.String("bucket-a"),
+				Key:      aws.String("missing"),
+				UploadId: aws.String("nope"),
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NoSuchUpload"))
+		})
+	})
+
+	Describe("directories", func() {
+		It("syncs and downloads a tree", func() {
+			src := GinkgoT().TempDir()
+			for name, content := range map[string]string{"index.html": "<html></html>", "css/site.css": "body{}"} {
+				p := filepath.Join(src, filepath.FromSlash(name))
+				Expect(os.MkdirAll(filepath.Dir(p), 0o755)).To(Succeed())
+				Expect(os.WriteFile(p, []byte(content), 0o644)).To(Succeed())
+			}
+
+			result, err := client.Sync(ctx, src, "bucket-a", "site")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Operations).To(HaveLen(2))
+
+			result, err = client.Sync(ctx, src, "bucket-a", "site", func(o *types.SyncOptions) { o.Checksum = true })
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Operations).To(BeEmpty())
+			Expect(result.Unchanged).To(Equal(2))
+
+			// Without checksums, files written after the server's fixed clock always look modified.
+			earlier := now.Add(-time.Hour)
+			for _, name := range []string{"index.html", "css/site.css"} {
+				Expect(os.Chtimes(filepath.Join(src, filepath.FromSlash(name)), earlier, earlier)).To(Succeed())
+			}
+			compress := func(o *types.SyncOptions) {
+				o.ObjectOptions = append(o.ObjectOptions, func(o *types.PutObjectOptions) { o.Compression = types.CompressionGzip })
+			}
+			result, err = client.Sync(ctx, src, "bucket-a", "compressed", compress)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Operations).To(HaveLen(2))
+			for _, checksum := range []bool{false, true} {
+				result, err = client.Sync(ctx, src, "bucket-a", "compressed", compress, func(o *types.SyncOptions) { o.Checksum = checksum })
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Operations).To(BeEmpty())
+			}
+
+			dst := GinkgoT().TempDir()
+			summary, err := client.DownloadPrefix(ctx, "bucket-a", "site", dst)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summary.Transferred).To(HaveLen(2))
+			got, err := os.ReadFile(filepath.Join(dst, "css", "site.css"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(got)).To(Equal("body{}"))
+		})
+	})
+
+	It("returns errors with request IDs", func() {
+		_, err := client.FetchObject(ctx, "missing", "bucket-a")
+		var s3Err *simple_s3.Error
+		Expect(err).To(BeAssignableToTypeOf(s3Err))
+		Expect(err.(*simple_s3.Error).RequestID).NotTo(BeEmpty())
+		Expect(fmt.Sprint(err)).To(ContainSubstring("missing"))
+	})
+})
@@ -0,0 +1,13 @@
+package s3test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestS3test(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "S3test Suite")
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// UploadDirectoryOptions configures an UploadDirectory call.
+type UploadDirectoryOptions struct {
+	// Include limits the upload to files matching at least one glob pattern. Patterns containing a
+	// slash match the path relative to the directory, where "**" matches any number of directories;
+	// other patterns match the file name at any depth. Empty includes every file.
+	Include []string
+	// Exclude skips files matching any glob pattern, and directories matching one are not walked.
+	// Exclusions take precedence over Include.
+	Exclude []string
+	// FollowSymlinks uploads the targets of symbolic links, walking linked directories once for
+	// each link to them. Links back to a directory being walked are skipped. Otherwise links are
+	// skipped.
+	FollowSymlinks bool
+	// Concurrency is the number of files uploaded in parallel. Zero uses 5.
+	Concurrency int
+	// ObjectOptions are applied to the PutObject call made for every file.
+	ObjectOptions []func(*PutObjectOptions)
+}
+
+// DownloadPrefixOptions configures a DownloadPrefix call.
+type DownloadPrefixOptions struct {
+	// Concurrency is the number of objects downloaded in parallel. Zero uses 5.
+	Concurrency int
+	// PartSize is the part size used to compare existing files with the ETags of objects uploaded in
+	// parts. Zero uses the client's multipart part size.
+	PartSize int64
+}
+
+// FileTransfer describes a file copied to or from a bucket.
+type FileTransfer struct {
+	// Path is the local file path.
+	Path string
+	// Key is the object key.
+	Key string
+	// Size is the size of the file in bytes.
+	Size int64
+	// Err is the reason the transfer failed, or nil.
+	Err error
+}
+
+// TransferSummary reports the outcome of transferring a set of files.
+type TransferSummary struct {
+	// Transferred lists the files copied successfully.
+	Transferred []FileTransfer
+	// Failed lists the files that could not be copied and the directories that could not be read,
+	// with the reason in Err.
+	Failed []FileTransfer
+	// Skipped lists the files left out by filters, symbolic links that were not followed and files
+	// that were already up to date.
+	Skipped []FileTransfer
+	// Bytes is the total size of the transferred files.
+	Bytes int64
+}
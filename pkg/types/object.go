@@ -0,0 +1,49 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "time"
+
+// ObjectInfo describes a stored object without its content.
+type ObjectInfo struct {
+	// Bucket is the bucket holding the object.
+	Bucket string `json:"bucket"`
+	// Key is the object key.
+	Key string `json:"key"`
+	// Size is the stored size in bytes.
+	Size int64 `json:"size"`
+	// ETag is the entity tag of the object, without quotes.
+	ETag string `json:"etag"`
+	// LastModified is when the object was last written.
+	LastModified time.Time `json:"lastModified"`
+	// ContentType is the stored Content-Type.
+	ContentType string `json:"contentType,omitempty"`
+	// ContentEncoding is the stored Content-Encoding.
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	// StorageClass is the storage class, empty for the default class.
+	StorageClass string `json:"storageClass,omitempty"`
+	// Metadata holds the user metadata with lower-case keys.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// PresignOptions configures a presigned URL.
+type PresignOptions struct {
+	// Method is the HTTP method the URL permits, GET or PUT. Empty uses GET.
+	Method string
+	// Expires is how long the URL stays valid. Zero uses 15 minutes.
+	Expires time.Duration
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package types holds the option and result types shared by the S3 wrapper and S3Interface.
+package types
+
+import "time"
+
+// ETagAny matches any existing object when used as a condition. Passing it as
+// PutObjectOptions.IfNoneMatch makes the upload create-only.
+const ETagAny = "*"
+
+// ChecksumAlgorithm names an algorithm used to verify the integrity of object data.
+type ChecksumAlgorithm string
+
+// Checksum algorithms supported by S3.
+const (
+	ChecksumCRC32     ChecksumAlgorithm = "CRC32"
+	ChecksumCRC32C    ChecksumAlgorithm = "CRC32C"
+	ChecksumCRC64NVME ChecksumAlgorithm = "CRC64NVME"
+	ChecksumSHA1      ChecksumAlgorithm = "SHA1"
+	ChecksumSHA256    ChecksumAlgorithm = "SHA256"
+)
+
+// Compression names a codec used to compress object data. It is stored as the object's
+// Content-Encoding.
+type Compression string
+
+// Compression codecs supported for uploads and decoded on download.
+const (
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// MultipartOptions configures how uploads are split into parts.
+//
+// Zero values fall back to the client-level setting and then to the library defaults.
+type MultipartOptions struct {
+	// PartSize is the size of each uploaded part in bytes. It is kept between the 5 MiB and 5 GiB S3
+	// limits, and scaled up automatically when an upload of known size would need more than 10,000 parts.
+	PartSize int64
+	// Threshold is the size in bytes below which objects of known size are uploaded in a single request.
+	// It is capped at 5 GiB, the largest object S3 accepts in one request, and a larger PartSize is
+	// lowered to it, though not below the 5 MiB minimum.
+	Threshold int64
+	// Concurrency is the number of parts uploaded in parallel.
+	Concurrency int
+}
+
+// PutObjectOptions configures a single PutObject call.
+type PutObjectOptions struct {
+	// ContentType is stored as the object's Content-Type. When empty it is derived from the key's
+	// extension, falling back to sniffing the first 512 bytes of the content.
+	ContentType string
+	// IfMatch only stores the object if the current object's ETag matches, enabling compare-and-swap.
+	IfMatch string
+	// IfNoneMatch only stores the object if no current object matches. Use ETagAny to create only.
+	IfNoneMatch string
+	// ContentLength is the expected size of a streamed body, used to scale the part size. It is
+	// ignored for seekable bodies, whose size is measured directly.
+	ContentLength int64
+	// Multipart overrides the client-level multipart settings for this upload.
+	Multipart MultipartOptions
+	// Resumable records multipart progress so a failed upload of the same bucket and key resumes
+	// where it stopped instead of starting again. It applies to PutObject only.
+	Resumable bool
+	// StateStore persists resumable upload progress. Nil uses a file store in the user cache directory.
+	StateStore UploadStateStore
+	// Progress is called as data is uploaded and each part completes.
+	Progress ProgressListener
+	// ChecksumAlgorithm is the algorithm used to checksum the upload and stored with the object.
+	// Zero uses CRC32.
+	ChecksumAlgorithm ChecksumAlgorithm
+	// Checksum is a precomputed base64 checksum of the whole object using ChecksumAlgorithm, which
+	// must be set explicitly. The service rejects the upload if the data does not match. Multipart
+	// uploads store it as a full-object checksum, which only the CRC algorithms support.
+	Checksum string
+	// ContentMD5 sends a Content-MD5 header with single-part uploads and resumable upload parts, and
+	// verifies the returned ETag against the MD5 digest of the data, including the "md5-of-md5s-N"
+	// ETag of multipart uploads. It suits S3-compatible stores without flexible checksums. Objects
+	// encrypted with SSE-KMS or SSE-C have ETags that are not MD5 digests and cannot be verified.
+	ContentMD5 bool
+	// Compression compresses the body before it is uploaded and records the codec as the object's
+	// Content-Encoding, along with the uncompressed size when it is known. The compressed length is
+	// not known up front, so the body is streamed and cannot be resumed or carry a precomputed
+	// Checksum.
+	Compression Compression
+}
+
+// FetchObjectOptions configures a single FetchObject call.
+type FetchObjectOptions struct {
+	// IfMatch only returns the object if its ETag matches.
+	IfMatch string
+	// IfNoneMatch only returns the object if its ETag differs.
+	IfNoneMatch string
+	// IfModifiedSince only returns the object if it changed after this time.
+	IfModifiedSince time.Time
+	// IfUnmodifiedSince only returns the object if it has not changed since this time.
+	IfUnmodifiedSince time.Time
+	// Progress is called as the object body is downloaded.
+	Progress ProgressListener
+	// DisableChecksumValidation skips verifying the downloaded data against the checksum stored
+	// with the object, and with it the GetObjectAttributes requests made for objects uploaded in parts.
+	DisableChecksumValidation bool
+	// DisableDecompression returns the stored bytes of objects with a gzip or zstd Content-Encoding
+	// instead of decoding them.
+	DisableDecompression bool
+}
+
+// DeleteObjectOptions configures a single DeleteObject call.
+type DeleteObjectOptions struct {
+	// IfMatch only deletes the object if its ETag matches.
+	IfMatch string
+}
+
+// ReaderAtOptions configures an object reader created by ReaderAt.
+type ReaderAtOptions struct {
+	// BlockSize is the size of each ranged GET issued when caching. Zero disables caching and each
+	// ReadAt fetches exactly the bytes requested.
+	BlockSize int64
+	// CacheBlocks is the number of most recently used blocks kept in memory. Zero with a non-zero
+	// BlockSize keeps a single block.
+	CacheBlocks int
+}
+
+// OpenObjectOptions configures an object handle created by OpenObject.
+type OpenObjectOptions struct {
+	// ReadAheadSize is the number of bytes buffered ahead of sequential reads. Zero uses 64 KiB.
+	ReadAheadSize int
+	// ReaderAt configures reads made through the handle's ReadAt method.
+	ReaderAt ReaderAtOptions
+	// Progress is called as data is returned by Read. Reads through ReadAt are not reported.
+	Progress ProgressListener
+	// DisableDecompression reads the stored bytes of objects with a gzip or zstd Content-Encoding
+	// instead of decoding them.
+	DisableDecompression bool
+}
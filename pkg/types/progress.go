@@ -0,0 +1,48 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "time"
+
+// ProgressEvent reports how far a transfer has got.
+type ProgressEvent struct {
+	// Bucket is the bucket being transferred to or from.
+	Bucket string
+	// Key is the object key being transferred.
+	Key string
+	// BytesTransferred is the number of bytes transferred so far.
+	BytesTransferred int64
+	// TotalBytes is the size of the transfer, or -1 if it is not known.
+	TotalBytes int64
+	// PartsCompleted is the number of parts uploaded so far. It is zero for downloads.
+	PartsCompleted int
+	// TotalParts is the number of parts in the upload, or 0 if it is not known.
+	TotalParts int
+	// Elapsed is the time since the transfer started.
+	Elapsed time.Duration
+	// BytesPerSecond is the average throughput since the transfer started.
+	BytesPerSecond float64
+	// Done is set on the final event of a successful transfer.
+	Done bool
+}
+
+// ProgressListener receives progress events for a transfer.
+//
+// Calls for a single transfer are serialised, so a listener does not need to be safe for concurrent
+// use even when parts are transferred in parallel. Listeners should return quickly as they delay
+// the transfer.
+type ProgressListener func(ProgressEvent)
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "context"
+
+// ReplicateOptions configures a Replicate call.
+type ReplicateOptions struct {
+	// Prefix limits replication to source keys with this prefix.
+	Prefix string
+	// Concurrency is the number of objects copied in parallel. Zero uses 5.
+	Concurrency int
+	// DisableServerSideCopy streams every object through the client, for destinations on the same
+	// endpoint whose credentials cannot read the source bucket.
+	DisableServerSideCopy bool
+	// CheckpointStore records progress so an interrupted run resumes after the last key copied. Nil
+	// disables checkpointing and every object is copied.
+	CheckpointStore CheckpointStore
+}
+
+// ReplicationCheckpoint is the persisted progress of a Replicate call. Every key up to and including
+// LastKey has been copied.
+type ReplicationCheckpoint struct {
+	// SourceBucket is the bucket copied from.
+	SourceBucket string `json:"sourceBucket"`
+	// DestinationBucket is the bucket copied to.
+	DestinationBucket string `json:"destinationBucket"`
+	// Prefix is the source key prefix being replicated.
+	Prefix string `json:"prefix,omitempty"`
+	// LastKey is the last source key copied.
+	LastKey string `json:"lastKey"`
+}
+
+// CheckpointStore persists replication checkpoints between runs.
+//
+// Implementations must be safe for concurrent use.
+type CheckpointStore interface {
+	// Load returns the checkpoint stored under id, or nil if there is none.
+	Load(ctx context.Context, id string) (*ReplicationCheckpoint, error)
+	// Save stores checkpoint under id, replacing any previous checkpoint.
+	Save(ctx context.Context, id string, checkpoint *ReplicationCheckpoint) error
+	// Delete removes the checkpoint stored under id. Deleting a missing checkpoint is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// ObjectCopyError records an object that could not be replicated.
+type ObjectCopyError struct {
+	// Key is the source key.
+	Key string
+	// Err is the reason the copy failed.
+	Err error
+}
+
+// ReplicateResult reports the outcome of a Replicate call.
+type ReplicateResult struct {
+	// Copied is the number of objects copied.
+	Copied int
+	// Bytes is the total size of the objects copied.
+	Bytes int64
+	// Skipped is the number of objects already copied by an earlier run, according to the checkpoint.
+	Skipped int
+	// Failed lists the objects that could not be copied.
+	Failed []ObjectCopyError
+	// ServerSide reports whether objects were copied within the service rather than streamed.
+	ServerSide bool
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "fmt"
+
+// SyncOptions configures a Sync call.
+type SyncOptions struct {
+	// Include, Exclude and FollowSymlinks select local files as for UploadDirectory. Remote keys
+	// that the filters would leave out are never deleted.
+	Include        []string
+	Exclude        []string
+	FollowSymlinks bool
+	// Checksum compares file contents with object ETags instead of modification times, detecting
+	// changes that keep the size and time but costing a read of every file of matching size.
+	Checksum bool
+	// Delete removes objects under the prefix that have no corresponding local file.
+	Delete bool
+	// DryRun plans the changes without making them.
+	DryRun bool
+	// Concurrency is the number of files compared and transferred in parallel. Zero uses 5.
+	Concurrency int
+	// ObjectOptions are applied to the PutObject call made for every uploaded file.
+	ObjectOptions []func(*PutObjectOptions)
+}
+
+// SyncAction is a change made by Sync.
+type SyncAction string
+
+// Actions planned by Sync.
+const (
+	SyncUpload SyncAction = "upload"
+	SyncDelete SyncAction = "delete"
+)
+
+// SyncOperation is a single planned change.
+type SyncOperation struct {
+	// Action is the change to make.
+	Action SyncAction
+	// Path is the local file uploaded, empty for deletions.
+	Path string
+	// Key is the object key written or deleted.
+	Key string
+	// Size is the size of the local file, or of the object being deleted.
+	Size int64
+	// Reason explains why the change is needed.
+	Reason string
+	// Err is the reason the change failed, or nil. It is always nil in a dry run.
+	Err error
+}
+
+// String formats the operation as a line of a plan.
+func (o SyncOperation) String() string {
+	return fmt.Sprintf("%-6s %s (%s)", o.Action, o.Key, o.Reason)
+}
+
+// SyncResult reports the changes planned and made by Sync.
+type SyncResult struct {
+	// Operations lists the planned changes, uploads before deletions and each ordered by key.
+	Operations []SyncOperation
+	// Unchanged is the number of files already up to date.
+	Unchanged int
+	// Bytes is the total size of the files uploaded, or that would be in a dry run.
+	Bytes int64
+	// DryRun reports that no changes were made.
+	DryRun bool
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// UploadPart records a part of a multipart upload that has been stored.
+type UploadPart struct {
+	// PartNumber is the 1-based position of the part.
+	PartNumber int32 `json:"partNumber"`
+	// ETag is the entity tag returned when the part was uploaded.
+	ETag string `json:"etag"`
+	// Size is the size of the part in bytes.
+	Size int64 `json:"size"`
+	// Checksum is the base64 checksum of the part using the upload's checksum algorithm.
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// UploadState is the persisted progress of a resumable multipart upload.
+type UploadState struct {
+	// Bucket is the destination bucket.
+	Bucket string `json:"bucket"`
+	// Key is the destination object key.
+	Key string `json:"key"`
+	// UploadID identifies the multipart upload.
+	UploadID string `json:"uploadId"`
+	// Size is the total size of the object in bytes.
+	Size int64 `json:"size"`
+	// PartSize is the size of every part except the last.
+	PartSize int64 `json:"partSize"`
+	// ChecksumAlgorithm is the algorithm used to checksum each part.
+	ChecksumAlgorithm ChecksumAlgorithm `json:"checksumAlgorithm,omitempty"`
+	// Parts lists the parts uploaded so far.
+	Parts []UploadPart `json:"parts"`
+}
+
+// UploadStateStore persists resumable upload state between attempts.
+//
+// Implementations must be safe for concurrent use.
+type UploadStateStore interface {
+	// Load returns the state stored under id, or nil if there is none.
+	Load(ctx context.Context, id string) (*UploadState, error)
+	// Save stores state under id, replacing any previous state.
+	Save(ctx context.Context, id string, state *UploadState) error
+	// Delete removes the state stored under id. Deleting missing state is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// MultipartUpload describes a multipart upload that has been started but not completed or aborted.
+type MultipartUpload struct {
+	// Key is the object key the upload will create.
+	Key string
+	// UploadID identifies the multipart upload.
+	UploadID string
+	// Initiated is when the upload was started.
+	Initiated time.Time
+}
@@ -19,8 +19,11 @@ package util
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=../mock/interfaces.go -package=mock
@@ -34,11 +37,35 @@ type S3Interface interface {
 	// DeleteBucket deletes a bucket and any objects it contains.
 	DeleteBucket(context.Context, string) error
 	// FetchObject reads and returns the full object content.
-	FetchObject(context.Context, string, string) ([]byte, error)
+	FetchObject(context.Context, string, string, ...func(*types.FetchObjectOptions)) ([]byte, error)
+	// DownloadObject streams the object content to a writer.
+	DownloadObject(context.Context, string, string, io.Writer, ...func(*types.FetchObjectOptions)) (int64, error)
+	// FetchRange reads part of an object.
+	FetchRange(context.Context, string, string, int64, int64) ([]byte, error)
 	// PutObject uploads data to the provided bucket and key.
-	PutObject(context.Context, string, string, io.ReadSeeker) error
+	PutObject(context.Context, string, string, io.ReadSeeker, ...func(*types.PutObjectOptions)) error
+	// PutObjectStream uploads data of unknown length from a non-seekable reader.
+	PutObjectStream(context.Context, string, string, io.Reader, ...func(*types.PutObjectOptions)) error
 	// ListObject lists object keys in a bucket filtered by prefix.
 	ListObject(context.Context, string, string) ([]string, error)
+	// IsBucketEmpty reports whether a bucket holds no objects.
+	IsBucketEmpty(context.Context, string) (bool, error)
 	// DeleteObject deletes a single object key from a bucket.
-	DeleteObject(context.Context, string, string) error
+	DeleteObject(context.Context, string, string, ...func(*types.DeleteObjectOptions)) error
+	// StatObject returns the size, ETag and headers of an object.
+	StatObject(context.Context, string, string) (*types.ObjectInfo, error)
+	// CopyObject copies an object within the service.
+	CopyObject(context.Context, string, string, string, string) error
+	// PresignObject returns a URL granting temporary access to an object.
+	PresignObject(context.Context, string, string, ...func(*types.PresignOptions)) (string, error)
+	// ListMultipartUploads lists incomplete multipart uploads in a bucket filtered by key prefix.
+	ListMultipartUploads(context.Context, string, string) ([]types.MultipartUpload, error)
+	// AbortStaleMultipartUploads aborts incomplete multipart uploads older than the given age.
+	AbortStaleMultipartUploads(context.Context, string, time.Duration) ([]types.MultipartUpload, error)
+	// UploadDirectory uploads the files below a local directory to keys under a prefix.
+	UploadDirectory(context.Context, string, string, string, ...func(*types.UploadDirectoryOptions)) (*types.TransferSummary, error)
+	// DownloadPrefix downloads the objects under a prefix into a local directory.
+	DownloadPrefix(context.Context, string, string, string, ...func(*types.DownloadPrefixOptions)) (*types.TransferSummary, error)
+	// Sync uploads new and changed files below a local directory to a prefix.
+	Sync(context.Context, string, string, string, ...func(*types.SyncOptions)) (*types.SyncResult, error)
 }
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// defaultPresignExpiry is how long presigned URLs stay valid when no expiry is given.
+const defaultPresignExpiry = 15 * time.Minute
+
+// PresignObject returns a URL granting temporary access to an object without credentials, for
+// downloading it with GET or uploading it with PUT. It requires a client backed by an *s3.Client.
+func (s *S3) PresignObject(ctx context.Context, bucket, key string, optFns ...func(*types.PresignOptions)) (_ string, err error) {
+	ctx, call := s.startOperation(ctx, "PresignObject", bucket, key)
+	defer func() { call.end(ctx, err) }()
+
+	o := types.PresignOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if s.Client == nil {
+		return "", newError("PresignObject", bucket, key, errors.New("presigning requires an *s3.Client"))
+	}
+
+	expires := o.Expires
+	if expires <= 0 {
+		expires = defaultPresignExpiry
+	}
+	client := s3.NewPresignClient(s.Client, s3.WithPresignExpires(expires))
+
+	var req *v4.PresignedHTTPRequest
+	switch method := strings.ToUpper(o.Method); method {
+	case "", http.MethodGet:
+		req, err = client.PresignGetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	case http.MethodPut:
+		req, err = client.PresignPutObject(ctx, &s3.PutObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	default:
+		err = fmt.Errorf("cannot presign %s requests", method)
+	}
+	if err != nil {
+		return "", newError("PresignObject", bucket, key, err)
+	}
+	return req.URL, nil
+}
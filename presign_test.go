@@ -0,0 +1,73 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("PresignObject", func() {
+	var sut *S3
+
+	BeforeEach(func() {
+		var err error
+		sut, err = New(context.Background(), "http://example.local:9000", "ak", "sk", "eu-west-1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("presigns a GET request with the default expiry", func() {
+		raw, err := sut.PresignObject(context.Background(), "bucket-a", "dir/key-a")
+		Expect(err).NotTo(HaveOccurred())
+
+		u, err := url.Parse(raw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.Host).To(Equal("example.local:9000"))
+		Expect(u.Path).To(Equal("/bucket-a/dir/key-a"))
+		Expect(u.Query().Get("X-Amz-Expires")).To(Equal("900"))
+		Expect(u.Query().Get("X-Amz-Credential")).To(HavePrefix("ak/"))
+		Expect(u.Query().Get("X-Amz-Signature")).NotTo(BeEmpty())
+	})
+
+	It("presigns a PUT request with a custom expiry", func() {
+		get, err := sut.PresignObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		put, err := sut.PresignObject(context.Background(), "bucket-a", "key-a", func(o *types.PresignOptions) {
+			o.Method = "put"
+			o.Expires = time.Hour
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		u, err := url.Parse(put)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.Query().Get("X-Amz-Expires")).To(Equal("3600"))
+		Expect(put).NotTo(Equal(get))
+	})
+
+	It("rejects other methods", func() {
+		_, err := sut.PresignObject(context.Background(), "bucket-a", "key-a", func(o *types.PresignOptions) {
+			o.Method = "DELETE"
+		})
+		Expect(err).To(MatchError(ContainSubstring("cannot presign DELETE requests")))
+	})
+})
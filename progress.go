@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// progressTracker turns transfer activity into events for a ProgressListener, serialising calls from
+// concurrent workers. A nil tracker ignores all updates so callers need not check for a listener.
+type progressTracker struct {
+	mu       sync.Mutex
+	listener types.ProgressListener
+	event    types.ProgressEvent
+	start    time.Time
+}
+
+// newProgressTracker returns a tracker for a transfer of total bytes in parts, or nil if listener is nil.
+func newProgressTracker(listener types.ProgressListener, bucket, key string, total int64, parts int) *progressTracker {
+	if listener == nil {
+		return nil
+	}
+	return &progressTracker{
+		listener: listener,
+		event: types.ProgressEvent{
+			Bucket:     bucket,
+			Key:        key,
+			TotalBytes: total,
+			TotalParts: parts,
+		},
+		start: time.Now(),
+	}
+}
+
+// transferred reports n more bytes transferred.
+func (p *progressTracker) transferred(n int64) {
+	p.emit(func(e *types.ProgressEvent) {
+		e.BytesTransferred += n
+	})
+}
+
+// partCompleted reports a part of n bytes completed.
+func (p *progressTracker) partCompleted(n int64) {
+	p.emit(func(e *types.ProgressEvent) {
+		e.BytesTransferred += n
+		e.PartsCompleted++
+	})
+}
+
+// resumed reports parts totalling n bytes that were already stored by an earlier attempt.
+func (p *progressTracker) resumed(parts int, n int64) {
+	p.emit(func(e *types.ProgressEvent) {
+		e.BytesTransferred += n
+		e.PartsCompleted += parts
+	})
+}
+
+// done reports the transfer finished successfully.
+func (p *progressTracker) done() {
+	p.emit(func(e *types.ProgressEvent) {
+		if e.TotalBytes < 0 {
+			e.TotalBytes = e.BytesTransferred
+		}
+		if e.TotalParts == 0 {
+			e.TotalParts = e.PartsCompleted
+		}
+		e.Done = true
+	})
+}
+
+func (p *progressTracker) emit(update func(*types.ProgressEvent)) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	update(&p.event)
+	p.event.Elapsed = time.Since(p.start)
+	if secs := p.event.Elapsed.Seconds(); secs > 0 {
+		p.event.BytesPerSecond = float64(p.event.BytesTransferred) / secs
+	}
+	p.listener(p.event)
+}
+
+// OnObjectBytesTransferred receives part completions from the transfer manager. Its byte count is
+// cumulative and events from concurrent parts can arrive out of order, so only increases are applied.
+func (p *progressTracker) OnObjectBytesTransferred(_ context.Context, e *transfermanager.ObjectBytesTransferredEvent) {
+	p.emit(func(event *types.ProgressEvent) {
+		event.BytesTransferred = max(event.BytesTransferred, e.BytesTransferred)
+		event.PartsCompleted++
+	})
+}
+
+// OnObjectTransferComplete receives the end of a successful transfer from the transfer manager.
+func (p *progressTracker) OnObjectTransferComplete(_ context.Context, _ *transfermanager.ObjectTransferCompleteEvent) {
+	p.done()
+}
+
+// progressReader reports bytes read from r to a tracker.
+type progressReader struct {
+	r        io.Reader
+	progress *progressTracker
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.progress.transferred(int64(n))
+	}
+	return n, err
+}
+
+// partCount returns the number of parts an upload of size bytes is split into, or 0 if unknown.
+func partCount(m types.MultipartOptions, size, partSize int64) int {
+	switch {
+	case size < 0:
+		return 0
+	case size < m.Threshold:
+		return 1
+	default:
+		return int((size + partSize - 1) / partSize)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("Progress", func() {
+	var (
+		sut    *S3
+		api    *stubAPI
+		events []types.ProgressEvent
+		record types.ProgressListener
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		events = nil
+		// Appending without a lock relies on the tracker serialising calls.
+		record = func(e types.ProgressEvent) {
+			events = append(events, e)
+		}
+	})
+
+	It("serialises updates from concurrent workers", func() {
+		progress := newProgressTracker(record, "bucket-a", "key-a", 800, 8)
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				progress.partCompleted(100)
+			}()
+		}
+		wg.Wait()
+		progress.done()
+
+		Expect(events).To(HaveLen(9))
+		for i := 1; i < len(events); i++ {
+			Expect(events[i].BytesTransferred).To(BeNumerically(">=", events[i-1].BytesTransferred))
+		}
+		last := events[len(events)-1]
+		Expect(last.BytesTransferred).To(Equal(int64(800)))
+		Expect(last.PartsCompleted).To(Equal(8))
+		Expect(last.TotalParts).To(Equal(8))
+		Expect(last.Done).To(BeTrue())
+		Expect(last.Bucket).To(Equal("bucket-a"))
+	})
+
+	It("ignores updates when no listener is set", func() {
+		progress := newProgressTracker(nil, "bucket-a", "key-a", 10, 1)
+		Expect(progress).To(BeNil())
+		progress.partCompleted(10)
+		progress.done()
+	})
+
+	It("reports the final size of transfers of unknown length", func() {
+		progress := newProgressTracker(record, "bucket-a", "key-a", -1, 0)
+		progress.partCompleted(7)
+		progress.partCompleted(3)
+		progress.done()
+
+		Expect(events[1].TotalBytes).To(Equal(int64(-1)))
+		Expect(events[2].TotalBytes).To(Equal(int64(10)))
+		Expect(events[2].TotalParts).To(Equal(2))
+	})
+
+	It("bridges transfer manager events for PutObject", func() {
+		fakeClient := &fakeTransferManager{}
+		sut.transfer = fakeClient
+
+		payload := bytes.Repeat([]byte("a"), 12*mib)
+		err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+			o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}
+			o.Progress = record
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		listeners := fakeClient.uploadOptions.ObjectProgressListeners
+		Expect(listeners.ObjectBytesTransferred).To(HaveLen(1))
+		Expect(listeners.ObjectTransferComplete).To(HaveLen(1))
+
+		// Concurrent parts can report cumulative totals out of order.
+		listeners.ObjectBytesTransferred[0].OnObjectBytesTransferred(context.Background(), &transfermanager.ObjectBytesTransferredEvent{BytesTransferred: 10 * mib})
+		listeners.ObjectBytesTransferred[0].OnObjectBytesTransferred(context.Background(), &transfermanager.ObjectBytesTransferredEvent{BytesTransferred: 5 * mib})
+		listeners.ObjectBytesTransferred[0].OnObjectBytesTransferred(context.Background(), &transfermanager.ObjectBytesTransferredEvent{BytesTransferred: 12 * mib})
+		listeners.ObjectTransferComplete[0].OnObjectTransferComplete(context.Background(), &transfermanager.ObjectTransferCompleteEvent{})
+
+		Expect(events).To(HaveLen(4))
+		Expect(events[1].BytesTransferred).To(Equal(int64(10 * mib)))
+		Expect(events[1].PartsCompleted).To(Equal(2))
+		last := events[3]
+		Expect(last.BytesTransferred).To(Equal(int64(12 * mib)))
+		Expect(last.TotalBytes).To(Equal(int64(12 * mib)))
+		Expect(last.PartsCompleted).To(Equal(3))
+		Expect(last.TotalParts).To(Equal(3))
+		Expect(last.Done).To(BeTrue())
+	})
+
+	It("does not register a listener when none is set", func() {
+		fakeClient := &fakeTransferManager{}
+		sut.transfer = fakeClient
+
+		Expect(sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader([]byte("hello")))).To(Succeed())
+		Expect(fakeClient.uploadOptions.ObjectProgressListeners.ObjectBytesTransferred).To(BeEmpty())
+	})
+
+	It("counts parts stored by an earlier attempt when resuming", func() {
+		fake := &fakeMultipart{}
+		fake.install(api)
+		store := &memStateStore{}
+		payload := bytes.Repeat([]byte("b"), 12*mib)
+		Expect(store.Save(context.Background(), "bucket-a/key-a", &types.UploadState{
+			Bucket: "bucket-a", Key: "key-a", UploadID: "upload-0", Size: int64(len(payload)), PartSize: 5 * mib,
+		})).To(Succeed())
+		fake.uploads["upload-0"] = map[int32][]byte{1: payload[:5*mib]}
+
+		err := sut.PutObject(context.Background(), "bucket-a", "key-a", bytes.NewReader(payload), func(o *types.PutObjectOptions) {
+			o.Resumable = true
+			o.StateStore = store
+			o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}
+			o.Progress = record
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(events[0].BytesTransferred).To(Equal(int64(5 * mib)))
+		Expect(events[0].PartsCompleted).To(Equal(1))
+		last := events[len(events)-1]
+		Expect(last.BytesTransferred).To(Equal(int64(12 * mib)))
+		Expect(last.PartsCompleted).To(Equal(3))
+		Expect(last.Done).To(BeTrue())
+	})
+
+	It("reports download progress for FetchObject", func() {
+		payload := bytes.Repeat([]byte("c"), 100*1024)
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			return &s3.GetObjectOutput{
+				Body:          io.NopCloser(bytes.NewReader(payload)),
+				ContentLength: aws.Int64(int64(len(payload))),
+			}, nil
+		}
+
+		data, err := sut.FetchObject(context.Background(), "key-a", "bucket-a", func(o *types.FetchObjectOptions) {
+			o.Progress = record
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(payload))
+
+		last := events[len(events)-1]
+		Expect(last.BytesTransferred).To(Equal(int64(len(payload))))
+		Expect(last.TotalBytes).To(Equal(int64(len(payload))))
+		Expect(last.Done).To(BeTrue())
+	})
+
+	It("reports bytes returned by OpenObject reads", func() {
+		payload := []byte("hello progress")
+		api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+			return &s3.HeadObjectOutput{ContentLength: aws.Int64(int64(len(payload))), ETag: aws.String("\"e\"")}, nil
+		}
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(payload))}, nil
+		}
+
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a", func(o *types.OpenObjectOptions) {
+			o.Progress = record
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		_, err = io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		last := events[len(events)-1]
+		Expect(last.BytesTransferred).To(Equal(int64(len(payload))))
+		Expect(last.TotalBytes).To(Equal(int64(len(payload))))
+		Expect(last.Done).To(BeTrue())
+	})
+})
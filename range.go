@@ -0,0 +1,280 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// FetchRange downloads part of an object.
+//
+// If length is positive, up to length bytes starting at offset are returned. If length is zero or
+// negative, everything from offset to the end of the object is returned. A negative offset returns
+// the last -offset bytes of the object and length is ignored.
+func (s *S3) FetchRange(ctx context.Context, bucket, key string, offset, length int64) (_ []byte, err error) {
+	ctx, call := s.startOperation(ctx, "FetchRange", bucket, key)
+	defer func() { call.end(ctx, err) }()
+
+	data, err := s.getRange(ctx, bucket, key, rangeHeader(offset, length), "")
+	call.transferred(ctx, int64(len(data)))
+	return data, newError("FetchRange", bucket, key, err)
+}
+
+// getRange issues a ranged GET and reads the full response body.
+func (s *S3) getRange(ctx context.Context, bucket, key, rng, ifMatch string) ([]byte, error) {
+	obj, err := s.client().GetObject(ctx, &s3.GetObjectInput{
+		Bucket:  aws.String(bucket),
+		Key:     aws.String(key),
+		Range:   aws.String(rng),
+		IfMatch: nonEmpty(ifMatch),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer obj.Body.Close() //nolint:all
+
+	return io.ReadAll(obj.Body)
+}
+
+// rangeHeader formats an HTTP Range header for FetchRange semantics.
+func rangeHeader(offset, length int64) string {
+	switch {
+	case offset < 0:
+		return fmt.Sprintf("bytes=%d", offset)
+	case length <= 0:
+		return fmt.Sprintf("bytes=%d-", offset)
+	default:
+		return fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+}
+
+// ObjectReaderAt implements io.ReaderAt over an object using ranged GETs, allowing formats such as
+// zip and Parquet to be read directly from S3.
+//
+// Reads are pinned to the ETag seen when the reader was created, so a concurrent overwrite of the
+// object causes reads to fail with ErrPreconditionFailed instead of returning mixed content. It is
+// safe for concurrent use.
+type ObjectReaderAt struct {
+	s *S3
+	// ctx is the context given on creation, used for every read because io.ReaderAt has no
+	// context parameter.
+	ctx    context.Context
+	bucket string
+	key    string
+	size   int64
+	etag   string
+
+	blockSize int64
+	cache     *blockCache
+}
+
+// ReaderAt returns an io.ReaderAt over an object.
+//
+// The context is kept and used for every read made through the returned reader, so it must outlive
+// the reader: once it is cancelled or its deadline passes, every later ReadAt fails. Do not pass a
+// context scoped to creating the reader, such as one with a short timeout.
+func (s *S3) ReaderAt(ctx context.Context, bucket, key string, optFns ...func(*types.ReaderAtOptions)) (_ *ObjectReaderAt, err error) {
+	opCtx, call := s.startOperation(ctx, "ReaderAt", bucket, key)
+	defer func() { call.end(opCtx, err) }()
+
+	o := types.ReaderAtOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	r, _, err := s.newObjectReaderAt(opCtx, bucket, key, o)
+	if err != nil {
+		return nil, newError("ReaderAt", bucket, key, err)
+	}
+	call.setSize(r.size)
+	// Later reads belong to the caller's span rather than this one, which ends on return.
+	r.ctx = ctx
+	return r, nil
+}
+
+// newObjectReaderAt reads the object size and ETag and prepares a reader over it. The HEAD response
+// is returned for callers needing other object attributes.
+func (s *S3) newObjectReaderAt(ctx context.Context, bucket, key string, o types.ReaderAtOptions) (*ObjectReaderAt, *s3.HeadObjectOutput, error) {
+	head, err := s.client().HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &ObjectReaderAt{
+		s:      s,
+		ctx:    ctx,
+		bucket: bucket,
+		key:    key,
+		size:   aws.ToInt64(head.ContentLength),
+		etag:   aws.ToString(head.ETag),
+	}
+	if o.BlockSize > 0 {
+		r.blockSize = o.BlockSize
+		r.cache = newBlockCache(max(o.CacheBlocks, 1))
+	}
+
+	return r, head, nil
+}
+
+// Size returns the size of the object in bytes.
+func (r *ObjectReaderAt) Size() int64 {
+	return r.size
+}
+
+// ReadAt reads len(p) bytes starting at off. It returns io.EOF when fewer bytes remain.
+func (r *ObjectReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, newError("ReadAt", r.bucket, r.key, fmt.Errorf("negative offset %d", off))
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		// An empty range would otherwise be requested as the rest of the object.
+		return 0, nil
+	}
+
+	want := min(int64(len(p)), r.size-off)
+	var n int
+	var err error
+	if r.cache == nil {
+		n, err = r.readDirect(p[:want], off)
+	} else {
+		n, err = r.readCached(p[:want], off)
+	}
+	if err != nil {
+		return n, newError("ReadAt", r.bucket, r.key, err)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readDirect fetches exactly the requested bytes.
+func (r *ObjectReaderAt) readDirect(p []byte, off int64) (int, error) {
+	data, err := r.s.getRange(r.ctx, r.bucket, r.key, rangeHeader(off, int64(len(p))), r.etag)
+	if err != nil {
+		return 0, err
+	}
+	n := copy(p, data)
+	if n < len(p) {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, nil
+}
+
+// readCached serves the requested bytes from whole blocks, fetching missing blocks.
+func (r *ObjectReaderAt) readCached(p []byte, off int64) (int, error) {
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		index := pos / r.blockSize
+		block, err := r.block(index)
+		if err != nil {
+			return n, err
+		}
+
+		start := pos - index*r.blockSize
+		if start >= int64(len(block)) {
+			return n, io.ErrUnexpectedEOF
+		}
+		n += copy(p[n:], block[start:])
+	}
+	return n, nil
+}
+
+// block returns the block at index from the cache, fetching it on a miss.
+func (r *ObjectReaderAt) block(index int64) ([]byte, error) {
+	if block, ok := r.cache.get(index); ok {
+		return block, nil
+	}
+
+	start := index * r.blockSize
+	length := min(r.blockSize, r.size-start)
+	block, err := r.s.getRange(r.ctx, r.bucket, r.key, rangeHeader(start, length), r.etag)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.put(index, block)
+	return block, nil
+}
+
+// blockCache is a fixed-size least recently used cache of object blocks.
+type blockCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[int64]*list.Element
+}
+
+type blockEntry struct {
+	index int64
+	data  []byte
+}
+
+func newBlockCache(capacity int) *blockCache {
+	return &blockCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[int64]*list.Element, capacity),
+	}
+}
+
+func (c *blockCache) get(index int64) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[index]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*blockEntry).data, true
+}
+
+func (c *blockCache) put(index int64, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[index]; ok {
+		e.Value.(*blockEntry).data = data
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[index] = c.order.PushFront(&blockEntry{index: index, data: data})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*blockEntry).index)
+	}
+}
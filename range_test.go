@@ -0,0 +1,271 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("Ranged reads", func() {
+	var (
+		sut      *S3
+		api      *stubAPI
+		payload  []byte
+		requests atomic.Int32
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		payload = []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+		requests.Store(0)
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			requests.Add(1)
+			data, err := applyRange(payload, aws.ToString(params.Range))
+			if err != nil {
+				return nil, err
+			}
+			return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+		}
+		api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+			return &s3.HeadObjectOutput{ContentLength: aws.Int64(int64(len(payload))), ETag: aws.String(`"etag-a"`)}, nil
+		}
+	})
+
+	Describe("rangeHeader", func() {
+		It("formats bounded, open and suffix ranges", func() {
+			Expect(rangeHeader(10, 5)).To(Equal("bytes=10-14"))
+			Expect(rangeHeader(10, 0)).To(Equal("bytes=10-"))
+			Expect(rangeHeader(-5, 100)).To(Equal("bytes=-5"))
+		})
+	})
+
+	Describe("FetchRange", func() {
+		It("returns the requested bytes", func() {
+			data, err := sut.FetchRange(context.Background(), "bucket-a", "key-a", 10, 6)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("abcdef"))
+		})
+
+		It("returns the tail of the object", func() {
+			data, err := sut.FetchRange(context.Background(), "bucket-a", "key-a", -3, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("xyz"))
+		})
+
+		It("wraps errors", func() {
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				return nil, apiErr{code: "InvalidRange"}
+			}
+
+			_, err := sut.FetchRange(context.Background(), "bucket-a", "key-a", 1000, 1)
+			Expect(err).To(MatchError(ErrInvalidRange))
+		})
+	})
+
+	Describe("ReaderAt", func() {
+		It("reports the object size", func() {
+			r, err := sut.ReaderAt(context.Background(), "bucket-a", "key-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Size()).To(Equal(int64(len(payload))))
+		})
+
+		It("returns head errors", func() {
+			api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+				return nil, apiErr{code: "NotFound"}
+			}
+
+			_, err := sut.ReaderAt(context.Background(), "bucket-a", "key-a")
+			Expect(err).To(MatchError(ErrObjectNotFound))
+		})
+
+		It("reads ranges pinned to the original ETag", func() {
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				Expect(aws.ToString(params.IfMatch)).To(Equal(`"etag-a"`))
+				data, err := applyRange(payload, aws.ToString(params.Range))
+				Expect(err).NotTo(HaveOccurred())
+				return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+			}
+
+			r, err := sut.ReaderAt(context.Background(), "bucket-a", "key-a")
+			Expect(err).NotTo(HaveOccurred())
+
+			p := make([]byte, 4)
+			n, err := r.ReadAt(p, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(4))
+			Expect(string(p)).To(Equal("2345"))
+		})
+
+		It("returns io.EOF for short reads at the end of the object", func() {
+			r, err := sut.ReaderAt(context.Background(), "bucket-a", "key-a")
+			Expect(err).NotTo(HaveOccurred())
+
+			p := make([]byte, 10)
+			n, err := r.ReadAt(p, int64(len(payload)-3))
+			Expect(err).To(Equal(io.EOF))
+			Expect(string(p[:n])).To(Equal("xyz"))
+
+			n, err = r.ReadAt(p, int64(len(payload)))
+			Expect(err).To(Equal(io.EOF))
+			Expect(n).To(BeZero())
+		})
+
+		It("reads nothing without a request for an empty buffer", func() {
+			for _, blockSize := range []int64{0, 8} {
+				r, err := sut.ReaderAt(context.Background(), "bucket-a", "key-a", func(o *types.ReaderAtOptions) {
+					o.BlockSize = blockSize
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				n, err := r.ReadAt(nil, 4)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(BeZero())
+			}
+			Expect(requests.Load()).To(BeZero())
+		})
+
+		It("rejects negative offsets", func() {
+			r, err := sut.ReaderAt(context.Background(), "bucket-a", "key-a")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = r.ReadAt(make([]byte, 1), -1)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("surfaces a changed object as a precondition failure", func() {
+			api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+				return nil, apiErr{code: "PreconditionFailed"}
+			}
+
+			r, err := sut.ReaderAt(context.Background(), "bucket-a", "key-a")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = r.ReadAt(make([]byte, 1), 0)
+			Expect(err).To(MatchError(ErrPreconditionFailed))
+		})
+
+		It("serves repeated reads from cached blocks", func() {
+			r, err := sut.ReaderAt(context.Background(), "bucket-a", "key-a", func(o *types.ReaderAtOptions) {
+				o.BlockSize = 8
+				o.CacheBlocks = 2
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			p := make([]byte, 10)
+			_, err = r.ReadAt(p, 4)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(p)).To(Equal("456789abcd"))
+			Expect(requests.Load()).To(Equal(int32(2)))
+
+			_, err = r.ReadAt(p[:3], 9)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(p[:3])).To(Equal("9ab"))
+			Expect(requests.Load()).To(Equal(int32(2)))
+
+			// Reading a third block evicts the least recently used one.
+			_, err = r.ReadAt(p[:1], 20)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = r.ReadAt(p[:1], 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests.Load()).To(Equal(int32(4)))
+		})
+
+		It("reads a zip archive directly from the object", func() {
+			archive := &bytes.Buffer{}
+			zw := zip.NewWriter(archive)
+			for i := 0; i < 3; i++ {
+				w, err := zw.Create(fmt.Sprintf("file-%d.txt", i))
+				Expect(err).NotTo(HaveOccurred())
+				_, err = w.Write([]byte(strings.Repeat(strconv.Itoa(i), 100)))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(zw.Close()).To(Succeed())
+			payload = archive.Bytes()
+
+			r, err := sut.ReaderAt(context.Background(), "bucket-a", "archive.zip", func(o *types.ReaderAtOptions) {
+				o.BlockSize = 64
+				o.CacheBlocks = 4
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			zr, err := zip.NewReader(r, r.Size())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zr.File).To(HaveLen(3))
+
+			f, err := zr.File[1].Open()
+			Expect(err).NotTo(HaveOccurred())
+			content, err := io.ReadAll(f)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(strings.Repeat("1", 100)))
+		})
+	})
+})
+
+// applyRange returns the bytes of data selected by an HTTP Range header.
+func applyRange(data []byte, header string) ([]byte, error) {
+	if header == "" {
+		return data, nil
+	}
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok {
+		return nil, fmt.Errorf("unsupported range %q", header)
+	}
+	first, last, _ := strings.Cut(spec, "-")
+	size := int64(len(data))
+
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return data[size-min(n, size):], nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if start >= size {
+		return nil, apiErr{code: "InvalidRange"}
+	}
+	end := size - 1
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil {
+			return nil, err
+		}
+		end = min(end, size-1)
+	}
+	if end < start {
+		return nil, errors.New("invalid range")
+	}
+	return data[start : end+1], nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/drewbernetes/simple-s3/internal/compression"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// defaultReadAheadSize is the read-ahead buffer used by OpenObject when none is configured.
+const defaultReadAheadSize = 64 * 1024
+
+// ObjectReader is a file-like handle over an object implementing io.ReadSeekCloser and io.ReaderAt.
+//
+// Sequential reads stream a single GetObject response through a read-ahead buffer. Seeking closes
+// the stream and the next Read reopens it at the new offset, unless the target is already buffered.
+// ReadAt issues independent ranged GETs and does not move the read offset.
+//
+// Objects stored with a gzip or zstd Content-Encoding are decompressed by Read. Decoding must start
+// at the beginning of the object, so seeking backwards decodes it again up to the new offset, and
+// ReadAt is not supported.
+//
+// Read, Seek and Close are not safe for concurrent use; ReadAt is.
+type ObjectReader struct {
+	*ObjectReaderAt
+
+	readAhead int
+	pos       int64
+	body      io.ReadCloser
+	buf       *bufio.Reader
+	closed    bool
+	progress  *progressTracker
+
+	// codec decodes the stored bytes, and length is the size of the decoded content or -1 when it is
+	// not known until the end is reached.
+	codec  types.Compression
+	dec    io.ReadCloser
+	length int64
+}
+
+// OpenObject opens an object for reading.
+//
+// The context is used for every request made through the returned handle. The handle must be
+// closed to release the underlying connection.
+func (s *S3) OpenObject(ctx context.Context, bucket, key string, optFns ...func(*types.OpenObjectOptions)) (_ *ObjectReader, err error) {
+	opCtx, call := s.startOperation(ctx, "OpenObject", bucket, key)
+	defer func() { call.end(opCtx, err) }()
+
+	o := types.OpenObjectOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	r, head, err := s.newObjectReaderAt(opCtx, bucket, key, o.ReaderAt)
+	if err != nil {
+		return nil, newError("OpenObject", bucket, key, err)
+	}
+	call.setSize(r.size)
+	// Later reads belong to the caller's span rather than this one, which ends on return.
+	r.ctx = ctx
+
+	readAhead := o.ReadAheadSize
+	if readAhead <= 0 {
+		readAhead = defaultReadAheadSize
+	}
+
+	length := r.size
+	var codec types.Compression
+	if !o.DisableDecompression {
+		if codec = compression.Decoding(aws.ToString(head.ContentEncoding)); codec != "" {
+			length = compression.OriginalSize(head.Metadata)
+		}
+	}
+
+	return &ObjectReader{
+		ObjectReaderAt: r,
+		readAhead:      readAhead,
+		progress:       newProgressTracker(o.Progress, bucket, key, length, 0),
+		codec:          codec,
+		length:         length,
+	}, nil
+}
+
+// Size returns the number of bytes Read returns before the end of the object. For a decompressed
+// object this is its uncompressed size, or -1 if it was not recorded and the end has not been read.
+func (r *ObjectReader) Size() int64 {
+	return r.length
+}
+
+// ReadAt reads len(p) bytes starting at off. It fails for decompressed objects.
+func (r *ObjectReader) ReadAt(p []byte, off int64) (int, error) {
+	if r.codec != "" {
+		return 0, newError("ReadAt", r.bucket, r.key, fmt.Errorf("random access is not supported for %s encoded objects", r.codec))
+	}
+	return r.ObjectReaderAt.ReadAt(p, off)
+}
+
+// Read reads up to len(p) bytes from the current offset.
+func (r *ObjectReader) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+	if r.length >= 0 && r.pos >= r.length {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if r.buf == nil {
+		if err := r.open(); err != nil {
+			return 0, newError("Read", r.bucket, r.key, err)
+		}
+	}
+
+	n, err := r.buf.Read(p)
+	r.pos += int64(n)
+	if n > 0 {
+		r.progress.transferred(int64(n))
+		if r.pos == r.length {
+			r.progress.done()
+		}
+	}
+	if err == io.EOF && r.length < 0 {
+		// The end of content of unknown length has been reached.
+		r.length = r.pos
+		r.progress.done()
+	}
+	if err == io.EOF && r.pos < r.length {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil && err != io.EOF {
+		r.reset()
+		return n, newError("Read", r.bucket, r.key, err)
+	}
+	return n, err
+}
+
+// Seek sets the offset for the next Read.
+func (r *ObjectReader) Seek(offset int64, whence int) (int64, error) {
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = r.pos + offset
+	case io.SeekEnd:
+		if r.length < 0 {
+			return 0, newError("Seek", r.bucket, r.key, fmt.Errorf("size of %s encoded object is unknown", r.codec))
+		}
+		target = r.length + offset
+	default:
+		return 0, newError("Seek", r.bucket, r.key, fmt.Errorf("invalid whence %d", whence))
+	}
+	if target < 0 {
+		return 0, newError("Seek", r.bucket, r.key, fmt.Errorf("negative position %d", target))
+	}
+
+	if target == r.pos {
+		return target, nil
+	}
+
+	// Skip forward within the read-ahead buffer rather than reopening the stream.
+	if r.buf != nil && target > r.pos && target-r.pos <= int64(r.buf.Buffered()) {
+		if _, err := r.buf.Discard(int(target - r.pos)); err == nil {
+			r.pos = target
+			return target, nil
+		}
+	}
+
+	r.reset()
+	r.pos = target
+	return target, nil
+}
+
+// Close releases the underlying stream. Further reads and seeks fail with os.ErrClosed.
+func (r *ObjectReader) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	if r.body == nil {
+		return nil
+	}
+	if r.dec != nil {
+		_ = r.dec.Close()
+	}
+	err := r.body.Close()
+	r.body = nil
+	r.dec = nil
+	r.buf = nil
+	return err
+}
+
+// open starts a GetObject stream at the current offset.
+func (r *ObjectReader) open() error {
+	params := &s3.GetObjectInput{
+		Bucket:  aws.String(r.bucket),
+		Key:     aws.String(r.key),
+		IfMatch: nonEmpty(r.etag),
+	}
+	if r.codec == "" {
+		params.Range = aws.String(rangeHeader(r.pos, 0))
+	}
+	obj, err := r.s.client().GetObject(r.ctx, params)
+	if err != nil {
+		return err
+	}
+
+	r.body = obj.Body
+	if r.codec == "" {
+		r.buf = bufio.NewReaderSize(obj.Body, r.readAhead)
+		return nil
+	}
+
+	if r.dec, err = compression.NewReader(r.codec, obj.Body); err != nil {
+		r.reset()
+		return err
+	}
+	r.buf = bufio.NewReaderSize(r.dec, r.readAhead)
+	// Decoded content cannot be requested by range, so everything before the offset is decoded and
+	// discarded.
+	n, err := io.CopyN(io.Discard, r.buf, r.pos)
+	if err == io.EOF {
+		r.length = n
+	} else if err != nil {
+		r.reset()
+		return err
+	}
+	return nil
+}
+
+// reset discards the current stream so the next Read reopens it.
+func (r *ObjectReader) reset() {
+	if r.dec != nil {
+		_ = r.dec.Close()
+	}
+	if r.body != nil {
+		_ = r.body.Close()
+	}
+	r.body = nil
+	r.dec = nil
+	r.buf = nil
+}
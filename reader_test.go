@@ -0,0 +1,230 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var (
+	_ io.ReadSeekCloser = (*ObjectReader)(nil)
+	_ io.ReaderAt       = (*ObjectReader)(nil)
+)
+
+var _ = Describe("OpenObject", func() {
+	var (
+		sut     *S3
+		api     *stubAPI
+		payload []byte
+		ranges  []string
+		closes  int
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		payload = []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+		ranges = nil
+		closes = 0
+		api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+			return &s3.HeadObjectOutput{ContentLength: aws.Int64(int64(len(payload))), ETag: aws.String(`"etag-a"`)}, nil
+		}
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			Expect(aws.ToString(params.IfMatch)).To(Equal(`"etag-a"`))
+			ranges = append(ranges, aws.ToString(params.Range))
+			data, err := applyRange(payload, aws.ToString(params.Range))
+			if err != nil {
+				return nil, err
+			}
+			return &s3.GetObjectOutput{Body: &closeCounter{Reader: bytes.NewReader(data), closes: &closes}}, nil
+		}
+	})
+
+	It("returns head errors", func() {
+		api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+			return nil, apiErr{code: "NotFound"}
+		}
+
+		_, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).To(MatchError(ErrObjectNotFound))
+	})
+
+	It("streams the whole object lazily with a single request", func() {
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ranges).To(BeEmpty())
+
+		data, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(payload))
+		Expect(ranges).To(Equal([]string{"bytes=0-"}))
+		Expect(r.Close()).To(Succeed())
+		Expect(closes).To(Equal(1))
+	})
+
+	It("reopens the stream at the new offset after seeking", func() {
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a", func(o *types.OpenObjectOptions) {
+			o.ReadAheadSize = 16
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		p := make([]byte, 4)
+		_, err = io.ReadFull(r, p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(p)).To(Equal("0123"))
+
+		pos, err := r.Seek(-3, io.SeekEnd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pos).To(Equal(int64(len(payload) - 3)))
+
+		rest, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rest)).To(Equal("xyz"))
+		Expect(ranges).To(Equal([]string{"bytes=0-", "bytes=33-"}))
+		Expect(closes).To(Equal(1))
+	})
+
+	It("skips forward within the read-ahead buffer without a new request", func() {
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a", func(o *types.OpenObjectOptions) {
+			o.ReadAheadSize = 16
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		p := make([]byte, 2)
+		_, err = io.ReadFull(r, p)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = r.Seek(5, io.SeekCurrent)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = io.ReadFull(r, p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(p)).To(Equal("78"))
+		Expect(ranges).To(HaveLen(1))
+	})
+
+	It("reads at arbitrary offsets without moving the read position", func() {
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		p := make([]byte, 3)
+		_, err = r.ReadAt(p, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(p)).To(Equal("abc"))
+
+		_, err = io.ReadFull(r, p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(p)).To(Equal("012"))
+	})
+
+	It("works with io.SectionReader", func() {
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		data, err := io.ReadAll(io.NewSectionReader(r, 30, 6))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("uvwxyz"))
+	})
+
+	It("rejects invalid seeks", func() {
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		_, err = r.Seek(-1, io.SeekStart)
+		Expect(err).To(HaveOccurred())
+		_, err = r.Seek(0, 42)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns io.EOF when positioned past the end", func() {
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		_, err = r.Seek(100, io.SeekStart)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = r.Read(make([]byte, 1))
+		Expect(err).To(Equal(io.EOF))
+		Expect(ranges).To(BeEmpty())
+	})
+
+	It("reports a stream that ends early", func() {
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(payload[:5]))}, nil
+		}
+
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		_, err = io.ReadAll(r)
+		Expect(errors.Is(err, io.ErrUnexpectedEOF)).To(BeTrue())
+	})
+
+	It("returns get errors from Read", func() {
+		api.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			return nil, apiErr{code: "PreconditionFailed"}
+		}
+
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close() //nolint:all
+
+		_, err = r.Read(make([]byte, 1))
+		Expect(err).To(MatchError(ErrPreconditionFailed))
+	})
+
+	It("fails reads and seeks after Close", func() {
+		r, err := sut.OpenObject(context.Background(), "bucket-a", "key-a")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = r.Read(make([]byte, 1))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Close()).To(Succeed())
+		Expect(r.Close()).To(Succeed())
+		Expect(closes).To(Equal(1))
+
+		_, err = r.Read(make([]byte, 1))
+		Expect(err).To(MatchError(os.ErrClosed))
+		_, err = r.Seek(0, io.SeekStart)
+		Expect(err).To(MatchError(os.ErrClosed))
+	})
+})
+
+type closeCounter struct {
+	io.Reader
+	closes *int
+}
+
+func (c *closeCounter) Close() error {
+	*c.closes++
+	return nil
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+const (
+	// maxCopySize is the largest object CopyObject copies in a single request.
+	maxCopySize = 5 * 1024 * 1024 * 1024
+	// checkpointInterval is the minimum time between checkpoint saves while replicating.
+	checkpointInterval = time.Second
+)
+
+// Replicate copies the objects under the prefix in srcBucket to dstBucket through dst, keeping their
+// keys, user metadata, tags and content headers.
+//
+// When dst uses the same endpoint as s, objects are copied within the service by the destination
+// client, whose credentials must be able to read the source. Otherwise, and for objects larger than
+// 5 GiB, they are streamed from s to dst. With a checkpoint store, progress is saved as objects
+// complete so an interrupted run resumes after the last key copied, and the checkpoint is removed
+// once every object has been copied. A failed object does not stop the others; the result lists the
+// failures and the returned error joins them.
+func (s *S3) Replicate(ctx context.Context, srcBucket string, dst *S3, dstBucket string, optFns ...func(*types.ReplicateOptions)) (_ *types.ReplicateResult, err error) {
+	o := types.ReplicateOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	ctx, call := s.startOperation(ctx, "Replicate", srcBucket, o.Prefix)
+	defer func() { call.end(ctx, err) }()
+
+	tracker := &checkpointTracker{
+		store:      o.CheckpointStore,
+		id:         s.endpoint + "/" + srcBucket + "/" + o.Prefix + " -> " + dst.endpoint + "/" + dstBucket,
+		checkpoint: types.ReplicationCheckpoint{SourceBucket: srcBucket, DestinationBucket: dstBucket, Prefix: o.Prefix},
+	}
+	if tracker.store != nil {
+		checkpoint, err := tracker.store.Load(ctx, tracker.id)
+		if err != nil {
+			return nil, newError("Replicate", srcBucket, o.Prefix, err)
+		}
+		if checkpoint != nil {
+			tracker.checkpoint.LastKey = checkpoint.LastKey
+		}
+	}
+
+	objects, err := listObjectsV2All(ctx, s.client(), srcBucket, o.Prefix)
+	if err != nil {
+		return nil, newError("Replicate", srcBucket, o.Prefix, err)
+	}
+
+	// Listings are in key order, so everything up to the checkpoint was copied by an earlier run.
+	pending := make([]s3types.Object, 0, len(objects))
+	for _, obj := range objects {
+		if aws.ToString(obj.Key) > tracker.checkpoint.LastKey {
+			pending = append(pending, obj)
+		}
+	}
+	tracker.keys = make([]string, len(pending))
+	for i, obj := range pending {
+		tracker.keys[i] = aws.ToString(obj.Key)
+	}
+	tracker.done = make([]bool, len(pending))
+
+	serverSide := !o.DisableServerSideCopy && s.sameEndpoint(dst)
+	result := &types.ReplicateResult{Skipped: len(objects) - len(pending), ServerSide: serverSide}
+	var (
+		mu      sync.Mutex
+		saveErr error
+	)
+	concurrency := int(firstPositive(int64(o.Concurrency), defaultConcurrency))
+	err = forEach(ctx, len(pending), concurrency, func(ctx context.Context, i int) error {
+		obj := pending[i]
+		var err error
+		streamed := !serverSide || aws.ToInt64(obj.Size) > maxCopySize
+		if streamed {
+			err = s.streamObject(ctx, srcBucket, obj, dst, dstBucket)
+		} else {
+			err = s.copyObject(ctx, srcBucket, obj, dst, dstBucket)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.Failed = append(result.Failed, types.ObjectCopyError{Key: tracker.keys[i], Err: newError("Replicate", srcBucket, tracker.keys[i], err)})
+			return nil
+		}
+		result.Copied++
+		result.Bytes += aws.ToInt64(obj.Size)
+		if streamed {
+			call.transferred(ctx, aws.ToInt64(obj.Size))
+		}
+		if err := tracker.complete(ctx, i); err != nil && saveErr == nil {
+			saveErr = err
+		}
+		return nil
+	})
+
+	call.setSize(result.Bytes)
+	errs := make([]error, 0, len(result.Failed)+2)
+	for _, f := range result.Failed {
+		errs = append(errs, f.Err)
+	}
+	if err != nil {
+		errs = append(errs, newError("Replicate", srcBucket, o.Prefix, err))
+	}
+	if tracker.store != nil {
+		// The run may have been cancelled, which must not prevent recording its progress.
+		ctx := context.WithoutCancel(ctx)
+		if len(errs) == 0 {
+			saveErr = tracker.store.Delete(ctx, tracker.id)
+		} else if err := tracker.save(ctx); err != nil {
+			saveErr = err
+		}
+		if saveErr != nil {
+			errs = append(errs, newError("Replicate", srcBucket, o.Prefix, saveErr))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// sameEndpoint reports whether dst sends requests to the same service as s, so objects can be copied
+// between them within the service.
+func (s *S3) sameEndpoint(dst *S3) bool {
+	if s == dst {
+		return true
+	}
+	if s.Client == nil || dst.Client == nil {
+		// The destination of requests made through other implementations of API is unknown.
+		return false
+	}
+	return strings.TrimSuffix(s.endpoint, "/") == strings.TrimSuffix(dst.endpoint, "/")
+}
+
+// copyObject copies an object within the service, keeping its metadata and tags.
+func (s *S3) copyObject(ctx context.Context, srcBucket string, obj s3types.Object, dst *S3, dstBucket string) error {
+	_, err := dst.client().CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:            aws.String(dstBucket),
+		Key:               obj.Key,
+		CopySource:        aws.String(copySource(srcBucket, aws.ToString(obj.Key))),
+		CopySourceIfMatch: obj.ETag,
+		MetadataDirective: s3types.MetadataDirectiveCopy,
+		TaggingDirective:  s3types.TaggingDirectiveCopy,
+	})
+	return err
+}
+
+// streamObject downloads an object through s and uploads it through dst with the same metadata and
+// tags, verifying the data against the source checksum on the way.
+func (s *S3) streamObject(ctx context.Context, srcBucket string, obj s3types.Object, dst *S3, dstBucket string) error {
+	key := aws.ToString(obj.Key)
+	out, err := s.client().GetObject(ctx, &s3.GetObjectInput{
+		Bucket:       aws.String(srcBucket),
+		Key:          obj.Key,
+		IfMatch:      obj.ETag,
+		ChecksumMode: s3types.ChecksumModeEnabled,
+	}, withoutSDKChecksumValidation)
+	if err != nil {
+		return err
+	}
+
+	defer out.Body.Close() //nolint:all
+
+	body, err := s.verifyChecksum(ctx, srcBucket, key, out, out.Body)
+	if err != nil {
+		return err
+	}
+
+	var tagging *string
+	if aws.ToInt32(out.TagCount) > 0 {
+		if tagging, err = s.objectTagging(ctx, srcBucket, key); err != nil {
+			return err
+		}
+	}
+
+	m := dst.multipartOptions(types.MultipartOptions{})
+	partSize := uploadPartSize(m, aws.ToInt64(out.ContentLength))
+	_, err = dst.transferManager().UploadObject(ctx, &transfermanager.UploadObjectInput{
+		Bucket:                  aws.String(dstBucket),
+		Key:                     obj.Key,
+		Body:                    body,
+		ContentLength:           out.ContentLength,
+		CacheControl:            out.CacheControl,
+		ContentDisposition:      out.ContentDisposition,
+		ContentEncoding:         out.ContentEncoding,
+		ContentLanguage:         out.ContentLanguage,
+		ContentType:             out.ContentType,
+		Metadata:                out.Metadata,
+		Tagging:                 tagging,
+		WebsiteRedirectLocation: out.WebsiteRedirectLocation,
+	}, func(to *transfermanager.Options) {
+		to.PartSizeBytes = partSize
+		// Ignored by the transfer manager; partSize applies the threshold.
+		to.MultipartUploadThreshold = m.Threshold
+		if m.Concurrency > 0 {
+			to.Concurrency = m.Concurrency
+		}
+	})
+	return err
+}
+
+// objectTagging returns the tags of an object encoded as a URL query, the form uploads take them in.
+func (s *S3) objectTagging(ctx context.Context, bucket, key string) (*string, error) {
+	out, err := s.client().GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	for _, tag := range out.TagSet {
+		values.Add(aws.ToString(tag.Key), aws.ToString(tag.Value))
+	}
+	return nonEmpty(values.Encode()), nil
+}
+
+// copySource formats the URL-encoded source of a CopyObject request.
+func copySource(bucket, key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return bucket + "/" + strings.Join(segments, "/")
+}
+
+// checkpointTracker advances the replication checkpoint as objects complete out of order. The
+// checkpoint moves past a key only once it and every key before it have been copied, so a failed
+// object holds it back and is retried by the next run.
+type checkpointTracker struct {
+	store      types.CheckpointStore
+	id         string
+	checkpoint types.ReplicationCheckpoint
+	keys       []string
+	done       []bool
+	next       int
+	saved      time.Time
+}
+
+// complete records that the object at index i was copied, saving the checkpoint at most once per
+// checkpointInterval.
+func (c *checkpointTracker) complete(ctx context.Context, i int) error {
+	c.done[i] = true
+	for c.next < len(c.done) && c.done[c.next] {
+		c.next++
+	}
+	if time.Since(c.saved) < checkpointInterval {
+		return nil
+	}
+	return c.save(ctx)
+}
+
+// save stores the last key before which every object has been copied.
+func (c *checkpointTracker) save(ctx context.Context) error {
+	if c.store == nil || c.next == 0 {
+		return nil
+	}
+	c.checkpoint.LastKey = c.keys[c.next-1]
+	c.saved = time.Now()
+	return c.store.Save(ctx, c.id, &c.checkpoint)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("Replicate", func() {
+	var (
+		src, dst       *S3
+		srcAPI, dstAPI *stubAPI
+		fake           *bucketTransferManager
+		objects        map[string]string
+		listing        []s3types.Object
+		mu             sync.Mutex
+		copies         []*s3.CopyObjectInput
+		store          *FileCheckpointStore
+	)
+
+	checkpointID := "/src-bucket/ -> /dst-bucket"
+
+	BeforeEach(func() {
+		// Both wrappers are backed by SDK clients so that their endpoints are compared.
+		srcAPI, dstAPI = &stubAPI{}, &stubAPI{}
+		src = &S3{Client: &s3.Client{}, api: srcAPI}
+		dst = &S3{Client: &s3.Client{}, api: dstAPI}
+		fake = &bucketTransferManager{objects: map[string]string{}, inputs: map[string]*transfermanager.UploadObjectInput{}}
+		dst.transfer = fake
+		objects = map[string]string{"a": "alpha", "b": "bravo", "c": "charlie", "d/e f.txt": "echo"}
+		listing = nil
+		for _, key := range []string{"a", "b", "c", "d/e f.txt"} {
+			listing = append(listing, s3types.Object{
+				Key:  aws.String(key),
+				Size: aws.Int64(int64(len(objects[key]))),
+				ETag: aws.String(md5ETag([]byte(objects[key]))),
+			})
+		}
+		copies = nil
+		store = &FileCheckpointStore{Dir: GinkgoT().TempDir()}
+
+		srcAPI.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
+			Expect(bucket).To(Equal("src-bucket"))
+			return listing, nil
+		}
+		dstAPI.copyObject = func(ctx context.Context, params *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			copies = append(copies, params)
+			return &s3.CopyObjectOutput{}, nil
+		}
+		srcAPI.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			key := aws.ToString(params.Key)
+			return &s3.GetObjectOutput{
+				Body:          io.NopCloser(bytes.NewReader([]byte(objects[key]))),
+				ContentLength: aws.Int64(int64(len(objects[key]))),
+				ContentType:   aws.String("text/plain"),
+				CacheControl:  aws.String("max-age=60"),
+				Metadata:      map[string]string{"owner": "ops"},
+			}, nil
+		}
+	})
+
+	It("copies within the service when both clients use the same endpoint", func() {
+		result, err := src.Replicate(context.Background(), "src-bucket", dst, "dst-bucket")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.ServerSide).To(BeTrue())
+		Expect(result.Copied).To(Equal(4))
+		Expect(result.Bytes).To(Equal(int64(5 + 5 + 7 + 4)))
+		Expect(copies).To(HaveLen(4))
+		Expect(fake.objects).To(BeEmpty())
+
+		var spaced *s3.CopyObjectInput
+		for _, c := range copies {
+			if aws.ToString(c.Key) == "d/e f.txt" {
+				spaced = c
+			}
+		}
+		Expect(aws.ToString(spaced.Bucket)).To(Equal("dst-bucket"))
+		Expect(aws.ToString(spaced.CopySource)).To(Equal("src-bucket/d/e%20f.txt"))
+		Expect(aws.ToString(spaced.CopySourceIfMatch)).To(Equal(md5ETag([]byte("echo"))))
+		Expect(spaced.MetadataDirective).To(Equal(s3types.MetadataDirectiveCopy))
+		Expect(spaced.TaggingDirective).To(Equal(s3types.TaggingDirectiveCopy))
+	})
+
+	It("streams objects with their metadata and tags between endpoints", func() {
+		dst.endpoint = "https://other.example.com"
+		srcAPI.getObjectTagging = func(ctx context.Context, params *s3.GetObjectTaggingInput) (*s3.GetObjectTaggingOutput, error) {
+			Expect(aws.ToString(params.Key)).To(Equal("a"))
+			return &s3.GetObjectTaggingOutput{TagSet: []s3types.Tag{
+				{Key: aws.String("team"), Value: aws.String("ops")},
+				{Key: aws.String("env"), Value: aws.String("prod & test")},
+			}}, nil
+		}
+		get := srcAPI.getObject
+		srcAPI.getObject = func(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			out, err := get(ctx, params)
+			if aws.ToString(params.Key) == "a" {
+				out.TagCount = aws.Int32(2)
+			}
+			return out, err
+		}
+
+		result, err := src.Replicate(context.Background(), "src-bucket", dst, "dst-bucket")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.ServerSide).To(BeFalse())
+		Expect(copies).To(BeEmpty())
+		Expect(fake.objects).To(HaveKeyWithValue("d/e f.txt", "echo"))
+
+		input := fake.inputs["a"]
+		Expect(aws.ToString(input.Bucket)).To(Equal("dst-bucket"))
+		Expect(aws.ToString(input.ContentType)).To(Equal("text/plain"))
+		Expect(aws.ToString(input.CacheControl)).To(Equal("max-age=60"))
+		Expect(input.Metadata).To(HaveKeyWithValue("owner", "ops"))
+		Expect(aws.ToString(input.Tagging)).To(Equal("env=prod+%26+test&team=ops"))
+		Expect(fake.inputs["b"].Tagging).To(BeNil())
+	})
+
+	It("streams objects too large to copy in one request", func() {
+		listing[0].Size = aws.Int64(maxCopySize + 1)
+
+		_, err := src.Replicate(context.Background(), "src-bucket", dst, "dst-bucket")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fake.objects).To(HaveKey("a"))
+		Expect(copies).To(HaveLen(3))
+	})
+
+	It("resumes after the checkpoint and removes it once complete", func() {
+		Expect(store.Save(context.Background(), checkpointID, &types.ReplicationCheckpoint{LastKey: "b"})).To(Succeed())
+
+		result, err := src.Replicate(context.Background(), "src-bucket", dst, "dst-bucket", func(o *types.ReplicateOptions) {
+			o.CheckpointStore = store
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.Skipped).To(Equal(2))
+		Expect(result.Copied).To(Equal(2))
+		Expect(store.Load(context.Background(), checkpointID)).To(BeNil())
+	})
+
+	It("keeps the checkpoint before the first failure", func() {
+		dstAPI.copyObject = func(ctx context.Context, params *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+			if aws.ToString(params.Key) == "c" {
+				return nil, apiErr{code: "AccessDenied"}
+			}
+			return &s3.CopyObjectOutput{}, nil
+		}
+
+		result, err := src.Replicate(context.Background(), "src-bucket", dst, "dst-bucket", func(o *types.ReplicateOptions) {
+			o.CheckpointStore = store
+			o.Concurrency = 1
+		})
+		Expect(err).To(MatchError(ErrAccessDenied))
+
+		Expect(result.Copied).To(Equal(3))
+		Expect(result.Failed).To(HaveLen(1))
+		Expect(result.Failed[0].Key).To(Equal("c"))
+
+		checkpoint, err := store.Load(context.Background(), checkpointID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkpoint.LastKey).To(Equal("b"))
+		Expect(checkpoint.SourceBucket).To(Equal("src-bucket"))
+		Expect(checkpoint.DestinationBucket).To(Equal("dst-bucket"))
+	})
+
+	It("streams when server-side copy is disabled", func() {
+		_, err := src.Replicate(context.Background(), "src-bucket", dst, "dst-bucket", func(o *types.ReplicateOptions) {
+			o.DisableServerSideCopy = true
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(copies).To(BeEmpty())
+		Expect(fake.objects).To(HaveLen(4))
+	})
+
+	It("returns listing errors", func() {
+		srcAPI.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
+			return nil, apiErr{code: "NoSuchBucket"}
+		}
+
+		_, err := src.Replicate(context.Background(), "src-bucket", dst, "dst-bucket")
+		Expect(err).To(MatchError(ErrBucketNotFound))
+	})
+})
@@ -0,0 +1,410 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+const (
+	// defaultConcurrency matches the transfer manager's default number of parts uploaded in parallel.
+	defaultConcurrency = 5
+	// abortTimeout bounds the request aborting a failed multipart upload.
+	abortTimeout = 30 * time.Second
+)
+
+// multipartUpload uploads body in parts without the transfer manager, which is needed to resume
+// uploads and to store a precomputed full-object checksum.
+//
+// With resumable set, progress is persisted in the options' state store so a later call for the
+// same bucket and key uploads only the parts the service does not already hold. Parts already held
+// are read back from the body and uploaded again if they no longer match it. State from an upload of a different size, part size or checksum
+// algorithm is discarded and its multipart upload aborted. On failure the upload is left in place
+// so it can be resumed; the state is removed once the object has been stored. Without resumable, a
+// failed upload is aborted.
+func (s *S3) multipartUpload(ctx context.Context, bucket, key, contentType string, body io.ReadSeeker, size int64, alg types.ChecksumAlgorithm, o types.PutObjectOptions, resumable bool) error {
+	if o.Checksum != "" && !supportsFullObject(alg) {
+		return fmt.Errorf("multipart uploads cannot store a full-object %s checksum", alg)
+	}
+
+	store := o.StateStore
+	switch {
+	case !resumable:
+		store = discardStateStore{}
+	case store == nil:
+		store = NewFileStateStore()
+	}
+	m := s.multipartOptions(o.Multipart)
+	partSize := uploadPartSize(m, size)
+	id := bucket + "/" + key
+
+	state, err := s.resumeState(ctx, store, id, bucket, key, size, partSize, alg)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		params := &s3.CreateMultipartUploadInput{
+			Bucket:            aws.String(bucket),
+			Key:               aws.String(key),
+			ContentType:       aws.String(contentType),
+			ChecksumAlgorithm: s3types.ChecksumAlgorithm(alg),
+		}
+		if o.Checksum != "" {
+			params.ChecksumType = s3types.ChecksumTypeFullObject
+		}
+		out, err := s.client().CreateMultipartUpload(ctx, params)
+		if err != nil {
+			return err
+		}
+		s.logger().InfoContext(ctx, "created multipart upload", slog.String("bucket", bucket), slog.String("key", key),
+			slog.String("upload_id", aws.ToString(out.UploadId)), slog.Int64("size", size), slog.Int64("part_size", partSize))
+		state = &types.UploadState{
+			Bucket:            bucket,
+			Key:               key,
+			UploadID:          aws.ToString(out.UploadId),
+			Size:              size,
+			PartSize:          partSize,
+			ChecksumAlgorithm: alg,
+		}
+		if err := store.Save(ctx, id, state); err != nil {
+			return err
+		}
+	}
+
+	src := newPartSource(body)
+	concurrency := int(firstPositive(int64(m.Concurrency), defaultConcurrency))
+	if err := s.dropChangedParts(ctx, src, state, o.ContentMD5, concurrency); err != nil {
+		return err
+	}
+
+	done := make(map[int32]bool, len(state.Parts))
+	var stored int64
+	for _, p := range state.Parts {
+		done[p.PartNumber] = true
+		stored += p.Size
+	}
+	var missing []int32
+	for n := int32(1); int64(n-1)*partSize < size; n++ {
+		if !done[n] {
+			missing = append(missing, n)
+		}
+	}
+
+	log := s.logger().With(slog.String("bucket", bucket), slog.String("key", key), slog.String("upload_id", state.UploadID))
+	progress := newProgressTracker(o.Progress, bucket, key, size, len(done)+len(missing))
+	if len(done) > 0 {
+		progress.resumed(len(done), stored)
+		log.InfoContext(ctx, "resuming multipart upload", slog.Int("parts_stored", len(done)), slog.Int("parts_remaining", len(missing)))
+	}
+
+	var mu sync.Mutex
+	err = forEach(ctx, len(missing), concurrency, func(ctx context.Context, i int) error {
+		n := missing[i]
+		offset := int64(n-1) * partSize
+		length := min(partSize, size-offset)
+
+		data, err := src.section(offset, length)
+		if err != nil {
+			return err
+		}
+		params := &s3.UploadPartInput{
+			Bucket:            aws.String(bucket),
+			Key:               aws.String(key),
+			UploadId:          aws.String(state.UploadID),
+			PartNumber:        aws.Int32(n),
+			Body:              data,
+			ContentLength:     aws.Int64(length),
+			ChecksumAlgorithm: s3types.ChecksumAlgorithm(alg),
+		}
+		var md5Hex string
+		if o.ContentMD5 {
+			var md5Base64 string
+			if md5Base64, md5Hex, err = contentMD5(data); err != nil {
+				return err
+			}
+			params.ContentMD5 = aws.String(md5Base64)
+		}
+		out, err := s.client().UploadPart(ctx, params)
+		if err != nil {
+			return err
+		}
+		if etag := aws.ToString(out.ETag); o.ContentMD5 && etag != "" {
+			if err := compareETag(md5Hex, etag); err != nil {
+				return err
+			}
+		}
+
+		progress.partCompleted(length)
+		log.DebugContext(ctx, "uploaded part", slog.Int("part", int(n)), slog.Int64("size", length))
+
+		mu.Lock()
+		defer mu.Unlock()
+		state.Parts = append(state.Parts, types.UploadPart{
+			PartNumber: n,
+			ETag:       aws.ToString(out.ETag),
+			Size:       length,
+			Checksum:   checksumValue(alg, out.ChecksumCRC32, out.ChecksumCRC32C, out.ChecksumCRC64NVME, out.ChecksumSHA1, out.ChecksumSHA256),
+		})
+		return store.Save(ctx, id, state)
+	})
+	if err != nil {
+		if !resumable {
+			s.abortUpload(ctx, bucket, key, state.UploadID)
+		} else {
+			log.InfoContext(ctx, "multipart upload left in place to resume", slog.Any("error", err))
+		}
+		return err
+	}
+
+	sort.Slice(state.Parts, func(i, j int) bool {
+		return state.Parts[i].PartNumber < state.Parts[j].PartNumber
+	})
+	completed := make([]s3types.CompletedPart, 0, len(state.Parts))
+	for _, p := range state.Parts {
+		part := s3types.CompletedPart{
+			PartNumber: aws.Int32(p.PartNumber),
+			ETag:       aws.String(p.ETag),
+		}
+		part.ChecksumCRC32, part.ChecksumCRC32C, part.ChecksumCRC64NVME, part.ChecksumSHA1, part.ChecksumSHA256 = checksumFields(alg, p.Checksum)
+		completed = append(completed, part)
+	}
+	params := &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(state.UploadID),
+		MultipartUpload: &s3types.CompletedMultipartUpload{Parts: completed},
+		IfMatch:         nonEmpty(o.IfMatch),
+		IfNoneMatch:     nonEmpty(o.IfNoneMatch),
+	}
+	if o.Checksum != "" {
+		params.ChecksumType = s3types.ChecksumTypeFullObject
+		params.ChecksumCRC32, params.ChecksumCRC32C, params.ChecksumCRC64NVME, params.ChecksumSHA1, params.ChecksumSHA256 = checksumFields(alg, o.Checksum)
+	}
+	out, err := s.client().CompleteMultipartUpload(ctx, params)
+	if err != nil {
+		if !resumable {
+			s.abortUpload(ctx, bucket, key, state.UploadID)
+		}
+		return err
+	}
+	if etag := aws.ToString(out.ETag); o.ContentMD5 && etag != "" {
+		// Parts sent by this call were verified as they were uploaded and reused parts were checked
+		// against the body, so the part ETags determine the ETag of the whole object.
+		partETags := make([]string, 0, len(state.Parts))
+		for _, p := range state.Parts {
+			partETags = append(partETags, p.ETag)
+		}
+		expected, err := multipartETag(partETags)
+		if err != nil {
+			return err
+		}
+		if err := compareETag(expected, etag); err != nil {
+			return err
+		}
+	}
+
+	log.InfoContext(ctx, "completed multipart upload", slog.Int("parts", len(state.Parts)))
+
+	// The object is stored, so failing to remove the state is not reported. Stale state is
+	// discarded by the next upload once the service no longer recognises the upload ID.
+	_ = store.Delete(ctx, id)
+	progress.done()
+	return nil
+}
+
+// resumeState loads the saved state for id and reconciles it with the parts held by the service.
+//
+// It returns nil when there is no usable state and a new multipart upload must be created.
+func (s *S3) resumeState(ctx context.Context, store types.UploadStateStore, id, bucket, key string, size, partSize int64, alg types.ChecksumAlgorithm) (*types.UploadState, error) {
+	state, err := store.Load(ctx, id)
+	if err != nil || state == nil {
+		return nil, err
+	}
+
+	// State saved before the algorithm was recorded always used the default.
+	if state.ChecksumAlgorithm == "" {
+		state.ChecksumAlgorithm = defaultChecksumAlgorithm
+	}
+	if state.UploadID == "" || state.Size != size || state.PartSize != partSize || state.ChecksumAlgorithm != alg {
+		if state.UploadID != "" {
+			s.abortUpload(ctx, bucket, key, state.UploadID)
+		}
+		return nil, store.Delete(ctx, id)
+	}
+
+	parts, err := listPartsAll(ctx, s.client(), bucket, key, state.UploadID)
+	if isUploadNotFound(err) {
+		return nil, store.Delete(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The service is authoritative: a part may have been stored after the state was last saved, and
+	// parts of an unexpected size are uploaded again.
+	state.Parts = state.Parts[:0]
+	for _, p := range parts {
+		n := aws.ToInt32(p.PartNumber)
+		if n < 1 || aws.ToInt64(p.Size) != min(partSize, size-int64(n-1)*partSize) {
+			continue
+		}
+		state.Parts = append(state.Parts, types.UploadPart{
+			PartNumber: n,
+			ETag:       aws.ToString(p.ETag),
+			Size:       aws.ToInt64(p.Size),
+			Checksum:   checksumValue(alg, p.ChecksumCRC32, p.ChecksumCRC32C, p.ChecksumCRC64NVME, p.ChecksumSHA1, p.ChecksumSHA256),
+		})
+	}
+	return state, nil
+}
+
+// dropChangedParts reads the parts of state from src and removes those whose stored checksum no
+// longer matches, so a body changed since the failed attempt is not completed from stale parts.
+// Parts without a checksum, and every part when checkMD5 is set, must also match their ETag.
+func (s *S3) dropChangedParts(ctx context.Context, src *partSource, state *types.UploadState, checkMD5 bool, concurrency int) error {
+	var mu sync.Mutex
+	stale := map[int32]bool{}
+	err := forEach(ctx, len(state.Parts), concurrency, func(ctx context.Context, i int) error {
+		p := state.Parts[i]
+		data, err := src.section(int64(p.PartNumber-1)*state.PartSize, p.Size)
+		if err != nil {
+			return err
+		}
+		ok, err := partMatches(data, state.ChecksumAlgorithm, p, checkMD5 || p.Checksum == "")
+		if err != nil || ok {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		stale[p.PartNumber] = true
+		return nil
+	})
+	if err != nil || len(stale) == 0 {
+		return err
+	}
+
+	s.logger().WarnContext(ctx, "stored parts differ from the body and will be uploaded again", slog.String("bucket", state.Bucket),
+		slog.String("key", state.Key), slog.String("upload_id", state.UploadID), slog.Int("parts", len(stale)))
+	kept := state.Parts[:0]
+	for _, p := range state.Parts {
+		if !stale[p.PartNumber] {
+			kept = append(kept, p)
+		}
+	}
+	state.Parts = kept
+	return nil
+}
+
+// partMatches reports whether data has the checksum recorded for p and, with checkMD5, its ETag.
+func partMatches(data io.Reader, alg types.ChecksumAlgorithm, p types.UploadPart, checkMD5 bool) (bool, error) {
+	h, err := newChecksumHash(alg)
+	if err != nil {
+		return false, err
+	}
+	sum := md5.New() //nolint:gosec
+	if _, err := io.Copy(io.MultiWriter(h, sum), data); err != nil {
+		return false, err
+	}
+	if p.Checksum != "" && base64.StdEncoding.EncodeToString(h.Sum(nil)) != p.Checksum {
+		return false, nil
+	}
+	if checkMD5 && compareETag(hex.EncodeToString(sum.Sum(nil)), p.ETag) != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// abortUpload aborts a multipart upload on a best-effort basis. It ignores cancellation of ctx,
+// which is often the reason the upload failed, and gives up after abortTimeout instead.
+func (s *S3) abortUpload(ctx context.Context, bucket, key, uploadID string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abortTimeout)
+	defer cancel()
+
+	_, err := s.client().AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	log := s.logger().With(slog.String("bucket", bucket), slog.String("key", key), slog.String("upload_id", uploadID))
+	if err != nil {
+		log.WarnContext(ctx, "failed to abort multipart upload", slog.Any("error", err))
+		return
+	}
+	log.InfoContext(ctx, "aborted multipart upload")
+}
+
+// discardStateStore is the UploadStateStore used by uploads that are not resumable.
+type discardStateStore struct{}
+
+func (discardStateStore) Load(context.Context, string) (*types.UploadState, error) { return nil, nil }
+func (discardStateStore) Save(context.Context, string, *types.UploadState) error   { return nil }
+func (discardStateStore) Delete(context.Context, string) error                     { return nil }
+
+// isUploadNotFound reports whether err indicates the multipart upload no longer exists.
+func isUploadNotFound(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchUpload"
+}
+
+// partSource reads byte ranges of an upload body for concurrent part uploads.
+type partSource struct {
+	mu   sync.Mutex
+	body io.ReadSeeker
+	at   io.ReaderAt
+}
+
+func newPartSource(body io.ReadSeeker) *partSource {
+	at, _ := body.(io.ReaderAt)
+	return &partSource{body: body, at: at}
+}
+
+// section returns a seekable reader over length bytes at offset. Bodies implementing io.ReaderAt
+// are read directly; others are buffered under a lock because their position is shared.
+func (p *partSource) section(offset, length int64) (io.ReadSeeker, error) {
+	if p.at != nil {
+		return io.NewSectionReader(p.at, offset, length), nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.body.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(p.body, buf); err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(buf), nil
+}
@@ -0,0 +1,375 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// memStateStore is an in-memory UploadStateStore.
+type memStateStore struct {
+	mu     sync.Mutex
+	states map[string]types.UploadState
+}
+
+func (m *memStateStore) Load(_ context.Context, id string) (*types.UploadState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[id]
+	if !ok {
+		return nil, nil
+	}
+	state.Parts = append([]types.UploadPart(nil), state.Parts...)
+	return &state, nil
+}
+
+func (m *memStateStore) Save(_ context.Context, id string, state *types.UploadState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.states == nil {
+		m.states = map[string]types.UploadState{}
+	}
+	saved := *state
+	saved.Parts = append([]types.UploadPart(nil), state.Parts...)
+	m.states[id] = saved
+	return nil
+}
+
+func (m *memStateStore) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.states, id)
+	return nil
+}
+
+// fakeMultipart records multipart uploads made through a stubAPI.
+type fakeMultipart struct {
+	mu        sync.Mutex
+	uploads   map[string]map[int32][]byte
+	created   int
+	create    *s3.CreateMultipartUploadInput
+	aborted   []string
+	uploaded  []int32
+	completed *s3.CompleteMultipartUploadInput
+	failPart  int32
+	// contentMD5s counts parts uploaded with a Content-MD5 header.
+	contentMD5s int
+	// completeETag overrides the ETag returned on completion.
+	completeETag string
+}
+
+func (f *fakeMultipart) install(api *stubAPI) {
+	f.uploads = map[string]map[int32][]byte{}
+	api.createMultipartUpload = func(ctx context.Context, params *s3.CreateMultipartUploadInput) (*s3.CreateMultipartUploadOutput, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.created++
+		f.create = params
+		id := fmt.Sprintf("upload-%d", f.created)
+		f.uploads[id] = map[int32][]byte{}
+		return &s3.CreateMultipartUploadOutput{UploadId: aws.String(id)}, nil
+	}
+	api.uploadPart = func(ctx context.Context, params *s3.UploadPartInput) (*s3.UploadPartOutput, error) {
+		n := aws.ToInt32(params.PartNumber)
+		if n == f.failPart {
+			return nil, errors.New("connection reset")
+		}
+		data, err := io.ReadAll(params.Body)
+		if err != nil {
+			return nil, err
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.uploads[aws.ToString(params.UploadId)][n] = data
+		f.uploaded = append(f.uploaded, n)
+		if params.ContentMD5 != nil {
+			f.contentMD5s++
+		}
+		return &s3.UploadPartOutput{ETag: aws.String(md5ETag(data)), ChecksumCRC32: aws.String(checksumOf(types.ChecksumCRC32, data))}, nil
+	}
+	api.listPartsOf = func(ctx context.Context, bucket, key, uploadID string) ([]s3types.Part, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		parts, ok := f.uploads[uploadID]
+		if !ok {
+			return nil, apiErr{code: "NoSuchUpload"}
+		}
+		out := make([]s3types.Part, 0, len(parts))
+		for n, data := range parts {
+			out = append(out, s3types.Part{
+				PartNumber:    aws.Int32(n),
+				ETag:          aws.String(md5ETag(data)),
+				Size:          aws.Int64(int64(len(data))),
+				ChecksumCRC32: aws.String(checksumOf(types.ChecksumCRC32, data)),
+			})
+		}
+		return out, nil
+	}
+	api.completeMultipartUpload = func(ctx context.Context, params *s3.CompleteMultipartUploadInput) (*s3.CompleteMultipartUploadOutput, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.completed = params
+		etags := make([]string, 0, len(params.MultipartUpload.Parts))
+		for _, p := range params.MultipartUpload.Parts {
+			etags = append(etags, aws.ToString(p.ETag))
+		}
+		etag, err := multipartETag(etags)
+		if err != nil {
+			return nil, err
+		}
+		if f.completeETag != "" {
+			etag = f.completeETag
+		}
+		return &s3.CompleteMultipartUploadOutput{ETag: aws.String(etag)}, nil
+	}
+	api.abortMultipartUpload = func(ctx context.Context, params *s3.AbortMultipartUploadInput) (*s3.AbortMultipartUploadOutput, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.aborted = append(f.aborted, aws.ToString(params.UploadId))
+		delete(f.uploads, aws.ToString(params.UploadId))
+		return &s3.AbortMultipartUploadOutput{}, nil
+	}
+}
+
+// md5ETag returns the quoted ETag S3 assigns to data stored in a single request.
+func md5ETag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// assembled returns the object the completed upload would produce.
+func (f *fakeMultipart) assembled() []byte {
+	var out []byte
+	for _, p := range f.completed.MultipartUpload.Parts {
+		out = append(out, f.uploads[aws.ToString(f.completed.UploadId)][aws.ToInt32(p.PartNumber)]...)
+	}
+	return out
+}
+
+var _ = Describe("Resumable uploads", func() {
+	var (
+		sut     *S3
+		api     *stubAPI
+		fake    *fakeMultipart
+		store   *memStateStore
+		payload []byte
+	)
+
+	resumable := func(o *types.PutObjectOptions) {
+		o.Resumable = true
+		o.StateStore = store
+		o.Multipart = types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib, Concurrency: 2}
+	}
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		fake = &fakeMultipart{}
+		fake.install(api)
+		store = &memStateStore{}
+		payload = bytes.Repeat([]byte("0123456789abcdef"), 12*mib/16)
+	})
+
+	It("uploads every part and removes the state once complete", func() {
+		err := sut.PutObject(context.Background(), "bucket-a", "big.bin", bytes.NewReader(payload), resumable, func(o *types.PutObjectOptions) {
+			o.IfNoneMatch = types.ETagAny
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fake.created).To(Equal(1))
+		Expect(fake.uploaded).To(ConsistOf(int32(1), int32(2), int32(3)))
+		Expect(fake.completed.MultipartUpload.Parts).To(HaveLen(3))
+		for i, p := range fake.completed.MultipartUpload.Parts {
+			Expect(aws.ToInt32(p.PartNumber)).To(Equal(int32(i + 1)))
+		}
+		Expect(aws.ToString(fake.completed.IfNoneMatch)).To(Equal("*"))
+		Expect(fake.assembled()).To(Equal(payload))
+		Expect(store.states).To(BeEmpty())
+	})
+
+	It("resumes a failed upload by sending only the missing parts", func() {
+		fake.failPart = 2
+		err := sut.PutObject(context.Background(), "bucket-a", "big.bin", bytes.NewReader(payload), resumable)
+		Expect(err).To(HaveOccurred())
+		Expect(fake.completed).To(BeNil())
+		Expect(store.states).To(HaveKey("bucket-a/big.bin"))
+		Expect(fake.aborted).To(BeEmpty())
+		var stored []int32
+		for n := range fake.uploads["upload-1"] {
+			stored = append(stored, n)
+		}
+
+		fake.failPart = 0
+		fake.uploaded = nil
+		// A body without io.ReaderAt is read part by part under a lock.
+		body := struct{ io.ReadSeeker }{bytes.NewReader(payload)}
+		err = sut.PutObject(context.Background(), "bucket-a", "big.bin", body, resumable)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fake.created).To(Equal(1))
+		Expect(fake.uploaded).To(ContainElement(int32(2)))
+		Expect(fake.uploaded).To(HaveLen(3 - len(stored)))
+		for _, n := range stored {
+			Expect(fake.uploaded).NotTo(ContainElement(n))
+		}
+		Expect(fake.assembled()).To(Equal(payload))
+		Expect(store.states).To(BeEmpty())
+	})
+
+	It("uploads stored parts again when the body changed between attempts", func() {
+		fake.failPart = 3
+		err := sut.PutObject(context.Background(), "bucket-a", "big.bin", bytes.NewReader(payload), resumable)
+		Expect(err).To(HaveOccurred())
+		Expect(fake.uploads["upload-1"]).To(HaveKey(int32(1)))
+
+		changed := bytes.Clone(payload)
+		copy(changed, "changed")
+		fake.failPart = 0
+		fake.uploaded = nil
+		err = sut.PutObject(context.Background(), "bucket-a", "big.bin", bytes.NewReader(changed), resumable)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fake.created).To(Equal(1))
+		Expect(fake.uploaded).To(ContainElements(int32(1), int32(3)))
+		Expect(fake.assembled()).To(Equal(changed))
+	})
+
+	It("checks stored parts without a checksum against their ETag", func() {
+		Expect(store.Save(context.Background(), "bucket-a/big.bin", &types.UploadState{
+			Bucket: "bucket-a", Key: "big.bin", UploadID: "upload-0", Size: int64(len(payload)), PartSize: 5 * mib,
+		})).To(Succeed())
+		fake.uploads["upload-0"] = map[int32][]byte{1: payload[:5*mib], 2: bytes.Repeat([]byte("x"), 5*mib)}
+		api.listPartsOf = func(ctx context.Context, bucket, key, uploadID string) ([]s3types.Part, error) {
+			var out []s3types.Part
+			for n, data := range fake.uploads[uploadID] {
+				out = append(out, s3types.Part{PartNumber: aws.Int32(n), ETag: aws.String(md5ETag(data)), Size: aws.Int64(int64(len(data)))})
+			}
+			return out, nil
+		}
+
+		err := sut.PutObject(context.Background(), "bucket-a", "big.bin", bytes.NewReader(payload), resumable)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fake.uploaded).To(ConsistOf(int32(2), int32(3)))
+		Expect(fake.assembled()).To(Equal(payload))
+	})
+
+	It("recovers parts stored after the state was last saved", func() {
+		Expect(store.Save(context.Background(), "bucket-a/big.bin", &types.UploadState{
+			Bucket: "bucket-a", Key: "big.bin", UploadID: "upload-0", Size: int64(len(payload)), PartSize: 5 * mib,
+		})).To(Succeed())
+		fake.uploads["upload-0"] = map[int32][]byte{1: payload[:5*mib], 3: payload[10*mib:]}
+
+		err := sut.PutObject(context.Background(), "bucket-a", "big.bin", bytes.NewReader(payload), resumable)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fake.created).To(Equal(0))
+		Expect(fake.uploaded).To(Equal([]int32{2}))
+		Expect(aws.ToString(fake.completed.UploadId)).To(Equal("upload-0"))
+		Expect(fake.assembled()).To(Equal(payload))
+	})
+
+	It("aborts and restarts when the saved state is for a different object size", func() {
+		Expect(store.Save(context.Background(), "bucket-a/big.bin", &types.UploadState{
+			Bucket: "bucket-a", Key: "big.bin", UploadID: "upload-0", Size: 1, PartSize: 5 * mib,
+		})).To(Succeed())
+		fake.uploads["upload-0"] = map[int32][]byte{}
+
+		err := sut.PutObject(context.Background(), "bucket-a", "big.bin", bytes.NewReader(payload), resumable)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fake.aborted).To(Equal([]string{"upload-0"}))
+		Expect(fake.created).To(Equal(1))
+		Expect(fake.assembled()).To(Equal(payload))
+	})
+
+	It("starts again when the saved upload no longer exists", func() {
+		Expect(store.Save(context.Background(), "bucket-a/big.bin", &types.UploadState{
+			Bucket: "bucket-a", Key: "big.bin", UploadID: "gone", Size: int64(len(payload)), PartSize: 5 * mib,
+		})).To(Succeed())
+
+		err := sut.PutObject(context.Background(), "bucket-a", "big.bin", bytes.NewReader(payload), resumable)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fake.created).To(Equal(1))
+		Expect(fake.uploaded).To(HaveLen(3))
+	})
+
+	It("uses the transfer manager below the multipart threshold", func() {
+		fakeClient := &fakeTransferManager{}
+		sut.transfer = fakeClient
+
+		err := sut.PutObject(context.Background(), "bucket-a", "small.txt", bytes.NewReader([]byte("hello")), resumable)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.uploadInput).NotTo(BeNil())
+		Expect(fake.created).To(Equal(0))
+	})
+})
+
+var _ = Describe("FileStateStore", func() {
+	It("saves, loads and deletes state", func() {
+		ctx := context.Background()
+		store := &FileStateStore{Dir: GinkgoT().TempDir()}
+
+		state, err := store.Load(ctx, "bucket-a/key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(BeNil())
+
+		saved := &types.UploadState{Bucket: "bucket-a", Key: "key", UploadID: "id", Size: 10, PartSize: 5,
+			Parts: []types.UploadPart{{PartNumber: 1, ETag: "\"a\"", Size: 5}}}
+		Expect(store.Save(ctx, "bucket-a/key", saved)).To(Succeed())
+
+		state, err = store.Load(ctx, "bucket-a/key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(saved))
+
+		Expect(store.Delete(ctx, "bucket-a/key")).To(Succeed())
+		Expect(store.Delete(ctx, "bucket-a/key")).To(Succeed())
+		state, err = store.Load(ctx, "bucket-a/key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(BeNil())
+	})
+})
+
+var _ = Describe("forEach", func() {
+	It("returns the first error and stops handing out work", func() {
+		var mu sync.Mutex
+		calls := 0
+		err := forEach(context.Background(), 100, 1, func(ctx context.Context, i int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			if i == 2 {
+				return errors.New("boom")
+			}
+			return nil
+		})
+		Expect(err).To(MatchError("boom"))
+		Expect(calls).To(BeNumerically("<", 100))
+	})
+})
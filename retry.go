@@ -0,0 +1,76 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// RetryError records how many attempts were made before a request failed.
+type RetryError struct {
+	// Attempts is the number of attempts made, including the first.
+	Attempts int
+	// Err is the error returned by the final attempt.
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v (attempts: %d)", e.Err, e.Attempts)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Attempts returns the number of attempts recorded in err, or 0 if err carries no attempt count.
+func Attempts(err error) int {
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		return retryErr.Attempts
+	}
+	return 0
+}
+
+// attemptCounter wraps errors leaving the SDK retry loop with the number of attempts made.
+type attemptCounter struct{}
+
+func (attemptCounter) ID() string { return "SimpleS3AttemptCounter" }
+
+func (attemptCounter) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	middleware.FinalizeOutput, middleware.Metadata, error,
+) {
+	out, metadata, err := next.HandleFinalize(ctx, in)
+	if err != nil {
+		if results, ok := retry.GetAttemptResults(metadata); ok && len(results.Results) > 0 {
+			err = &RetryError{Attempts: len(results.Results), Err: err}
+		}
+	}
+	return out, metadata, err
+}
+
+// addAttemptCounter installs attemptCounter around the SDK retry middleware.
+func addAttemptCounter(stack *middleware.Stack) error {
+	if _, ok := stack.Finalize.Get("Retry"); !ok {
+		return nil
+	}
+	return stack.Finalize.Insert(attemptCounter{}, "Retry", middleware.Before)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retry", func() {
+
+	Describe("Attempts", func() {
+		It("returns the attempt count from a wrapped error", func() {
+			err := errors.Join(errors.New("outer"), &RetryError{Attempts: 3, Err: errors.New("boom")})
+			Expect(Attempts(err)).To(Equal(3))
+		})
+
+		It("returns zero when no attempt count is present", func() {
+			Expect(Attempts(errors.New("boom"))).To(BeZero())
+			Expect(Attempts(nil)).To(BeZero())
+		})
+	})
+
+	Describe("RetryError", func() {
+		It("includes the attempt count in the message and unwraps", func() {
+			inner := errors.New("boom")
+			err := &RetryError{Attempts: 2, Err: inner}
+			Expect(err.Error()).To(Equal("boom (attempts: 2)"))
+			Expect(errors.Is(err, inner)).To(BeTrue())
+		})
+	})
+
+	Describe("against a throttling endpoint", func() {
+		var (
+			server   *httptest.Server
+			requests atomic.Int32
+		)
+
+		BeforeEach(func() {
+			requests.Store(0)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`<Error><Code>SlowDown</Code><Message>Please reduce your request rate.</Message></Error>`))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("retries up to the configured attempts and reports them in the error", func() {
+			sut, err := New(context.Background(), server.URL, "ak", "sk", "", func(o *Options) {
+				o.Retry.MaxAttempts = 4
+				o.Retry.MaxBackoff = time.Millisecond
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = sut.CreateBucket(context.Background(), "bucket-a")
+			Expect(err).To(HaveOccurred())
+			Expect(Attempts(err)).To(Equal(4))
+			Expect(requests.Load()).To(Equal(int32(4)))
+		})
+
+		It("does not retry when a single attempt is configured", func() {
+			sut, err := New(context.Background(), server.URL, "ak", "sk", "", func(o *Options) {
+				o.Retry.MaxAttempts = 1
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = sut.CreateBucket(context.Background(), "bucket-a")
+			Expect(err).To(HaveOccurred())
+			Expect(Attempts(err)).To(Equal(1))
+			Expect(requests.Load()).To(Equal(int32(1)))
+		})
+	})
+})
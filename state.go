@@ -0,0 +1,153 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// FileStateStore persists resumable upload state as JSON files in a directory.
+type FileStateStore struct {
+	// Dir is the directory holding the state files. It is created on first save.
+	Dir string
+}
+
+// NewFileStateStore returns a FileStateStore rooted in the simple-s3 directory of the user cache,
+// falling back to the system temporary directory when no cache directory is available.
+func NewFileStateStore() *FileStateStore {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return &FileStateStore{Dir: filepath.Join(dir, "simple-s3", "uploads")}
+}
+
+// Load returns the state stored under id, or nil if there is none.
+func (f *FileStateStore) Load(_ context.Context, id string) (*types.UploadState, error) {
+	state := &types.UploadState{}
+	if ok, err := readJSON(statePath(f.Dir, id), state); !ok || err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save atomically replaces the state stored under id.
+func (f *FileStateStore) Save(_ context.Context, id string, state *types.UploadState) error {
+	return writeJSON(f.Dir, statePath(f.Dir, id), state)
+}
+
+// Delete removes the state stored under id.
+func (f *FileStateStore) Delete(_ context.Context, id string) error {
+	return removeFile(statePath(f.Dir, id))
+}
+
+// FileCheckpointStore persists replication checkpoints as JSON files in a directory.
+type FileCheckpointStore struct {
+	// Dir is the directory holding the checkpoint files. It is created on first save.
+	Dir string
+}
+
+// NewFileCheckpointStore returns a FileCheckpointStore rooted in the simple-s3 directory of the user
+// cache, falling back to the system temporary directory when no cache directory is available.
+func NewFileCheckpointStore() *FileCheckpointStore {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return &FileCheckpointStore{Dir: filepath.Join(dir, "simple-s3", "checkpoints")}
+}
+
+// Load returns the checkpoint stored under id, or nil if there is none.
+func (f *FileCheckpointStore) Load(_ context.Context, id string) (*types.ReplicationCheckpoint, error) {
+	checkpoint := &types.ReplicationCheckpoint{}
+	if ok, err := readJSON(statePath(f.Dir, id), checkpoint); !ok || err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// Save atomically replaces the checkpoint stored under id.
+func (f *FileCheckpointStore) Save(_ context.Context, id string, checkpoint *types.ReplicationCheckpoint) error {
+	return writeJSON(f.Dir, statePath(f.Dir, id), checkpoint)
+}
+
+// Delete removes the checkpoint stored under id.
+func (f *FileCheckpointStore) Delete(_ context.Context, id string) error {
+	return removeFile(statePath(f.Dir, id))
+}
+
+// statePath returns the file in dir for id, hashing it so any bucket and key is a valid file name.
+func statePath(dir, id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// readJSON decodes the file at path into v, reporting false if the file does not exist.
+func readJSON(path string, v any) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// writeJSON atomically replaces the file at path, which must be in dir, with v encoded as JSON.
+func writeJSON(dir, path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// removeFile removes the file at path. A missing file is not an error.
+func removeFile(path string) error {
+	err := os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
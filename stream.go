@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// PutObjectStream uploads content read from a non-seekable reader, such as a pipe, an HTTP request
+// body or the output of a compressor.
+//
+// The total length does not need to be known up front. Content shorter than the part size is sent
+// in a single request and anything larger is streamed part by part, so only one part per upload
+// worker is held in memory at a time. Setting PutObjectOptions.ContentLength allows the part size to
+// be scaled for streams that would otherwise exceed the 10,000 part limit.
+func (s *S3) PutObjectStream(ctx context.Context, bucket, key string, body io.Reader, optFns ...func(*types.PutObjectOptions)) (err error) {
+	ctx, call := s.startOperation(ctx, "PutObjectStream", bucket, key)
+	defer func() { call.end(ctx, err) }()
+
+	o := types.PutObjectOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	counted := &countingReader{r: body}
+	buffered := bufio.NewReaderSize(counted, sniffLen)
+	contentType, err := s.contentType(key, o.ContentType, func() (string, error) {
+		return peekContentType(buffered)
+	})
+	if err != nil {
+		return newError("PutObjectStream", bucket, key, err)
+	}
+
+	size := int64(-1)
+	if o.ContentLength > 0 {
+		size = o.ContentLength
+	}
+
+	alg, err := checksumAlgorithm(o)
+	if err != nil {
+		return newError("PutObjectStream", bucket, key, err)
+	}
+
+	err = s.upload(ctx, bucket, key, contentType, buffered, size, alg, o)
+	if err == nil {
+		call.setSize(counted.n)
+		call.transferred(ctx, counted.n)
+	}
+	return newError("PutObjectStream", bucket, key, err)
+}
+
+// peekContentType detects the content type from the buffered head of the reader without consuming it.
+func peekContentType(body *bufio.Reader) (string, error) {
+	header, err := body.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return http.DetectContentType(header), nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+var _ = Describe("PutObjectStream", func() {
+	var (
+		sut        *S3
+		fakeClient *fakeTransferManager
+	)
+
+	BeforeEach(func() {
+		sut, _ = newStubbed()
+		fakeClient = &fakeTransferManager{}
+		sut.transfer = fakeClient
+	})
+
+	It("streams a compressor's output without requiring a seekable body", func() {
+		pr, pw := io.Pipe()
+		go func() {
+			zw := gzip.NewWriter(pw)
+			_, _ = zw.Write([]byte(strings.Repeat("log line\n", 1000)))
+			_ = zw.Close()
+			_ = pw.Close()
+		}()
+
+		err := sut.PutObjectStream(context.Background(), "bucket-a", "logs.gz", pr, func(o *types.PutObjectOptions) {
+			o.IfNoneMatch = types.ETagAny
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.uploadInput).NotTo(BeNil())
+		_, seekable := fakeClient.uploadInput.Body.(io.Seeker)
+		Expect(seekable).To(BeFalse())
+		Expect(aws.ToString(fakeClient.uploadInput.ContentType)).To(Equal("application/gzip"))
+		Expect(aws.ToString(fakeClient.uploadInput.IfNoneMatch)).To(Equal("*"))
+
+		zr, err := gzip.NewReader(fakeClient.uploadInput.Body)
+		Expect(err).NotTo(HaveOccurred())
+		content, err := io.ReadAll(zr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(strings.Repeat("log line\n", 1000)))
+	})
+
+	It("handles an empty stream", func() {
+		err := sut.PutObjectStream(context.Background(), "bucket-a", "empty", strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(aws.ToString(fakeClient.uploadInput.ContentType)).To(Equal("text/plain; charset=utf-8"))
+	})
+
+	It("returns read errors from the content sniff", func() {
+		err := sut.PutObjectStream(context.Background(), "bucket-a", "key-a", &failingReadSeeker{})
+		Expect(err).To(HaveOccurred())
+		Expect(fakeClient.uploadInput).To(BeNil())
+	})
+
+	It("returns transfer upload errors", func() {
+		fakeClient.err = errors.New("upload failed")
+
+		err := sut.PutObjectStream(context.Background(), "bucket-a", "key-a", strings.NewReader("hello"))
+		Expect(err).To(HaveOccurred())
+
+		var e *Error
+		Expect(errors.As(err, &e)).To(BeTrue())
+		Expect(e.Op).To(Equal("PutObjectStream"))
+	})
+
+	It("uploads a stream end to end", func() {
+		var received []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPut))
+			Expect(r.URL.Path).To(Equal("/bucket-a/key-a"))
+			var err error
+			received, err = io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			w.Header().Set("ETag", `"etag-a"`)
+		}))
+		defer server.Close()
+
+		client, err := New(context.Background(), server.URL, "ak", "sk", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		pr, pw := io.Pipe()
+		go func() {
+			_, _ = pw.Write([]byte("streamed payload"))
+			_ = pw.Close()
+		}()
+
+		Expect(client.PutObjectStream(context.Background(), "bucket-a", "key-a", pr)).To(Succeed())
+		Expect(string(received)).To(ContainSubstring("streamed payload"))
+	})
+
+	Describe("peekContentType", func() {
+		It("does not consume the peeked bytes", func() {
+			r := bufio.NewReaderSize(bytes.NewReader([]byte("<html><body>hi</body></html>")), sniffLen)
+			ct, err := peekContentType(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ct).To(Equal("text/html; charset=utf-8"))
+
+			rest, err := io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(rest)).To(Equal("<html><body>hi</body></html>"))
+		})
+	})
+})
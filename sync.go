@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/drewbernetes/simple-s3/internal/compression"
+	"github.com/drewbernetes/simple-s3/internal/dirwalk"
+	"github.com/drewbernetes/simple-s3/internal/syncplan"
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+// errDeleteSkipped marks deletions not made because an upload in the same sync failed.
+var errDeleteSkipped = errors.New("deletion skipped because an upload failed")
+
+// Sync makes the objects under prefix match the files below localDir, uploading only what changed.
+//
+// A file is uploaded when no object exists for it, the sizes differ, or the file was modified after
+// the object was last written. With the Checksum option the contents are compared with the object's
+// ETag instead of the modification time. Compressed objects are compared with the uncompressed size
+// they record, read with an extra HeadObject request when the listed size differs, and by
+// modification time, since their ETag covers the compressed bytes. With Delete, objects under the prefix with no local file
+// are removed once every upload has succeeded; objects the filters exclude are kept. A dry run
+// returns the plan without making changes.
+func (s *S3) Sync(ctx context.Context, localDir, bucket, prefix string, optFns ...func(*types.SyncOptions)) (_ *types.SyncResult, err error) {
+	ctx, call := s.startOperation(ctx, "Sync", bucket, prefix)
+	defer func() { call.end(ctx, err) }()
+
+	o := types.SyncOptions{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if err = dirwalk.ValidateGlobs(o.Include, o.Exclude); err != nil {
+		return nil, newError("Sync", bucket, prefix, err)
+	}
+
+	w, err := dirwalk.Walk(localDir, prefix, types.UploadDirectoryOptions{Include: o.Include, Exclude: o.Exclude, FollowSymlinks: o.FollowSymlinks})
+	if err != nil {
+		return nil, newError("Sync", bucket, prefix, err)
+	}
+
+	// Listing below the prefix as a directory keeps "site/v1" from matching "site/v10".
+	listPrefix := ""
+	if prefix != "" {
+		listPrefix = strings.TrimSuffix(prefix, "/") + "/"
+	}
+	listed, err := listObjectsV2All(ctx, s.client(), bucket, listPrefix)
+	if err != nil {
+		return nil, newError("Sync", bucket, prefix, err)
+	}
+	objects := make([]syncplan.Object, 0, len(listed))
+	remote := make(map[string]*syncplan.Object, len(listed))
+	for _, obj := range listed {
+		objects = append(objects, syncplan.Object{
+			Key:          aws.ToString(obj.Key),
+			Size:         aws.ToInt64(obj.Size),
+			ETag:         aws.ToString(obj.ETag),
+			LastModified: aws.ToTime(obj.LastModified),
+		})
+	}
+	for i := range objects {
+		remote[objects[i].Key] = &objects[i]
+	}
+
+	concurrency := int(firstPositive(int64(o.Concurrency), defaultConcurrency))
+	result := &types.SyncResult{DryRun: o.DryRun}
+	reasons := make([]string, len(w.Files))
+	err = forEach(ctx, len(w.Files), concurrency, func(ctx context.Context, i int) error {
+		f := w.Files[i]
+		var err error
+		reasons[i], err = syncplan.Reason(f, remote[f.Key], o.Checksum, func(path, etag string) (bool, error) {
+			return fileMatchesETag(path, etag, s.etagPartSize(0, f.Size))
+		}, func(key string) (int64, error) {
+			return s.originalSize(ctx, bucket, key)
+		})
+		return newError("Sync", bucket, f.Key, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, f := range w.Files {
+		if reasons[i] == "" {
+			result.Unchanged++
+			continue
+		}
+		result.Operations = append(result.Operations, types.SyncOperation{Action: types.SyncUpload, Path: f.Path, Key: f.Key, Size: f.Size, Reason: reasons[i]})
+		result.Bytes += f.Size
+	}
+	uploads := len(result.Operations)
+
+	if o.Delete {
+		for _, obj := range syncplan.Orphans(w, objects, listPrefix, o.Include, o.Exclude) {
+			result.Operations = append(result.Operations, types.SyncOperation{Action: types.SyncDelete, Key: obj.Key, Size: obj.Size, Reason: "not present locally"})
+		}
+	}
+	syncplan.Sort(result.Operations)
+
+	errs := make([]error, 0, len(w.Failed))
+	for _, f := range w.Failed {
+		errs = append(errs, newError("Sync", bucket, f.Key, f.Err))
+	}
+	call.setSize(result.Bytes)
+	if o.DryRun {
+		return result, errors.Join(errs...)
+	}
+
+	ops := result.Operations
+	err = forEach(ctx, uploads, concurrency, func(ctx context.Context, i int) error {
+		op := &ops[i]
+		op.Err = s.uploadFile(ctx, bucket, types.FileTransfer{Path: op.Path, Key: op.Key}, o.ObjectOptions)
+		return nil
+	})
+	failed := err != nil
+	for _, op := range ops[:uploads] {
+		if op.Err != nil {
+			failed = true
+			result.Bytes -= op.Size
+		}
+	}
+
+	if failed {
+		for i := uploads; i < len(ops); i++ {
+			ops[i].Err = errDeleteSkipped
+		}
+	} else {
+		deletes := ops[uploads:]
+		err = forEach(ctx, len(deletes), concurrency, func(ctx context.Context, i int) error {
+			deletes[i].Err = s.DeleteObject(ctx, bucket, deletes[i].Key)
+			return nil
+		})
+	}
+
+	for _, op := range ops {
+		if op.Err != nil && op.Err != errDeleteSkipped {
+			errs = append(errs, op.Err)
+		}
+	}
+	if err != nil {
+		errs = append(errs, newError("Sync", bucket, prefix, err))
+	}
+	return result, errors.Join(errs...)
+}
+
+// originalSize returns the uncompressed size recorded for a compressed object, or -1 if the object
+// is not compressed or no longer exists.
+func (s *S3) originalSize(ctx context.Context, bucket, key string) (int64, error) {
+	head, err := s.client().HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if isNotFoundError(err) {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if compression.Decoding(aws.ToString(head.ContentEncoding)) == "" {
+		return -1, nil
+	}
+	return compression.OriginalSize(head.Metadata), nil
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+func operations(result *types.SyncResult) []string {
+	out := make([]string, 0, len(result.Operations))
+	for _, op := range result.Operations {
+		out = append(out, op.String())
+	}
+	return out
+}
+
+var _ = Describe("Sync", func() {
+	var (
+		sut      *S3
+		api      *stubAPI
+		fake     *bucketTransferManager
+		dir      string
+		remote   []s3types.Object
+		listed   string
+		mu       sync.Mutex
+		deleted  []string
+		uploaded time.Time
+	)
+
+	BeforeEach(func() {
+		sut, api = newStubbed()
+		fake = &bucketTransferManager{objects: map[string]string{}}
+		sut.transfer = fake
+		dir = GinkgoT().TempDir()
+		writeTree(dir, map[string]string{
+			"index.html":          "<html></html>",
+			"assets/app.js":       "console.log(1)",
+			"node_modules/x/a.js": "x",
+		})
+		uploaded = time.Now().Add(time.Hour)
+		remote = nil
+		deleted = nil
+
+		api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
+			listed = prefix
+			return remote, nil
+		}
+		api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+			return &s3.HeadObjectOutput{}, nil
+		}
+		api.deleteObject = func(ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			deleted = append(deleted, aws.ToString(params.Key))
+			return &s3.DeleteObjectOutput{}, nil
+		}
+	})
+
+	object := func(key, content string, modified time.Time) s3types.Object {
+		return s3types.Object{
+			Key:          aws.String(key),
+			Size:         aws.Int64(int64(len(content))),
+			ETag:         aws.String(md5ETag([]byte(content))),
+			LastModified: aws.Time(modified),
+		}
+	}
+
+	It("uploads new files under the prefix", func() {
+		result, err := sut.Sync(context.Background(), dir, "bucket-a", "site/v1", func(o *types.SyncOptions) {
+			o.Exclude = []string{"node_modules"}
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(listed).To(Equal("site/v1/"))
+		Expect(operations(result)).To(Equal([]string{
+			"upload site/v1/assets/app.js (new)",
+			"upload site/v1/index.html (new)",
+		}))
+		Expect(result.Bytes).To(Equal(int64(14 + 13)))
+		Expect(fake.objects).To(HaveKeyWithValue("site/v1/index.html", "<html></html>"))
+	})
+
+	It("uploads only files whose size or modification time changed", func() {
+		writeTree(dir, map[string]string{"robots.txt": "User-agent: *"})
+		Expect(os.Chtimes(filepath.Join(dir, "robots.txt"), uploaded.Add(time.Hour), uploaded.Add(time.Hour))).To(Succeed())
+		remote = []s3types.Object{
+			object("index.html", "<html></html>", uploaded),
+			object("assets/app.js", "console.log(10)", uploaded),
+			object("robots.txt", "User-agent: *", uploaded),
+		}
+
+		result, err := sut.Sync(context.Background(), dir, "bucket-a", "", func(o *types.SyncOptions) {
+			o.Exclude = []string{"node_modules"}
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(operations(result)).To(Equal([]string{
+			"upload assets/app.js (size changed)",
+			"upload robots.txt (modified)",
+		}))
+		Expect(result.Unchanged).To(Equal(1))
+		Expect(fake.objects).NotTo(HaveKey("index.html"))
+	})
+
+	It("compares compressed objects with the original size they record", func() {
+		remote = []s3types.Object{
+			object("index.html", "compressed", uploaded),
+			object("assets/app.js", "compressed", uploaded),
+		}
+		var heads []string
+		api.headObject = func(ctx context.Context, params *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			heads = append(heads, aws.ToString(params.Key))
+			return &s3.HeadObjectOutput{
+				ContentEncoding: aws.String("gzip"),
+				Metadata:        map[string]string{"original-size": "13"},
+			}, nil
+		}
+
+		result, err := sut.Sync(context.Background(), dir, "bucket-a", "", func(o *types.SyncOptions) {
+			o.Exclude = []string{"node_modules"}
+			o.Checksum = true
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(heads).To(ConsistOf("index.html", "assets/app.js"))
+		Expect(operations(result)).To(Equal([]string{"upload assets/app.js (size changed)"}))
+		Expect(result.Unchanged).To(Equal(1))
+	})
+
+	It("compares contents with ETags when checksumming", func() {
+		writeTree(dir, map[string]string{"assets/app.js": "console.log(2)"})
+		earlier := time.Now().Add(-time.Hour)
+		remote = []s3types.Object{
+			object("index.html", "<html></html>", earlier),
+			object("assets/app.js", "console.log(1)", earlier),
+		}
+
+		result, err := sut.Sync(context.Background(), dir, "bucket-a", "", func(o *types.SyncOptions) {
+			o.Exclude = []string{"node_modules"}
+			o.Checksum = true
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(operations(result)).To(Equal([]string{"upload assets/app.js (content changed)"}))
+		Expect(result.Unchanged).To(Equal(1))
+	})
+
+	It("deletes remote keys with no local file except those the filters exclude", func() {
+		remote = []s3types.Object{
+			object("site/index.html", "<html></html>", uploaded),
+			object("site/assets/app.js", "console.log(1)", uploaded),
+			object("site/old.html", "old", uploaded),
+			object("site/assets/old.js", "old", uploaded),
+			object("site/node_modules/y/b.js", "y", uploaded),
+			object("site/folder/", "", uploaded),
+		}
+
+		result, err := sut.Sync(context.Background(), dir, "bucket-a", "site/", func(o *types.SyncOptions) {
+			o.Exclude = []string{"node_modules"}
+			o.Delete = true
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(operations(result)).To(Equal([]string{
+			"delete site/assets/old.js (not present locally)",
+			"delete site/old.html (not present locally)",
+		}))
+		Expect(deleted).To(ConsistOf("site/assets/old.js", "site/old.html"))
+	})
+
+	It("keeps remote keys under every link to a shared directory", func() {
+		shared := GinkgoT().TempDir()
+		writeTree(shared, map[string]string{"a.woff2": "font"})
+		Expect(os.Symlink(shared, filepath.Join(dir, "fonts"))).To(Succeed())
+		Expect(os.Symlink(shared, filepath.Join(dir, "static"))).To(Succeed())
+		remote = []s3types.Object{
+			object("fonts/a.woff2", "font", uploaded),
+			object("static/a.woff2", "font", uploaded),
+		}
+
+		_, err := sut.Sync(context.Background(), dir, "bucket-a", "", func(o *types.SyncOptions) {
+			o.FollowSymlinks = true
+			o.Delete = true
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeEmpty())
+	})
+
+	It("does not delete remote keys under unreadable directories", func() {
+		if os.Geteuid() == 0 {
+			Skip("directory permissions do not apply to root")
+		}
+		private := filepath.Join(dir, "private")
+		Expect(os.Mkdir(private, 0o000)).To(Succeed())
+		DeferCleanup(os.Chmod, private, os.FileMode(0o700))
+		remote = []s3types.Object{object("private/secret.txt", "secret", uploaded)}
+
+		_, err := sut.Sync(context.Background(), dir, "bucket-a", "", func(o *types.SyncOptions) {
+			o.Delete = true
+		})
+		Expect(err).To(MatchError(os.ErrPermission))
+		Expect(deleted).To(BeEmpty())
+	})
+
+	It("plans without changing anything in a dry run", func() {
+		remote = []s3types.Object{object("stale.txt", "stale", uploaded)}
+
+		result, err := sut.Sync(context.Background(), dir, "bucket-a", "", func(o *types.SyncOptions) {
+			o.Delete = true
+			o.DryRun = true
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.DryRun).To(BeTrue())
+		Expect(operations(result)).To(Equal([]string{
+			"upload assets/app.js (new)",
+			"upload index.html (new)",
+			"upload node_modules/x/a.js (new)",
+			"delete stale.txt (not present locally)",
+		}))
+		Expect(result.Bytes).To(Equal(int64(14 + 13 + 1)))
+		Expect(fake.objects).To(BeEmpty())
+		Expect(deleted).To(BeEmpty())
+	})
+
+	It("skips deletions when an upload fails", func() {
+		fake.failKeys = map[string]error{"index.html": apiErr{code: "AccessDenied"}}
+		remote = []s3types.Object{object("stale.txt", "stale", uploaded)}
+
+		result, err := sut.Sync(context.Background(), dir, "bucket-a", "", func(o *types.SyncOptions) {
+			o.Delete = true
+		})
+		Expect(err).To(MatchError(ErrAccessDenied))
+
+		Expect(deleted).To(BeEmpty())
+		last := result.Operations[len(result.Operations)-1]
+		Expect(last.Action).To(Equal(types.SyncDelete))
+		Expect(last.Err).To(MatchError(ContainSubstring("deletion skipped")))
+		Expect(result.Bytes).To(Equal(int64(14 + 1)))
+	})
+
+	It("returns listing errors", func() {
+		api.listObjects = func(ctx context.Context, bucket, prefix string) ([]s3types.Object, error) {
+			return nil, apiErr{code: "NoSuchBucket"}
+		}
+
+		_, err := sut.Sync(context.Background(), dir, "bucket-a", "")
+		Expect(err).To(MatchError(ErrBucketNotFound))
+	})
+})
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName is the OpenTelemetry scope used for the spans and metrics recorded by S3.
+const instrumentationName = "github.com/drewbernetes/simple-s3"
+
+// Attribute keys recorded on spans and metrics.
+const (
+	attrOperation = attribute.Key("simple_s3.operation")
+	attrBucket    = attribute.Key("aws.s3.bucket")
+	attrKey       = attribute.Key("aws.s3.key")
+	attrSize      = attribute.Key("simple_s3.size")
+	attrErrorType = attribute.Key("error.type")
+)
+
+// TelemetryOptions configures OpenTelemetry tracing and metrics.
+//
+// The zero value disables both.
+type TelemetryOptions struct {
+	// TracerProvider creates a span for every S3 method. Clients built by New also pass it to the
+	// AWS SDK so the SDK's own request, retry and signing spans are nested beneath them.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider records the latency, bytes transferred and errors of every S3 method. Bytes
+	// are counted once, against the method that moved them, so files uploaded by UploadDirectory
+	// and Sync are counted under PutObject.
+	MeterProvider metric.MeterProvider
+}
+
+// telemetry holds the tracer and instruments derived from TelemetryOptions.
+type telemetry struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	bytes    metric.Int64Counter
+	errors   metric.Int64Counter
+}
+
+// noopTelemetry is used by wrappers that were not created by New or NewFromAPI.
+var noopTelemetry = newTelemetry(TelemetryOptions{})
+
+// newTelemetry creates the tracer and instruments, falling back to no-op providers.
+func newTelemetry(o TelemetryOptions) *telemetry {
+	tp := o.TracerProvider
+	if tp == nil {
+		tp = tracenoop.NewTracerProvider()
+	}
+	mp := o.MeterProvider
+	if mp == nil {
+		mp = metricnoop.NewMeterProvider()
+	}
+
+	meter := mp.Meter(instrumentationName)
+	t := &telemetry{tracer: tp.Tracer(instrumentationName)}
+	// Instrument creation only fails on invalid names or options, which are fixed here; the
+	// returned instrument is still usable so the errors are ignored.
+	t.duration, _ = meter.Float64Histogram("simple_s3.operation.duration",
+		metric.WithDescription("Duration of S3 operations."), metric.WithUnit("s"))
+	t.bytes, _ = meter.Int64Counter("simple_s3.operation.bytes",
+		metric.WithDescription("Bytes uploaded or downloaded by S3 operations."), metric.WithUnit("By"))
+	t.errors, _ = meter.Int64Counter("simple_s3.operation.errors",
+		metric.WithDescription("Number of failed S3 operations."), metric.WithUnit("{error}"))
+	return t
+}
+
+// operation is an in-flight S3 method call being logged, traced and measured.
+type operation struct {
+	t     *telemetry
+	log   *slog.Logger
+	name  string
+	span  trace.Span
+	start time.Time
+	size  int64
+}
+
+// startOperation logs and starts a span for the S3 method op and returns the context carrying it.
+// The caller must call end once the method returns.
+func (s *S3) startOperation(ctx context.Context, op, bucket, key string) (context.Context, *operation) {
+	t := s.telemetry
+	if t == nil {
+		t = noopTelemetry
+	}
+
+	attrs := []attribute.KeyValue{attrOperation.String(op)}
+	logAttrs := []any{slog.String("operation", op)}
+	if bucket != "" {
+		attrs = append(attrs, attrBucket.String(bucket))
+		logAttrs = append(logAttrs, slog.String("bucket", bucket))
+	}
+	if key != "" {
+		attrs = append(attrs, attrKey.String(key))
+		logAttrs = append(logAttrs, slog.String("key", key))
+	}
+	ctx, span := t.tracer.Start(ctx, "simple_s3."+op,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	log := s.logger().With(logAttrs...)
+	log.DebugContext(ctx, "starting S3 operation")
+	return ctx, &operation{t: t, log: log, name: op, span: span, start: time.Now(), size: -1}
+}
+
+// setSize records the size of the object, or the total size of the objects, the operation handled.
+func (o *operation) setSize(n int64) {
+	o.size = n
+	o.span.SetAttributes(attrSize.Int64(n))
+}
+
+// transferred counts n bytes moved between the client and the service.
+func (o *operation) transferred(ctx context.Context, n int64) {
+	if n > 0 {
+		o.t.bytes.Add(ctx, n, metric.WithAttributes(attrOperation.String(o.name)))
+	}
+}
+
+// end logs the outcome, finishes the span and records the operation's latency.
+func (o *operation) end(ctx context.Context, err error) {
+	elapsed := time.Since(o.start)
+	logAttrs := []any{slog.Duration("duration", elapsed)}
+	if o.size >= 0 {
+		logAttrs = append(logAttrs, slog.Int64("size", o.size))
+	}
+	if err != nil {
+		o.log.WarnContext(ctx, "S3 operation failed", append(logAttrs, slog.Any("error", err))...)
+	} else {
+		o.log.DebugContext(ctx, "S3 operation completed", logAttrs...)
+	}
+
+	attrs := []attribute.KeyValue{attrOperation.String(o.name)}
+	if err != nil {
+		errType := errorType(err)
+		o.span.RecordError(err)
+		o.span.SetStatus(codes.Error, err.Error())
+		o.span.SetAttributes(attrErrorType.String(errType))
+		attrs = append(attrs, attrErrorType.String(errType))
+		o.t.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	o.t.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+	o.span.End()
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// errorType returns a low-cardinality description of err: the API error code, the HTTP status
+// code, or a generic value when neither is known.
+func errorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		if e.Code != "" {
+			return e.Code
+		}
+		if e.StatusCode != 0 {
+			return strconv.Itoa(e.StatusCode)
+		}
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return "_OTHER"
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/drewbernetes/simple-s3/pkg/s3test"
+)
+
+var _ = Describe("Telemetry", func() {
+	var (
+		ctx      context.Context
+		server   *s3test.Server
+		exporter *tracetest.InMemoryExporter
+		reader   *sdkmetric.ManualReader
+		sut      *S3
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = s3test.NewServer()
+		DeferCleanup(server.Close)
+
+		exporter = tracetest.NewInMemoryExporter()
+		reader = sdkmetric.NewManualReader()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		var err error
+		sut, err = New(ctx, server.URL, "access-key", "secret-key", "", func(o *Options) {
+			o.Telemetry = TelemetryOptions{TracerProvider: tp, MeterProvider: mp}
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sut.CreateBucket(ctx, "bucket-a")).To(Succeed())
+		exporter.Reset()
+	})
+
+	// spanNamed returns the single ended span with the given name.
+	spanNamed := func(name string) tracetest.SpanStub {
+		GinkgoHelper()
+		var found []tracetest.SpanStub
+		for _, span := range exporter.GetSpans() {
+			if span.Name == name {
+				found = append(found, span)
+			}
+		}
+		Expect(found).To(HaveLen(1))
+		return found[0]
+	}
+
+	// metricNamed collects the metrics and returns the one with the given name.
+	metricNamed := func(name string) metricdata.Metrics {
+		GinkgoHelper()
+		var rm metricdata.ResourceMetrics
+		Expect(reader.Collect(ctx, &rm)).To(Succeed())
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name == name {
+					return m
+				}
+			}
+		}
+		Fail("no metric named " + name)
+		return metricdata.Metrics{}
+	}
+
+	It("records a span with the bucket, key and size of each call", func() {
+		Expect(sut.PutObject(ctx, "bucket-a", "key-a", bytes.NewReader([]byte("payload")))).To(Succeed())
+
+		span := spanNamed("simple_s3.PutObject")
+		Expect(span.Attributes).To(ContainElements(
+			attribute.String("simple_s3.operation", "PutObject"),
+			attribute.String("aws.s3.bucket", "bucket-a"),
+			attribute.String("aws.s3.key", "key-a"),
+			attribute.Int64("simple_s3.size", 7),
+		))
+		Expect(span.Status.Code).To(Equal(codes.Unset))
+	})
+
+	It("nests the SDK's request spans beneath the call", func() {
+		_, err := sut.FetchObject(ctx, "missing", "bucket-a")
+		Expect(err).To(HaveOccurred())
+
+		parent := spanNamed("simple_s3.FetchObject")
+		sdk := spanNamed("S3.GetObject")
+		Expect(sdk.Parent.SpanID()).To(Equal(parent.SpanContext.SpanID()))
+		Expect(sdk.SpanContext.TraceID()).To(Equal(parent.SpanContext.TraceID()))
+	})
+
+	It("marks failed calls and counts them by error code", func() {
+		_, err := sut.FetchObject(ctx, "missing", "bucket-a")
+		Expect(err).To(MatchError(ErrObjectNotFound))
+
+		span := spanNamed("simple_s3.FetchObject")
+		Expect(span.Status.Code).To(Equal(codes.Error))
+		Expect(span.Attributes).To(ContainElement(attribute.String("error.type", "NoSuchKey")))
+		Expect(span.Events).To(ContainElement(HaveField("Name", "exception")))
+
+		errs := metricNamed("simple_s3.operation.errors").Data.(metricdata.Sum[int64])
+		Expect(errs.DataPoints).To(HaveLen(1))
+		Expect(errs.DataPoints[0].Value).To(Equal(int64(1)))
+		Expect(errs.DataPoints[0].Attributes.ToSlice()).To(ConsistOf(
+			attribute.String("error.type", "NoSuchKey"),
+			attribute.String("simple_s3.operation", "FetchObject"),
+		))
+	})
+
+	It("records latency and bytes transferred", func() {
+		payload := []byte(strings.Repeat("x", 1024))
+		Expect(sut.PutObject(ctx, "bucket-a", "key-a", bytes.NewReader(payload))).To(Succeed())
+		_, err := sut.FetchObject(ctx, "key-a", "bucket-a")
+		Expect(err).NotTo(HaveOccurred())
+
+		transferred := metricNamed("simple_s3.operation.bytes").Data.(metricdata.Sum[int64])
+		byOp := map[string]int64{}
+		for _, dp := range transferred.DataPoints {
+			op, _ := dp.Attributes.Value("simple_s3.operation")
+			byOp[op.AsString()] = dp.Value
+		}
+		Expect(byOp).To(Equal(map[string]int64{"PutObject": 1024, "FetchObject": 1024}))
+
+		duration := metricNamed("simple_s3.operation.duration").Data.(metricdata.Histogram[float64])
+		var calls uint64
+		for _, dp := range duration.DataPoints {
+			calls += dp.Count
+		}
+		Expect(calls).To(Equal(uint64(3)))
+	})
+
+	It("counts directory uploads under PutObject", func() {
+		dir := GinkgoT().TempDir()
+		writeTree(dir, map[string]string{"a.txt": "aaa", "b/c.txt": "cc"})
+
+		_, err := sut.UploadDirectory(ctx, dir, "bucket-a", "site")
+		Expect(err).NotTo(HaveOccurred())
+
+		span := spanNamed("simple_s3.UploadDirectory")
+		Expect(span.Attributes).To(ContainElement(attribute.Int64("simple_s3.size", 5)))
+		for _, s := range exporter.GetSpans() {
+			if s.Name == "simple_s3.PutObject" {
+				Expect(s.Parent.SpanID()).To(Equal(span.SpanContext.SpanID()))
+			}
+		}
+
+		transferred := metricNamed("simple_s3.operation.bytes").Data.(metricdata.Sum[int64])
+		Expect(transferred.DataPoints).To(HaveLen(1))
+		Expect(transferred.DataPoints[0].Value).To(Equal(int64(5)))
+	})
+
+	It("is a no-op when not configured", func() {
+		api := &stubAPI{}
+		for _, c := range []*S3{NewFromAPI(api), {api: api}} {
+			Expect(c.DeleteObject(ctx, "bucket-a", "key-a")).To(MatchError(ContainSubstring("unexpected DeleteObject")))
+		}
+		Expect(exporter.GetSpans()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+const (
+	// defaultPartSize is used for both the part size and the multipart threshold when unset.
+	defaultPartSize int64 = 100 * 1024 * 1024
+	// minPartSize is the smallest part size S3 accepts for all but the last part.
+	minPartSize int64 = 5 * 1024 * 1024
+	// maxPartSize is the largest part, and the largest object in a single request, S3 accepts.
+	maxPartSize int64 = 5 * 1024 * 1024 * 1024
+	// maxUploadParts is the maximum number of parts in a multipart upload.
+	maxUploadParts int64 = 10000
+	// partSizeAlignment is the granularity scaled part sizes are rounded up to.
+	partSizeAlignment int64 = 1024 * 1024
+)
+
+// transferManager returns the transfer manager shared by all uploads, creating it on first use
+// unless one has been set.
+func (s *S3) transferManager() transferManagerAPI {
+	s.transferOnce.Do(func() {
+		if s.transfer == nil {
+			s.transfer = transfermanager.New(s.client(), s.transferOptions)
+		}
+	})
+	return s.transfer
+}
+
+// transferOptions applies the client-level multipart settings to the transfer manager.
+func (s *S3) transferOptions(o *transfermanager.Options) {
+	m := s.multipartOptions(types.MultipartOptions{})
+	o.PartSizeBytes = m.PartSize
+	// The transfer manager does not read MultipartUploadThreshold; uploads apply the threshold
+	// through PartSizeBytes, as uploadPartSize describes.
+	o.MultipartUploadThreshold = m.Threshold
+	o.Concurrency = m.Concurrency
+}
+
+// multipartOptions merges per-call overrides with the client-level settings and library defaults.
+// The part size and threshold are capped at the largest size S3 accepts in one request.
+func (s *S3) multipartOptions(call types.MultipartOptions) types.MultipartOptions {
+	client := s.options.Multipart
+	return types.MultipartOptions{
+		PartSize:    min(firstPositive(call.PartSize, client.PartSize, defaultPartSize), maxPartSize),
+		Threshold:   min(firstPositive(call.Threshold, client.Threshold, defaultPartSize), maxPartSize),
+		Concurrency: int(firstPositive(int64(call.Concurrency), int64(client.Concurrency))),
+	}
+}
+
+// uploadPartSize returns the part size for an upload of the given size, or -1 if unknown.
+//
+// The transfer manager sends a single request whenever the body is smaller than one part, so objects
+// below the threshold use a part size just larger than the object. Otherwise the part size is capped
+// at the threshold so objects at or above it are split, raised to the S3 minimum and scaled so the
+// upload fits within the maximum part count. Objects smaller than the S3 minimum part size are
+// therefore always sent in a single request.
+func uploadPartSize(m types.MultipartOptions, size int64) int64 {
+	if size >= 0 && size < m.Threshold {
+		return max(size+1, minPartSize)
+	}
+
+	partSize := max(min(m.PartSize, m.Threshold), minPartSize)
+	if size > partSize*maxUploadParts {
+		partSize = (size + maxUploadParts - 1) / maxUploadParts
+		partSize = (partSize + partSizeAlignment - 1) / partSizeAlignment * partSizeAlignment
+	}
+	return partSize
+}
+
+// seekerLen returns the number of bytes from the start of body to its end, leaving it rewound.
+func seekerLen(body io.Seeker) (int64, error) {
+	size, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// firstPositive returns the first positive value, or 0 if there is none.
+func firstPositive(values ...int64) int64 {
+	for _, v := range values {
+		if v > 0 {
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 Drew Hudson-Viles.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_s3
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/drewbernetes/simple-s3/pkg/types"
+)
+
+const mib = 1024 * 1024
+
+var _ = Describe("Transfer settings", func() {
+
+	Describe("uploadPartSize", func() {
+		defaults := types.MultipartOptions{PartSize: 100 * mib, Threshold: 100 * mib}
+
+		DescribeTable("selects a part size",
+			func(m types.MultipartOptions, size, expected int64) {
+				Expect(uploadPartSize(m, size)).To(Equal(expected))
+			},
+			Entry("single request below the threshold", defaults, int64(10*mib), int64(10*mib+1)),
+			Entry("minimum part size for tiny objects", defaults, int64(10), minPartSize),
+			Entry("configured part size at the threshold", defaults, int64(100*mib), int64(100*mib)),
+			Entry("configured part size for unknown sizes", defaults, int64(-1), int64(100*mib)),
+			Entry("raised to the S3 minimum", types.MultipartOptions{PartSize: mib, Threshold: mib}, int64(50*mib), minPartSize),
+			Entry("capped at a lower threshold", types.MultipartOptions{PartSize: 100 * mib, Threshold: 20 * mib}, int64(50*mib), int64(20*mib)),
+			Entry("capped at the S3 minimum for a tiny threshold", types.MultipartOptions{PartSize: 100 * mib, Threshold: 512}, int64(50*mib), minPartSize),
+			Entry("scaled beyond 10,000 parts", types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}, int64(100000*mib), int64(10*mib)),
+			Entry("scaled and aligned to MiB", types.MultipartOptions{PartSize: 5 * mib, Threshold: 5 * mib}, int64(50001*mib), int64(6*mib)),
+		)
+	})
+
+	Describe("multipartOptions", func() {
+		It("prefers per-call settings, then client settings, then defaults", func() {
+			sut := &S3{options: Options{Multipart: types.MultipartOptions{PartSize: 16 * mib, Concurrency: 8}}}
+
+			m := sut.multipartOptions(types.MultipartOptions{})
+			Expect(m).To(Equal(types.MultipartOptions{PartSize: 16 * mib, Threshold: defaultPartSize, Concurrency: 8}))
+
+			m = sut.multipartOptions(types.MultipartOptions{PartSize: 32 * mib, Threshold: 64 * mib, Concurrency: 2})
+			Expect(m).To(Equal(types.MultipartOptions{PartSize: 32 * mib, Threshold: 64 * mib, Concurrency: 2}))
+		})
+
+		It("caps the part size and threshold at the largest single request", func() {
+			sut := &S3{options: Options{Multipart: types.MultipartOptions{PartSize: 8 * 1024 * mib, Threshold: 10 * 1024 * mib}}}
+
+			m := sut.multipartOptions(types.MultipartOptions{})
+			Expect(m.PartSize).To(Equal(maxPartSize))
+			Expect(m.Threshold).To(Equal(maxPartSize))
+			Expect(uploadPartSize(m, 6*1024*mib-1)).To(Equal(maxPartSize))
+		})
+	})
+
+	Describe("PutObject", func() {
+		It("reuses a single transfer manager built from client settings", func() {
+			sut := NewFromAPI(&stubAPI{}, func(o *Options) {
+				o.Multipart = types.MultipartOptions{PartSize: 8 * mib, Threshold: 16 * mib, Concurrency: 3}
+			})
+			o := &transfermanager.Options{}
+			sut.transferOptions(o)
+			Expect(o.PartSizeBytes).To(Equal(int64(8 * mib)))
+			Expect(o.MultipartUploadThreshold).To(Equal(int64(16 * mib)))
+			Expect(o.Concurrency).To(Equal(3))
+
+			manager := sut.transferManager()
+			Expect(manager).To(BeAssignableToTypeOf(&transfermanager.Client{}))
+			Expect(sut.transferManager()).To(BeIdenticalTo(manager))
+		})
+
+		It("applies per-call overrides and records the body length", func() {
+			sut, _ := newStubbed()
+			fakeClient := &fakeTransferManager{}
+			sut.transfer = fakeClient
+
+			body := bytes.NewReader(make([]byte, 8*mib))
+			err := sut.PutObject(context.Background(), "bucket-a", "key-a", body, func(o *types.PutObjectOptions) {
+				o.Multipart = types.MultipartOptions{PartSize: 16 * mib, Threshold: 6 * mib, Concurrency: 12}
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aws.ToInt64(fakeClient.uploadInput.ContentLength)).To(Equal(int64(8 * mib)))
+			// A part smaller than the body makes the transfer manager upload it in parts.
+			Expect(fakeClient.uploadOptions.PartSizeBytes).To(Equal(int64(6 * mib)))
+			Expect(fakeClient.uploadOptions.PartSizeBytes).To(BeNumerically("<", 8*mib))
+			Expect(fakeClient.uploadOptions.MultipartUploadThreshold).To(Equal(int64(6 * mib)))
+			Expect(fakeClient.uploadOptions.Concurrency).To(Equal(12))
+		})
+	})
+
+	Describe("PutObjectStream", func() {
+		It("scales the part size from a declared content length", func() {
+			sut := &S3{options: Options{Multipart: types.MultipartOptions{PartSize: 5 * mib}}}
+			fakeClient := &fakeTransferManager{}
+			sut.transfer = fakeClient
+
+			err := sut.PutObjectStream(context.Background(), "bucket-a", "key-a", strings.NewReader("stream"), func(o *types.PutObjectOptions) {
+				o.ContentLength = 100000 * mib
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.uploadOptions.PartSizeBytes).To(Equal(int64(10 * mib)))
+			Expect(aws.ToInt64(fakeClient.uploadInput.ContentLength)).To(Equal(int64(100000 * mib)))
+		})
+
+		It("leaves the content length unset for unknown sizes", func() {
+			sut, _ := newStubbed()
+			fakeClient := &fakeTransferManager{}
+			sut.transfer = fakeClient
+
+			Expect(sut.PutObjectStream(context.Background(), "bucket-a", "key-a", strings.NewReader("stream"))).To(Succeed())
+			Expect(fakeClient.uploadInput.ContentLength).To(BeNil())
+			Expect(fakeClient.uploadOptions.PartSizeBytes).To(Equal(defaultPartSize))
+		})
+	})
+
+	Describe("New", func() {
+		It("keeps the client-level multipart settings", func() {
+			sut, err := New(context.Background(), "http://localhost:9000", "ak", "sk", "", func(o *Options) {
+				o.Multipart.PartSize = 64 * mib
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sut.multipartOptions(types.MultipartOptions{}).PartSize).To(Equal(int64(64 * mib)))
+		})
+	})
+})